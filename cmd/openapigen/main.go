@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"configuration-management-service/internal/schema/builtin"
+	"configuration-management-service/internal/schema/openapi"
+	"flag"
+	"log"
+	"os"
+)
+
+// openapigen regenerates the config type components of the API contract
+// from the built-in schemas. With -check it only reports whether the file
+// is up to date.
+func main() {
+	path := flag.String("file", "api/openapi.yml", "OpenAPI document to update")
+	check := flag.Bool("check", false, "exit non-zero when the document is out of date instead of writing it")
+	flag.Parse()
+
+	doc, err := os.ReadFile(*path)
+	if err != nil {
+		log.Fatalf("openapigen: %v", err)
+	}
+	block, err := openapi.Components(builtin.Schemas())
+	if err != nil {
+		log.Fatalf("openapigen: %v", err)
+	}
+	out, err := openapi.Splice(doc, block)
+	if err != nil {
+		log.Fatalf("openapigen: %s: %v", *path, err)
+	}
+
+	if bytes.Equal(doc, out) {
+		log.Printf("openapigen: %s is up to date", *path)
+		return
+	}
+	if *check {
+		log.Fatalf("openapigen: %s is out of date, run go run ./cmd/openapigen", *path)
+	}
+	if err := os.WriteFile(*path, out, 0o644); err != nil {
+		log.Fatalf("openapigen: %v", err)
+	}
+	log.Printf("openapigen: updated %s", *path)
+}
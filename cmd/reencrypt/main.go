@@ -0,0 +1,46 @@
+package main
+
+import (
+	"configuration-management-service/db"
+	"configuration-management-service/internal/remote_config"
+	"configuration-management-service/internal/schema"
+	"configuration-management-service/pkg/app"
+	"configuration-management-service/pkg/config"
+	"context"
+	"log"
+	"time"
+)
+
+// reencrypt re-seals every secret field with the active key of the keyring.
+// Run it after adding a new key and making it active; once it reports
+// success the old keys can be removed from the keyring.
+func main() {
+	cfg := config.Load()
+
+	sqlDB, err := db.Open(db.Config{DSN: cfg.DSN})
+	if err != nil {
+		log.Fatalf("db: %v", err)
+	}
+	defer sqlDB.Close()
+	// Booting seeds the schema registry, so bring the tables up to date first.
+	if err := db.MigrateSQLFiles(sqlDB, "db/migrations"); err != nil {
+		log.Fatalf("migrate: %v", err)
+	}
+
+	schemaModule, err := schema.InitModule(context.Background(), sqlDB, nil, nil)
+	if err != nil {
+		log.Fatalf("boot: %v", err)
+	}
+	m, err := remote_config.InitModule(sqlDB, cfg, nil, app.SchemaSource(schemaModule.Service()))
+	if err != nil {
+		log.Fatalf("boot: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	n, err := m.Reencrypt(ctx)
+	if err != nil {
+		log.Fatalf("reencrypt: %v", err)
+	}
+	log.Printf("reencrypt: %d version(s) re-sealed with key %q", n, m.ActiveKeyID())
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"configuration-management-service/internal/schema/builtin"
+	"configuration-management-service/internal/schema/gogen"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// typegen generates Go types for config types. By default it renders the
+// built-in schemas into pkg/configclient; with -api it renders the latest
+// schema of every type registered in a running service. With -check it
+// only reports whether the file is up to date.
+func main() {
+	path := flag.String("out", "pkg/configclient/types_gen.go", "Go file to write")
+	pkg := flag.String("pkg", "configclient", "package name of the generated file")
+	api := flag.String("api", "", "base URL of a running service to read the registered schemas from (e.g. http://localhost:8080)")
+	key := flag.String("key", os.Getenv("S2S_STATIC_KEY"), "API key for -api")
+	check := flag.Bool("check", false, "exit non-zero when the file is out of date instead of writing it")
+	flag.Parse()
+
+	schemas, source := builtin.Schemas(), "the built-in schemas"
+	if *api != "" {
+		var err error
+		if schemas, err = registered(*api, *key); err != nil {
+			log.Fatalf("typegen: %v", err)
+		}
+		source = "the schemas registered at " + *api
+	}
+
+	out, err := gogen.Generate(gogen.Options{Package: *pkg, Source: source}, schemas)
+	if err != nil {
+		log.Fatalf("typegen: %v", err)
+	}
+
+	current, err := os.ReadFile(*path)
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatalf("typegen: %v", err)
+	}
+	if bytes.Equal(current, out) {
+		log.Printf("typegen: %s is up to date", *path)
+		return
+	}
+	if *check {
+		log.Fatalf("typegen: %s is out of date, run go run ./cmd/typegen", *path)
+	}
+	if err := os.WriteFile(*path, out, 0o644); err != nil {
+		log.Fatalf("typegen: %v", err)
+	}
+	log.Printf("typegen: updated %s", *path)
+}
+
+// registered reads the latest schema of every type from GET /api/schemas.
+func registered(baseURL, key string) (map[string]string, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(baseURL, "/")+"/api/schemas", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Api-Key", key)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET /api/schemas: %s", res.Status)
+	}
+
+	var body struct {
+		Schemas []struct {
+			Type   string          `json:"type"`
+			Schema json.RawMessage `json:"schema"`
+		} `json:"schemas"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(body.Schemas))
+	for _, s := range body.Schemas {
+		out[s.Type] = string(s.Schema)
+	}
+	return out, nil
+}
@@ -9,7 +9,20 @@ import (
 	"strings"
 )
 
+// MigrateSQLFiles applies every *.sql file in dir in lexical order. Applied
+// files are recorded in schema_migrations so non-idempotent statements such
+// as ALTER TABLE run exactly once.
 func MigrateSQLFiles(db *sql.DB, dir string) error {
+	const qTrack = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			name TEXT PRIMARY KEY,
+			applied_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+		)
+	`
+	if _, err := db.Exec(qTrack); err != nil {
+		return fmt.Errorf("migrations: %w", err)
+	}
+
 	ents, err := os.ReadDir(dir)
 	if err != nil {
 		return err
@@ -23,11 +36,30 @@ func MigrateSQLFiles(db *sql.DB, dir string) error {
 	}
 	sort.Strings(names)
 	for _, n := range names {
+		var applied int
+		if err := db.QueryRow(`SELECT COUNT(1) FROM schema_migrations WHERE name = ?`, n).Scan(&applied); err != nil {
+			return fmt.Errorf("migrations %s: %w", n, err)
+		}
+		if applied > 0 {
+			continue
+		}
 		b, err := os.ReadFile(filepath.Join(dir, n))
 		if err != nil {
 			return err
 		}
-		if _, err := db.Exec(string(b)); err != nil {
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("migrations %s: %w", n, err)
+		}
+		if _, err := tx.Exec(string(b)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrations %s: %w", n, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations(name) VALUES(?)`, n); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrations %s: %w", n, err)
+		}
+		if err := tx.Commit(); err != nil {
 			return fmt.Errorf("migrations %s: %w", n, err)
 		}
 	}
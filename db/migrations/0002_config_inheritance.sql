@@ -0,0 +1,3 @@
+ALTER TABLE configs ADD COLUMN extends TEXT;
+ALTER TABLE configs ADD COLUMN base_version INTEGER;
+CREATE INDEX IF NOT EXISTS idx_configs_extends ON configs(extends);
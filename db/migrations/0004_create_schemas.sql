@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS schemas (
+    type TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    schema TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+    PRIMARY KEY (type, version)
+);
+
+ALTER TABLE configs ADD COLUMN schema_version INTEGER;
+-- Every config written so far was validated by a built-in schema, which is
+-- seeded as version 1 of its type.
+UPDATE configs SET schema_version = 1 WHERE schema_version IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE schemas ADD COLUMN compatibility TEXT NOT NULL DEFAULT 'backward';
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS data_migrations (
+    id TEXT PRIMARY KEY,
+    type TEXT NOT NULL,
+    operations TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+);
+
+ALTER TABLE configs ADD COLUMN migration_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_configs_migration_id ON configs(migration_id);
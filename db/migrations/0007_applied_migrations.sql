@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS applied_migrations (
+    id TEXT PRIMARY KEY,
+    applied_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+);
+
+INSERT OR IGNORE INTO applied_migrations(id, applied_at)
+SELECT migration_id, MIN(created_at)
+FROM configs
+WHERE migration_id IS NOT NULL
+GROUP BY migration_id;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS seeded_schemas (
+    type TEXT PRIMARY KEY,
+    version INTEGER NOT NULL
+);
+
+INSERT OR IGNORE INTO seeded_schemas(type, version)
+SELECT type, 1
+FROM schemas
+WHERE version = 1
+  AND type IN ('experiment_config', 'feature_toggle', 'notification_policy', 'rate_limit_policy',
+               'schedule_rule', 'segment', 'service_client', 'threshold_policy');
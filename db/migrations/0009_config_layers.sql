@@ -0,0 +1,15 @@
+-- The layer range an active experiment_config version takes, so writes can
+-- check for overlaps in their own transaction. Versions that take their layer
+-- from a base are filled in by their next write.
+ALTER TABLE configs ADD COLUMN layer_name TEXT;
+ALTER TABLE configs ADD COLUMN layer_start REAL;
+ALTER TABLE configs ADD COLUMN layer_end REAL;
+CREATE INDEX IF NOT EXISTS idx_configs_layer_name ON configs(layer_name);
+
+UPDATE configs
+SET layer_name = json_extract(data, '$.layer.name'),
+    layer_start = json_extract(data, '$.layer.start'),
+    layer_end = json_extract(data, '$.layer.end')
+WHERE type = 'experiment_config'
+  AND json_extract(data, '$.active') = 1
+  AND json_extract(data, '$.layer.name') IS NOT NULL;
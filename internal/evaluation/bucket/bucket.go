@@ -0,0 +1,33 @@
+// Package bucket places users in stable rollout buckets.
+//
+// A user's bucket depends only on the seed (the flag or experiment name) and
+// the user key, so it never changes between evaluations, servers or client
+// versions. Raising a percentage only adds the buckets above the old bound:
+// users already in stay in, nobody is reshuffled.
+package bucket
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Scale is the number of buckets; a percentage p covers the buckets below
+// p*Scale/100, so rollouts are exact to a hundredth of a percent.
+const Scale = 10000
+
+// Of returns the bucket of key for seed, in [0, Scale): the first 8 bytes
+// of SHA-256(seed + "." + key) taken modulo Scale.
+func Of(seed, key string) int {
+	sum := sha256.Sum256([]byte(seed + "." + key))
+	return int(binary.BigEndian.Uint64(sum[:8]) % Scale)
+}
+
+// In reports whether bucket b falls within percentage pct (0-100).
+func In(b int, pct float64) bool {
+	return float64(b) < pct*Scale/100
+}
+
+// Percent returns bucket b as a percentage, e.g. 4217 is 42.17.
+func Percent(b int) float64 {
+	return float64(b) * 100 / Scale
+}
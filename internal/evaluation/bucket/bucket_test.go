@@ -0,0 +1,56 @@
+package bucket
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf(t *testing.T) {
+	// Buckets are part of the contract with every client: changing the hash
+	// reshuffles every rollout.
+	assert.Equal(t, 3399, Of("new_checkout", "user-1"))
+	assert.Equal(t, 1141, Of("new_checkout", "user-2"))
+	assert.Equal(t, 6204, Of("dark_mode", "user-1"))
+}
+
+func TestIn(t *testing.T) {
+	cases := []struct {
+		name   string
+		bucket int
+		pct    float64
+		want   bool
+	}{
+		{name: "when percentage is 0 should hold no bucket", bucket: 0, pct: 0, want: false},
+		{name: "when percentage is 100 should hold the last bucket", bucket: Scale - 1, pct: 100, want: true},
+		{name: "when bucket is just below the bound should be in", bucket: 2499, pct: 25, want: true},
+		{name: "when bucket is on the bound should be out", bucket: 2500, pct: 25, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, In(tc.bucket, tc.pct))
+		})
+	}
+}
+
+func TestIn_RaisingPercentageOnlyAddsUsers(t *testing.T) {
+	in := map[string]bool{}
+	total := 0
+	for pct := 0.0; pct <= 100; pct += 5 {
+		count := 0
+		for i := 0; i < 2000; i++ {
+			key := "user-" + strconv.Itoa(i)
+			if In(Of("new_checkout", key), pct) {
+				count++
+				in[key] = true
+			} else {
+				assert.False(t, in[key], "%s left the rollout at %v%%", key, pct)
+			}
+		}
+		assert.GreaterOrEqual(t, count, total)
+		total = count
+	}
+	assert.Equal(t, 2000, total)
+}
@@ -0,0 +1,219 @@
+// Package events records server-side evaluations: an event per flag value or
+// experiment variant served, buffered in memory and written in batches to
+// an EventSink, plus counters per config version and variant.
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"configuration-management-service/internal/evaluation/model"
+)
+
+// Options tune a Recorder. Zero values take the defaults.
+type Options struct {
+	// HashKey keys the HMAC-SHA256 of user keys, so the log cannot be
+	// joined back to users by hashing known keys without it.
+	HashKey []byte
+	// Buffer is how many events wait for the sink before new ones are
+	// dropped from the log (default 8192).
+	Buffer int
+	// Batch is the most events written at once (default 512).
+	Batch int
+	// FlushInterval bounds how long an event waits for its batch (default 1s).
+	FlushInterval time.Duration
+}
+
+// Recorder buffers events for its sink and counts them. Record never blocks
+// an evaluation: when the sink falls behind and the buffer is full the event
+// is counted but left out of the log.
+type Recorder struct {
+	sink    EventSink
+	hashKey []byte
+	batch   int
+	flush   time.Duration
+	now     func() time.Time
+
+	mu       sync.RWMutex // guards closed against Record sending on queue
+	closed   bool
+	queue    chan model.Event
+	done     chan struct{}
+	dropped  atomic.Int64
+	counters counters
+}
+
+func NewRecorder(sink EventSink, opts Options) *Recorder {
+	if opts.Buffer <= 0 {
+		opts.Buffer = 8192
+	}
+	if opts.Batch <= 0 {
+		opts.Batch = 512
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = time.Second
+	}
+	r := &Recorder{
+		sink:     sink,
+		hashKey:  opts.HashKey,
+		batch:    opts.Batch,
+		flush:    opts.FlushInterval,
+		now:      time.Now,
+		queue:    make(chan model.Event, opts.Buffer),
+		done:     make(chan struct{}),
+		counters: counters{since: time.Now().UTC(), byKey: map[counterKey]*model.EventCounter{}},
+	}
+	go r.run()
+	return r
+}
+
+// Record stamps e, replaces the user key with its hash and queues it.
+func (r *Recorder) Record(userKey string, e model.Event) {
+	e.Time = r.now().UTC()
+	e.User = r.hash(userKey)
+	r.counters.add(e)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		r.dropped.Add(1)
+		return
+	}
+	select {
+	case r.queue <- e:
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// Counters returns the counters of the config name, or of every config
+// when name is empty, sorted by kind, name, version and variant.
+func (r *Recorder) Counters(name string) model.EventCounters {
+	out := r.counters.snapshot(name)
+	out.Dropped = r.dropped.Load()
+	return out
+}
+
+// Close writes the buffered events and closes the sink. ctx bounds the wait
+// for the last batches.
+func (r *Recorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return r.sink.Close()
+}
+
+func (r *Recorder) hash(key string) string {
+	if key == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// run moves queued events to the sink in batches until the queue closes.
+func (r *Recorder) run() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.flush)
+	defer ticker.Stop()
+
+	batch := make([]model.Event, 0, r.batch)
+	write := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.sink.Write(batch); err != nil {
+			r.dropped.Add(int64(len(batch)))
+			log.Printf("events: write %d events: %v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case e, ok := <-r.queue:
+			if !ok {
+				write()
+				return
+			}
+			batch = append(batch, e)
+			if len(batch) == r.batch {
+				write()
+			}
+		case <-ticker.C:
+			write()
+		}
+	}
+}
+
+type counterKey struct {
+	kind, name string
+	version    int
+	variant    string
+}
+
+type counters struct {
+	since time.Time
+	mu    sync.Mutex
+	byKey map[counterKey]*model.EventCounter
+}
+
+func (c *counters) add(e model.Event) {
+	k := counterKey{kind: e.Kind, name: e.Name, version: e.Version, variant: e.Variant}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, ok := c.byKey[k]
+	if !ok {
+		n = &model.EventCounter{Kind: e.Kind, Name: e.Name, Version: e.Version, Variant: e.Variant, Reasons: map[string]int64{}}
+		c.byKey[k] = n
+	}
+	n.Count++
+	n.Reasons[e.Reason]++
+}
+
+func (c *counters) snapshot(name string) model.EventCounters {
+	c.mu.Lock()
+	out := model.EventCounters{Since: c.since, Counters: []model.EventCounter{}}
+	for k, n := range c.byKey {
+		if name != "" && k.name != name {
+			continue
+		}
+		cp := *n
+		cp.Reasons = make(map[string]int64, len(n.Reasons))
+		for r, v := range n.Reasons {
+			cp.Reasons[r] = v
+		}
+		out.Counters = append(out.Counters, cp)
+	}
+	c.mu.Unlock()
+
+	sort.Slice(out.Counters, func(i, j int) bool {
+		a, b := out.Counters[i], out.Counters[j]
+		switch {
+		case a.Kind != b.Kind:
+			return a.Kind < b.Kind
+		case a.Name != b.Name:
+			return a.Name < b.Name
+		case a.Version != b.Version:
+			return a.Version < b.Version
+		}
+		return a.Variant < b.Variant
+	})
+	return out
+}
@@ -0,0 +1,134 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"configuration-management-service/internal/evaluation/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memorySink keeps what it is given; block, when set, holds every Write
+// until it is closed.
+type memorySink struct {
+	mu      sync.Mutex
+	batches [][]model.Event
+	closed  bool
+	block   chan struct{}
+	err     error
+}
+
+func (m *memorySink) Write(events []model.Event) error {
+	if m.block != nil {
+		<-m.block
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches = append(m.batches, append([]model.Event(nil), events...))
+	return m.err
+}
+
+func (m *memorySink) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
+
+func (m *memorySink) events() []model.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []model.Event
+	for _, b := range m.batches {
+		out = append(out, b...)
+	}
+	return out
+}
+
+func flag(name string, version int, variant, reason string) model.Event {
+	return model.Event{Kind: model.EventFlag, Name: name, Version: version, Variant: variant, Reason: reason}
+}
+
+func TestRecorder(t *testing.T) {
+	sink := &memorySink{}
+	r := NewRecorder(sink, Options{HashKey: []byte("k"), Batch: 2, FlushInterval: time.Hour})
+	at := time.Date(2026, 10, 19, 4, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return at }
+
+	r.Record("user-1", flag("checkout", 3, "true", model.ReasonRollout))
+	r.Record("user-2", flag("checkout", 3, "false", model.ReasonRollout))
+	r.Record("", flag("checkout", 3, "false", model.ReasonMissingKey))
+	r.Record("user-1", model.Event{Kind: model.EventExperiment, Name: "button", Version: 2, Variant: "green", Reason: model.ReasonAssigned})
+
+	assert.NoError(t, r.Close(context.Background()))
+	assert.True(t, sink.closed)
+	got := sink.events()
+	if assert.Len(t, got, 4) {
+		assert.Equal(t, at, got[0].Time)
+		assert.Len(t, got[0].User, 32)
+		assert.NotContains(t, got[0].User, "user-1")
+		assert.Equal(t, got[0].User, got[3].User, "a user should hash the same in every event")
+		assert.NotEqual(t, got[0].User, got[1].User)
+		assert.Empty(t, got[2].User, "no user key should leave the user empty")
+	}
+	assert.Len(t, sink.batches, 2, "events should be written in batches")
+
+	other := NewRecorder(&memorySink{}, Options{HashKey: []byte("other")})
+	assert.NotEqual(t, r.hash("user-1"), other.hash("user-1"), "the hash should depend on the key")
+	assert.NoError(t, other.Close(context.Background()))
+
+	counters := r.Counters("checkout")
+	assert.Equal(t, []model.EventCounter{
+		{Kind: "flag", Name: "checkout", Version: 3, Variant: "false", Count: 2, Reasons: map[string]int64{"rollout": 1, "missing_user_key": 1}},
+		{Kind: "flag", Name: "checkout", Version: 3, Variant: "true", Count: 1, Reasons: map[string]int64{"rollout": 1}},
+	}, counters.Counters)
+	assert.Len(t, r.Counters("").Counters, 3)
+	assert.Empty(t, r.Counters("nope").Counters)
+
+	// Recording after Close counts the event without writing it.
+	r.Record("user-3", flag("checkout", 3, "true", model.ReasonRollout))
+	assert.Equal(t, int64(1), r.Counters("").Dropped)
+	assert.Equal(t, int64(2), r.Counters("checkout").Counters[1].Count)
+}
+
+func TestRecorder_drops(t *testing.T) {
+	sink := &memorySink{block: make(chan struct{})}
+	r := NewRecorder(sink, Options{Buffer: 2, Batch: 1, FlushInterval: time.Hour})
+
+	// The first event is taken into a batch and blocks in Write; two more
+	// fill the buffer and the rest are dropped.
+	r.Record("user-1", flag("checkout", 1, "true", model.ReasonEnabled))
+	assert.Eventually(t, func() bool { return len(r.queue) == 0 }, time.Second, time.Millisecond)
+	for i := 0; i < 5; i++ {
+		r.Record("user-1", flag("checkout", 1, "true", model.ReasonEnabled))
+	}
+	assert.Equal(t, int64(3), r.Counters("").Dropped)
+	assert.Equal(t, int64(6), r.Counters("").Counters[0].Count, "dropped events should still be counted")
+
+	close(sink.block)
+	assert.NoError(t, r.Close(context.Background()))
+	assert.Len(t, sink.events(), 3)
+}
+
+func TestRecorder_writeError(t *testing.T) {
+	sink := &memorySink{err: errors.New("disk full")}
+	r := NewRecorder(sink, Options{})
+	r.Record("user-1", flag("checkout", 1, "true", model.ReasonEnabled))
+	assert.NoError(t, r.Close(context.Background()))
+	assert.Equal(t, int64(1), r.Counters("").Dropped)
+}
+
+func TestRecorder_Close_timeout(t *testing.T) {
+	sink := &memorySink{block: make(chan struct{})}
+	defer close(sink.block)
+	r := NewRecorder(sink, Options{Batch: 1})
+	r.Record("user-1", flag("checkout", 1, "true", model.ReasonEnabled))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, r.Close(ctx), context.DeadlineExceeded)
+}
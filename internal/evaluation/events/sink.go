@@ -0,0 +1,149 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"configuration-management-service/internal/evaluation/model"
+)
+
+// EventSink stores batches of evaluation events. Write is called from one
+// goroutine at a time; Close flushes and releases the sink.
+type EventSink interface {
+	Write(events []model.Event) error
+	Close() error
+}
+
+// NopSink discards every event.
+type NopSink struct{}
+
+func (NopSink) Write([]model.Event) error { return nil }
+func (NopSink) Close() error              { return nil }
+
+const (
+	activeFile    = "events.ndjson"
+	rotatedPrefix = "events-"
+	rotatedSuffix = ".ndjson"
+)
+
+// NDJSONSink appends events, one JSON object per line, to events.ndjson in
+// Dir. Once a batch would take the file past MaxBytes the file is renamed to
+// events-<UTC time>.ndjson and a new one started; only the MaxFiles newest
+// rotated files are kept.
+type NDJSONSink struct {
+	dir      string
+	maxBytes int64
+	maxFiles int
+	now      func() time.Time
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+func NewNDJSONSink(dir string, maxBytes int64, maxFiles int) (*NDJSONSink, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("event log: %w", err)
+	}
+	s := &NDJSONSink{dir: dir, maxBytes: maxBytes, maxFiles: maxFiles, now: time.Now}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *NDJSONSink) Write(events []model.Event) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return os.ErrClosed
+	}
+	if s.size > 0 && s.size+int64(buf.Len()) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			if s.f == nil {
+				return err
+			}
+			// The active file is open again: keep the batch, rotate next time.
+			log.Printf("events: rotate: %v", err)
+		}
+	}
+	n, err := s.f.Write(buf.Bytes())
+	s.size += int64(n)
+	return err
+}
+
+func (s *NDJSONSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+func (s *NDJSONSink) open() error {
+	f, err := os.OpenFile(filepath.Join(s.dir, activeFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("event log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("event log: %w", err)
+	}
+	s.f, s.size = f, info.Size()
+	return nil
+}
+
+// rotate renames the active file aside, starts a new one and prunes the
+// oldest rotated files. When the rename fails the active file is opened
+// again, so the sink keeps appending to it.
+func (s *NDJSONSink) rotate() error {
+	if err := s.f.Close(); err != nil {
+		return fmt.Errorf("event log: %w", err)
+	}
+	s.f = nil
+	stamp := s.now().UTC().Format("20060102T150405.000000000Z")
+	rotated := filepath.Join(s.dir, rotatedPrefix+stamp+rotatedSuffix)
+	if err := os.Rename(filepath.Join(s.dir, activeFile), rotated); err != nil {
+		return errors.Join(fmt.Errorf("event log: %w", err), s.open())
+	}
+	if err := s.open(); err != nil {
+		return err
+	}
+	return s.prune()
+}
+
+func (s *NDJSONSink) prune() error {
+	names, err := filepath.Glob(filepath.Join(s.dir, rotatedPrefix+"*"+rotatedSuffix))
+	if err != nil {
+		return err
+	}
+	// The timestamps sort in time order.
+	sort.Strings(names)
+	for len(names) > s.maxFiles {
+		if err := os.Remove(names[0]); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("event log: %w", err)
+		}
+		names = names[1:]
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"configuration-management-service/internal/evaluation/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lines(t *testing.T, path string) []model.Event {
+	t.Helper()
+	f, err := os.Open(path)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	defer f.Close()
+	var out []model.Event
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e model.Event
+		assert.NoError(t, json.Unmarshal(sc.Bytes(), &e))
+		out = append(out, e)
+	}
+	return out
+}
+
+func TestNDJSONSink(t *testing.T) {
+	dir := t.TempDir()
+	// One event encodes to well under 200 bytes; two do not fit in 200.
+	sink, err := NewNDJSONSink(dir, 200, 2)
+	assert.NoError(t, err)
+	at := time.Date(2026, 10, 19, 4, 0, 0, 0, time.UTC)
+	sink.now = func() time.Time { at = at.Add(time.Second); return at }
+
+	for v := 1; v <= 5; v++ {
+		assert.NoError(t, sink.Write([]model.Event{{Kind: model.EventFlag, Name: "checkout", Version: v, Variant: "true", Reason: model.ReasonRollout}}))
+	}
+	assert.NoError(t, sink.Close())
+	assert.ErrorIs(t, sink.Write([]model.Event{{}}), os.ErrClosed)
+
+	rotated, _ := filepath.Glob(filepath.Join(dir, "events-*.ndjson"))
+	assert.Equal(t, []string{
+		filepath.Join(dir, "events-20261019T040003.000000000Z.ndjson"),
+		filepath.Join(dir, "events-20261019T040004.000000000Z.ndjson"),
+	}, rotated, "only the newest rotated files should be kept")
+	assert.Equal(t, 3, lines(t, rotated[0])[0].Version)
+	assert.Equal(t, 4, lines(t, rotated[1])[0].Version)
+	assert.Equal(t, 5, lines(t, filepath.Join(dir, "events.ndjson"))[0].Version)
+
+	// Reopening appends to the active file.
+	sink, err = NewNDJSONSink(dir, 1<<20, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Write([]model.Event{{Name: "checkout", Version: 6}}))
+	assert.NoError(t, sink.Close())
+	active := lines(t, filepath.Join(dir, "events.ndjson"))
+	assert.Len(t, active, 2)
+	assert.Equal(t, 6, active[1].Version)
+}
+
+func TestNDJSONSink_RenameFails(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewNDJSONSink(dir, 200, 2)
+	assert.NoError(t, err)
+	at := time.Date(2026, 10, 19, 4, 0, 0, 0, time.UTC)
+	sink.now = func() time.Time { return at }
+	// A non-empty directory in the way of the rotated name fails the rename.
+	blocked := filepath.Join(dir, "events-20261019T040000.000000000Z.ndjson")
+	assert.NoError(t, os.MkdirAll(filepath.Join(blocked, "x"), 0o750))
+
+	for v := 1; v <= 3; v++ {
+		assert.NoError(t, sink.Write([]model.Event{{Kind: model.EventFlag, Name: "checkout", Version: v, Variant: "true", Reason: model.ReasonRollout}}))
+	}
+	assert.NoError(t, sink.Close())
+
+	active := lines(t, filepath.Join(dir, "events.ndjson"))
+	if assert.Len(t, active, 3, "events should keep going to the active file") {
+		assert.Equal(t, 3, active[2].Version)
+	}
+}
@@ -0,0 +1,15 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handler) Counters(c echo.Context) error {
+	res, err := h.srv.Counters(c.Request().Context(), c.QueryParam("name"))
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
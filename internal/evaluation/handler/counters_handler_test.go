@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"configuration-management-service/internal/evaluation/model"
+	srvMock "configuration-management-service/internal/evaluation/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounters(t *testing.T) {
+	type expected struct {
+		code int
+		json string
+	}
+
+	cases := []struct {
+		name     string
+		query    string
+		mockFunc func(m *srvMock.MockIService)
+		ex       expected
+	}{
+		{
+			name:  "when service fails should status code 500",
+			query: "",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Counters(gomock.Any(), "").Return(model.EventCounters{}, errors.New("boom"))
+			},
+			ex: expected{
+				code: http.StatusInternalServerError,
+				json: `{"error":{"code":"Internal Server Error","message":"internal error","details":null}}`,
+			},
+		},
+		{
+			name:  "when name is given should return its counters per version and variant",
+			query: "?name=checkout",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Counters(gomock.Any(), "checkout").Return(model.EventCounters{
+					Since:   time.Date(2026, 10, 19, 4, 0, 0, 0, time.UTC),
+					Dropped: 2,
+					Counters: []model.EventCounter{
+						{Kind: "flag", Name: "checkout", Version: 3, Variant: "false", Count: 7, Reasons: map[string]int64{"rollout": 6, "missing_user_key": 1}},
+						{Kind: "flag", Name: "checkout", Version: 3, Variant: "true", Count: 3, Reasons: map[string]int64{"rollout": 3}},
+					},
+				}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"since":"2026-10-19T04:00:00Z","dropped":2,"counters":[
+					{"kind":"flag","name":"checkout","version":3,"variant":"false","count":7,"reasons":{"rollout":6,"missing_user_key":1}},
+					{"kind":"flag","name":"checkout","version":3,"variant":"true","count":3,"reasons":{"rollout":3}}
+				]}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodGet, "/evaluate/counters"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			_ = h.Counters(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.ex.json, string(b))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handler) Evaluate(c echo.Context) error {
+	if !httpx.IsJSON(c) {
+		return httpx.WriteError(c, http.StatusUnsupportedMediaType, "content-type must be application/json", nil)
+	}
+
+	var req model.EvaluateRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.WriteError(c, http.StatusBadRequest, "invalid JSON", err.Error())
+	}
+
+	res, err := h.srv.Evaluate(c.Request().Context(), req)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+
+	c.Response().Header().Set("ETag", res.ETag)
+	c.Response().Header().Set("Cache-Control", "no-cache")
+
+	if inm := c.Request().Header.Get("If-None-Match"); inm != "" && inm == res.ETag {
+		return c.NoContent(http.StatusNotModified)
+	}
+	h.srv.RecordServed(c.Request().Context(), req.Context, res)
+	return c.JSON(http.StatusOK, res)
+}
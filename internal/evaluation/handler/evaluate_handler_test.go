@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/evaluation/model"
+	srvMock "configuration-management-service/internal/evaluation/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	type input struct {
+		ct     string
+		body   string
+		ifNone string // If-None-Match header
+	}
+	type expected struct {
+		code int
+		json string
+		etag string
+	}
+
+	const etag = `W/"0123456789abcdef"`
+	result := model.EvaluateResult{
+		Flags:       []model.FlagResult{{Flag: "dark-mode", Version: 3, Reason: model.ReasonDisabled}},
+		Experiments: []model.ExperimentResult{{Experiment: "checkout-button", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Reason: model.ReasonInactive}},
+		ETag:        etag,
+	}
+	req := model.EvaluateRequest{Context: model.Context{Key: "user-7"}, Tags: []string{"mobile"}}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		in       input
+		ex       expected
+	}{
+		{
+			name:     "when unsupported media type should status code 415",
+			in:       input{ct: "text/plain", body: `{}`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusUnsupportedMediaType,
+				json: `{"error":{"code":"Unsupported Media Type","message":"content-type must be application/json","details":null}}`,
+			},
+		},
+		{
+			name: "when service fails should status code 500",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"context":{"key":"user-7"},"tags":["mobile"]}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Evaluate(gomock.Any(), req).Return(model.EvaluateResult{}, errors.New("db down"))
+			},
+			ex: expected{
+				code: http.StatusInternalServerError,
+				json: `{"error":{"code":"Internal Server Error","message":"internal error","details":null}}`,
+			},
+		},
+		{
+			name: "when success should return every result with the ETag and record it",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"context":{"key":"user-7"},"tags":["mobile"]}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Evaluate(gomock.Any(), req).Return(result, nil)
+				m.EXPECT().RecordServed(gomock.Any(), req.Context, result)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"flags":[{"flag":"dark-mode","version":3,"value":false,"reason":"disabled"}],
+					"experiments":[{"experiment":"checkout-button","version":2,"experiment_key":"checkout-button","variant":"control","eligible":false,"reason":"inactive"}]}`,
+				etag: etag,
+			},
+		},
+		{
+			name: "when If-None-Match matches should 304 without recording",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"context":{"key":"user-7"},"tags":["mobile"]}`, ifNone: etag},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Evaluate(gomock.Any(), req).Return(result, nil)
+			},
+			ex: expected{code: http.StatusNotModified, etag: etag},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodPost, "/evaluate", bytes.NewBufferString(tc.in.body))
+			req.Header.Set(echo.HeaderContentType, tc.in.ct)
+			if tc.in.ifNone != "" {
+				req.Header.Set("If-None-Match", tc.in.ifNone)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			_ = h.Evaluate(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.Equal(t, tc.ex.etag, res.Header.Get("ETag"))
+			if tc.ex.json != "" {
+				assert.JSONEq(t, tc.ex.json, string(b))
+			} else {
+				assert.Empty(t, b)
+			}
+		})
+	}
+}
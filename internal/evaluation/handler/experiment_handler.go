@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handler) AssignExperiment(c echo.Context) error {
+	if !httpx.IsJSON(c) {
+		return httpx.WriteError(c, http.StatusUnsupportedMediaType, "content-type must be application/json", nil)
+	}
+
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		return httpx.WriteError(c, http.StatusBadRequest, "name is required", nil)
+	}
+
+	var req model.ExperimentAssignRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.WriteError(c, http.StatusBadRequest, "invalid JSON", err.Error())
+	}
+
+	res, err := h.srv.AssignExperiment(c.Request().Context(), name, req.Context)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/internal/evaluation/service"
+	srvMock "configuration-management-service/internal/evaluation/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignExperiment(t *testing.T) {
+	type input struct {
+		ct   string
+		name string
+		body string
+	}
+	type expected struct {
+		code int
+		json string
+	}
+
+	bucket := 90.86
+
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		in       input
+		ex       expected
+	}{
+		{
+			name:     "when missing name should status code 400",
+			in:       input{ct: echo.MIMEApplicationJSON, name: " ", body: `{}`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"name is required","details":null}}`,
+			},
+		},
+		{
+			name: "when config is not an experiment should status code 400",
+			in:   input{ct: echo.MIMEApplicationJSON, name: "checkout", body: `{"context":{"key":"user-1"}}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().AssignExperiment(gomock.Any(), "checkout", model.Context{Key: "user-1"}).
+					Return(model.ExperimentResult{}, fmt.Errorf("%w: checkout is of type feature_toggle, not experiment_config", service.ErrInvalidInput))
+			},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid input","details":"invalid input: checkout is of type feature_toggle, not experiment_config"}}`,
+			},
+		},
+		{
+			name: "when success should return the variant and its reason",
+			in:   input{ct: echo.MIMEApplicationJSON, name: "checkout", body: `{"context":{"key":"user-1","attributes":{"os":"android"}}}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().AssignExperiment(gomock.Any(), "checkout", model.Context{Key: "user-1", Attributes: map[string]any{"os": "android"}}).
+					Return(model.ExperimentResult{Experiment: "checkout", Version: 2, ExperimentKey: "checkout-button", Variant: "green", Eligible: true, Reason: model.ReasonAssigned, Bucket: &bucket}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"experiment":"checkout","version":2,"experiment_key":"checkout-button","variant":"green","eligible":true,"reason":"assigned","bucket":90.86}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodPost, "/evaluate/experiments/_placeholder", bytes.NewBufferString(tc.in.body))
+			req.Header.Set(echo.HeaderContentType, tc.in.ct)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("name")
+			c.SetParamValues(tc.in.name)
+
+			_ = h.AssignExperiment(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.ex.json, string(b))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/internal/evaluation/service"
+	srvMock "configuration-management-service/internal/evaluation/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateFlag(t *testing.T) {
+	type input struct {
+		ct   string
+		name string
+		body string
+	}
+	type expected struct {
+		code int
+		json string
+	}
+
+	bucket, pct := 12.74, 25.0
+
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		in       input
+		ex       expected
+	}{
+		{
+			name:     "when unsupported media type should status code 415",
+			in:       input{ct: "text/plain", name: "checkout", body: `{}`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusUnsupportedMediaType,
+				json: `{"error":{"code":"Unsupported Media Type","message":"content-type must be application/json","details":null}}`,
+			},
+		},
+		{
+			name:     "when invalid json should status code 400",
+			in:       input{ct: echo.MIMEApplicationJSON, name: "checkout", body: `{"context":`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid JSON","details":"code=400, message=unexpected EOF, internal=unexpected EOF"}}`,
+			},
+		},
+		{
+			name: "when flag does not exist should status code 404",
+			in:   input{ct: echo.MIMEApplicationJSON, name: "nope", body: `{"context":{"key":"user-7"}}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().EvaluateFlag(gomock.Any(), "nope", model.Context{Key: "user-7"}).
+					Return(model.FlagResult{}, fmt.Errorf("%w: nope", service.ErrNotFound))
+			},
+			ex: expected{
+				code: http.StatusNotFound,
+				json: `{"error":{"code":"Not Found","message":"not found: nope","details":null}}`,
+			},
+		},
+		{
+			name: "when success should return the value and its reason",
+			in:   input{ct: echo.MIMEApplicationJSON, name: "checkout", body: `{"context":{"key":"user-7","attributes":{"country":"ID"}}}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().EvaluateFlag(gomock.Any(), "checkout", model.Context{Key: "user-7", Attributes: map[string]any{"country": "ID"}}).
+					Return(model.FlagResult{Flag: "checkout", Version: 3, Value: true, Reason: model.ReasonRollout, Bucket: &bucket, RolloutPercentage: &pct}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"flag":"checkout","version":3,"value":true,"reason":"rollout","bucket":12.74,"rollout_percentage":25}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodPost, "/evaluate/flags/_placeholder", bytes.NewBufferString(tc.in.body))
+			req.Header.Set(echo.HeaderContentType, tc.in.ct)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("name")
+			c.SetParamValues(tc.in.name)
+
+			_ = h.EvaluateFlag(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.ex.json, string(b))
+		})
+	}
+}
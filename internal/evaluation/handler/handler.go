@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"configuration-management-service/internal/evaluation/service"
+	"configuration-management-service/pkg/httpx"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IHandler interface {
+	EvaluateFlag(c echo.Context) error
+	AssignExperiment(c echo.Context) error
+	Evaluate(c echo.Context) error
+	Counters(c echo.Context) error
+	ScheduleStatus(c echo.Context) error
+	NextRuns(c echo.Context) error
+}
+
+type handler struct {
+	srv service.IService
+}
+
+func NewHandler(srv service.IService) IHandler {
+	return &handler{srv: srv}
+}
+
+func (h *handler) writeServiceError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		return httpx.WriteError(c, http.StatusNotFound, err.Error(), nil)
+	case errors.Is(err, service.ErrInvalidInput):
+		return httpx.WriteError(c, http.StatusBadRequest, "invalid input", err.Error())
+	default:
+		return httpx.WriteError(c, http.StatusInternalServerError, "internal error", nil)
+	}
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultRunCount = 5
+	maxRunCount     = 100
+)
+
+func (h *handler) ScheduleStatus(c echo.Context) error {
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		return httpx.WriteError(c, http.StatusBadRequest, "name is required", nil)
+	}
+	at, ok := queryTime(c, "at")
+	if !ok {
+		return httpx.WriteError(c, http.StatusBadRequest, "invalid at", "at must be an RFC 3339 time")
+	}
+
+	res, err := h.srv.ScheduleStatus(c.Request().Context(), name, at)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *handler) NextRuns(c echo.Context) error {
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		return httpx.WriteError(c, http.StatusBadRequest, "name is required", nil)
+	}
+	from, ok := queryTime(c, "from")
+	if !ok {
+		return httpx.WriteError(c, http.StatusBadRequest, "invalid from", "from must be an RFC 3339 time")
+	}
+	count := defaultRunCount
+	if q := strings.TrimSpace(c.QueryParam("count")); q != "" {
+		n, err := strconv.Atoi(q)
+		if err != nil || n < 1 || n > maxRunCount {
+			return httpx.WriteError(c, http.StatusBadRequest, "invalid count", "count must be an integer from 1 to "+strconv.Itoa(maxRunCount))
+		}
+		count = n
+	}
+
+	res, err := h.srv.NextRuns(c.Request().Context(), name, from, count)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// queryTime returns the RFC 3339 query parameter name, or the zero time
+// when it is absent. An unescaped "+07:00" offset arrives as " 07:00", so
+// spaces are read back as plus signs.
+func queryTime(c echo.Context, name string) (time.Time, bool) {
+	q := strings.TrimSpace(c.QueryParam(name))
+	if q == "" {
+		return time.Time{}, true
+	}
+	t, err := time.Parse(time.RFC3339, strings.ReplaceAll(q, " ", "+"))
+	return t, err == nil
+}
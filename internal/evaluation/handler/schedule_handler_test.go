@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/internal/evaluation/service"
+	srvMock "configuration-management-service/internal/evaluation/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleStatus(t *testing.T) {
+	type input struct {
+		name  string
+		query string
+	}
+	type expected struct {
+		code int
+		json string
+	}
+
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	at := time.Date(2025, 12, 25, 9, 0, 0, 0, jakarta)
+
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		in       input
+		ex       expected
+	}{
+		{
+			name:     "when at is not an RFC 3339 time should status code 400",
+			in:       input{name: "holidays", query: "?at=2025-12-25"},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid at","details":"at must be an RFC 3339 time"}}`,
+			},
+		},
+		{
+			name: "when schedule does not exist should status code 404",
+			in:   input{name: "nope"},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().ScheduleStatus(gomock.Any(), "nope", time.Time{}).
+					Return(model.ScheduleStatus{}, fmt.Errorf("%w: nope", service.ErrNotFound))
+			},
+			ex: expected{
+				code: http.StatusNotFound,
+				json: `{"error":{"code":"Not Found","message":"not found: nope","details":null}}`,
+			},
+		},
+		{
+			name: "when at has an unescaped offset should read it as plus",
+			in:   input{name: "holidays", query: "?at=2025-12-25T09:00:00+07:00"},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().ScheduleStatus(gomock.Any(), "holidays", gomock.Any()).
+					DoAndReturn(func(_ any, _ string, got time.Time) (model.ScheduleStatus, error) {
+						assert.True(t, at.Equal(got), got)
+						return model.ScheduleStatus{Schedule: "holidays", Version: 4, Timezone: "Asia/Jakarta", At: at, Active: true, Reason: model.ReasonInWindow,
+							Window: &model.Window{Start: time.Date(2025, 12, 24, 0, 0, 0, 0, jakarta), End: time.Date(2025, 12, 26, 0, 0, 0, 0, jakarta)}}, nil
+					})
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"schedule":"holidays","version":4,"timezone":"Asia/Jakarta","at":"2025-12-25T09:00:00+07:00","active":true,"reason":"in_window",
+					"window":{"start":"2025-12-24T00:00:00+07:00","end":"2025-12-26T00:00:00+07:00"}}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodGet, "/schedules/_placeholder/status"+tc.in.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("name")
+			c.SetParamValues(tc.in.name)
+
+			_ = h.ScheduleStatus(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.ex.json, string(b))
+		})
+	}
+}
+
+func TestNextRuns(t *testing.T) {
+	type input struct {
+		name  string
+		query string
+	}
+	type expected struct {
+		code int
+		json string
+	}
+
+	newYork, _ := time.LoadLocation("America/New_York")
+	from := time.Date(2025, 3, 8, 12, 0, 0, 0, newYork)
+
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		in       input
+		ex       expected
+	}{
+		{
+			name:     "when count is above the maximum should status code 400",
+			in:       input{name: "nightly", query: "?count=101"},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid count","details":"count must be an integer from 1 to 100"}}`,
+			},
+		},
+		{
+			name: "when rule has no cron should status code 400",
+			in:   input{name: "windows-only"},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().NextRuns(gomock.Any(), "windows-only", time.Time{}, 5).
+					Return(model.ScheduleRuns{}, fmt.Errorf("%w: windows-only has no cron expression", service.ErrInvalidInput))
+			},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid input","details":"invalid input: windows-only has no cron expression"}}`,
+			},
+		},
+		{
+			name: "when success should return the runs with their offsets",
+			in:   input{name: "nightly", query: "?count=2&from=2025-03-08T17:00:00Z"},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().NextRuns(gomock.Any(), "nightly", time.Date(2025, 3, 8, 17, 0, 0, 0, time.UTC), 2).
+					Return(model.ScheduleRuns{Schedule: "nightly", Version: 4, Timezone: "America/New_York", Cron: "30 2 * * *", From: from, Runs: []time.Time{
+						time.Date(2025, 3, 9, 3, 0, 0, 0, newYork),
+						time.Date(2025, 3, 10, 2, 30, 0, 0, newYork),
+					}}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"schedule":"nightly","version":4,"timezone":"America/New_York","cron":"30 2 * * *","from":"2025-03-08T12:00:00-05:00",
+					"runs":["2025-03-09T03:00:00-04:00","2025-03-10T02:30:00-04:00"]}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodGet, "/schedules/_placeholder/next"+tc.in.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("name")
+			c.SetParamValues(tc.in.name)
+
+			_ = h.NextRuns(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.ex.json, string(b))
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Context is who a flag is evaluated for: a stable user key and the
+// attributes targeting can look at.
+type Context struct {
+	Key        string         `json:"key"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+type FlagEvaluateRequest struct {
+	Context Context `json:"context"`
+}
+
+// StoredConfig is the latest resolved version of a config, as evaluated.
+type StoredConfig struct {
+	Name    string
+	Type    string
+	Version int
+	Data    json.RawMessage
+	// Variables maps each variable rendered into Data to the version used.
+	Variables map[string]int
+}
+
+// Reasons a flag evaluated to its value.
+const (
+	// ReasonDisabled: the toggle is off for everyone.
+	ReasonDisabled = "disabled"
+	// ReasonEnabled: no rule matched and the toggle is on with no rollout
+	// percentage.
+	ReasonEnabled = "enabled"
+	// ReasonRollout: no rule matched and the user's bucket is inside (on) or
+	// outside (off) the rollout percentage.
+	ReasonRollout = "rollout"
+	// ReasonRuleMatch: the first matching targeting rule served the value,
+	// either directly or by the user's bucket against its percentage.
+	ReasonRuleMatch = "rule_match"
+	// ReasonMissingKey: a partial rollout cannot place a context without a
+	// user key, so the flag is off.
+	ReasonMissingKey = "missing_user_key"
+)
+
+// FlagResult is the value of a feature_toggle for one context.
+type FlagResult struct {
+	Flag    string `json:"flag"`
+	Version int    `json:"version"`
+	Value   bool   `json:"value"`
+	Reason  string `json:"reason"`
+	// Rule is the index of the targeting rule that decided the value.
+	Rule *int `json:"rule,omitempty"`
+	// Bucket is the user's position in [0, 100) for a rollout decision.
+	Bucket *float64 `json:"bucket,omitempty"`
+	// RolloutPercentage is the percentage the bucket was compared with.
+	RolloutPercentage *float64 `json:"rollout_percentage,omitempty"`
+}
+
+type ExperimentAssignRequest struct {
+	Context Context `json:"context"`
+}
+
+// Context attributes the experiment audience is matched on.
+const (
+	AttrCountry    = "country"
+	AttrOS         = "os"
+	AttrAppVersion = "app_version"
+)
+
+// Reasons an experiment assigned its variant.
+const (
+	// ReasonAssigned: the context is in the audience and got a variant by
+	// its bucket.
+	ReasonAssigned = "assigned"
+	// ReasonInactive: the experiment is not running; everyone gets control.
+	ReasonInactive = "inactive"
+	// ReasonCountryMismatch: audience.countries does not list the
+	// context's country.
+	ReasonCountryMismatch = "country_not_targeted"
+	// ReasonOSMismatch: audience.os does not list the context's os.
+	ReasonOSMismatch = "os_not_targeted"
+	// ReasonAppVersionTooLow: the context's app_version is missing, not a
+	// semantic version or below audience.min_app_version.
+	ReasonAppVersionTooLow = "app_version_below_minimum"
+	// ReasonSegmentMismatch: audience.segments does not hold the context.
+	ReasonSegmentMismatch = "segment_not_targeted"
+	// ReasonHoldout: a holdout experiment holds the context out of every
+	// other experiment.
+	ReasonHoldout = "holdout"
+	// ReasonLayerMismatch: the context's layer bucket is outside the
+	// experiment's range of its layer.
+	ReasonLayerMismatch = "layer_not_targeted"
+)
+
+// ExperimentResult is the variant of an experiment_config for one context.
+// Contexts outside the experiment get the control variant with Eligible
+// false and are not part of the analysis.
+type ExperimentResult struct {
+	Experiment    string `json:"experiment"`
+	Version       int    `json:"version"`
+	ExperimentKey string `json:"experiment_key"`
+	Variant       string `json:"variant"`
+	Eligible      bool   `json:"eligible"`
+	Reason        string `json:"reason"`
+	// Bucket is the user's position in [0, 100) the variant was picked by.
+	Bucket *float64 `json:"bucket,omitempty"`
+	// LayerBucket is the user's position in [0, 100) of the experiment's
+	// layer, set once the layer decided.
+	LayerBucket *float64 `json:"layer_bucket,omitempty"`
+	// Holdout names the holdout experiment that held the context out.
+	Holdout string `json:"holdout,omitempty"`
+}
+
+// EvaluateRequest asks for every flag and experiment for one context. Names
+// keeps the configs named; Tags keeps the configs with any of the tags.
+type EvaluateRequest struct {
+	Context Context  `json:"context"`
+	Names   []string `json:"names,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// EvaluateResult holds every matching feature_toggle and experiment_config
+// evaluated against one snapshot of the config store, sorted by name.
+type EvaluateResult struct {
+	Flags       []FlagResult       `json:"flags"`
+	Experiments []ExperimentResult `json:"experiments"`
+	// ETag changes when any evaluated config version, variable version or
+	// the request changes.
+	ETag string `json:"-"`
+}
+
+// Kinds of evaluation events.
+const (
+	EventFlag       = "flag"
+	EventExperiment = "experiment"
+)
+
+// Event records one server-side evaluation: which config version served
+// which variant to which user, and why. Flags serve the variants "true" and
+// "false". User is a keyed hash of the user key, never the key itself.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	Name    string    `json:"name"`
+	Version int       `json:"version"`
+	Variant string    `json:"variant"`
+	Reason  string    `json:"reason"`
+	User    string    `json:"user,omitempty"`
+}
+
+// EventCounter counts the events of one variant of one config version.
+type EventCounter struct {
+	Kind    string           `json:"kind"`
+	Name    string           `json:"name"`
+	Version int              `json:"version"`
+	Variant string           `json:"variant"`
+	Count   int64            `json:"count"`
+	Reasons map[string]int64 `json:"reasons"`
+}
+
+// EventCounters aggregates the events recorded since Since. Dropped counts
+// events the log could not keep up with; they are still counted here.
+type EventCounters struct {
+	Since    time.Time      `json:"since"`
+	Dropped  int64          `json:"dropped"`
+	Counters []EventCounter `json:"counters"`
+}
+
+// Reasons a schedule_rule is active or not.
+const (
+	// ReasonScheduleInactive: the rule's active is false.
+	ReasonScheduleInactive = "inactive"
+	// ReasonNoWindows: the rule is active and lists no windows, so it is
+	// active at any time.
+	ReasonNoWindows = "no_windows"
+	// ReasonInWindow: the instant is in one of the rule's windows.
+	ReasonInWindow = "in_window"
+	// ReasonOutsideWindows: the instant is in none of the rule's windows.
+	ReasonOutsideWindows = "outside_windows"
+)
+
+// Window is a period of a schedule_rule, holding Start but not End.
+type Window struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// ScheduleStatus tells whether a schedule_rule is active at an instant.
+// Times are in the rule's time zone.
+type ScheduleStatus struct {
+	Schedule string    `json:"schedule"`
+	Version  int       `json:"version"`
+	Timezone string    `json:"timezone"`
+	At       time.Time `json:"at"`
+	Active   bool      `json:"active"`
+	Reason   string    `json:"reason"`
+	// Window is the window holding At.
+	Window *Window `json:"window,omitempty"`
+}
+
+// ScheduleRuns lists the next fire times of a schedule_rule's cron
+// expression after From, in the rule's time zone.
+type ScheduleRuns struct {
+	Schedule string      `json:"schedule"`
+	Version  int         `json:"version"`
+	Timezone string      `json:"timezone"`
+	Cron     string      `json:"cron"`
+	From     time.Time   `json:"from"`
+	Runs     []time.Time `json:"runs"`
+}
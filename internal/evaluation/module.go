@@ -0,0 +1,48 @@
+package evaluation
+
+import (
+	"configuration-management-service/internal/evaluation/handler"
+	"configuration-management-service/internal/evaluation/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IModule interface {
+	RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc)
+	Service() service.IService
+}
+
+type module struct {
+	srv service.IService
+	h   handler.IHandler
+}
+
+// InitModule wires flag evaluation, experiment assignment and schedule checks; configs reads the
+// latest resolved configs it evaluates and events records what was served.
+func InitModule(configs service.ConfigSource, events service.EventRecorder) IModule {
+	srv := service.NewService(configs, events)
+	return &module{
+		srv: srv,
+		h:   handler.NewHandler(srv),
+	}
+}
+
+func (m *module) Service() service.IService {
+	return m.srv
+}
+
+func (m *module) RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc) {
+	if g == nil {
+		return
+	}
+
+	evaluate := g.Group("/evaluate")
+	evaluate.POST("", m.h.Evaluate, writeLimit)
+	evaluate.POST("/flags/:name", m.h.EvaluateFlag, writeLimit)
+	evaluate.POST("/experiments/:name", m.h.AssignExperiment, writeLimit)
+	evaluate.GET("/counters", m.h.Counters)
+
+	schedules := g.Group("/schedules")
+	schedules.GET("/:name/status", m.h.ScheduleStatus)
+	schedules.GET("/:name/next", m.h.NextRuns)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"configuration-management-service/internal/evaluation/model"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Evaluate evaluates every feature_toggle and experiment_config kept by the
+// filters of req for req.Context, all read from one snapshot. It records no
+// events: a result answered with 304 was not served, so the caller records
+// the ones it sends with RecordServed.
+func (s service) Evaluate(ctx context.Context, req model.EvaluateRequest) (model.EvaluateResult, error) {
+	cfgs, err := s.configs.Snapshot(ctx, []string{typeFeatureToggle, typeExperimentConfig, typeSegment})
+	if err != nil {
+		return model.EvaluateResult{}, err
+	}
+	segs := s.segmentsOf(cfgs)
+	holdouts, err := s.holdoutsOf(cfgs)
+	if err != nil {
+		return model.EvaluateResult{}, err
+	}
+
+	names := set(req.Names)
+	tags := set(req.Tags)
+	res := model.EvaluateResult{Flags: []model.FlagResult{}, Experiments: []model.ExperimentResult{}}
+	var evaluated []model.StoredConfig
+	for _, cfg := range cfgs {
+		named := len(names) == 0 || names[cfg.Name]
+		switch cfg.Type {
+		case typeFeatureToggle:
+			if !named {
+				continue
+			}
+			var toggle featureToggle
+			if err := json.Unmarshal(cfg.Data, &toggle); err != nil {
+				return model.EvaluateResult{}, fmt.Errorf("decode %s: %w", cfg.Name, err)
+			}
+			if !tagged(tags, toggle.Tags) {
+				continue
+			}
+			flag, err := s.evaluateToggle(cfg, toggle, segs, req.Context)
+			if err != nil {
+				return model.EvaluateResult{}, err
+			}
+			res.Flags = append(res.Flags, flag)
+		case typeExperimentConfig:
+			exp, err := s.cache.experiment(cfg.Name, cfg.Data)
+			if err != nil {
+				return model.EvaluateResult{}, err
+			}
+			if !named || !tagged(tags, exp.Tags) {
+				if exp.Holdout {
+					// Left out of the result, but it can hold the context out
+					// of the experiments in it, so it counts towards the ETag.
+					evaluated = append(evaluated, cfg)
+				}
+				continue
+			}
+			assigned, err := assign(cfg, exp, segs, holdouts, req.Context)
+			if err != nil {
+				return model.EvaluateResult{}, err
+			}
+			res.Experiments = append(res.Experiments, assigned)
+		case typeSegment:
+			// Not evaluated, but any segment can change the result of a
+			// rule or an audience, so it counts towards the ETag.
+		default:
+			continue
+		}
+		evaluated = append(evaluated, cfg)
+	}
+
+	if res.ETag, err = etag(req, evaluated); err != nil {
+		return model.EvaluateResult{}, err
+	}
+	return res, nil
+}
+
+// etag identifies a bulk result by what it is computed from: the version of
+// every evaluated config and of the variables rendered into it, and the
+// request itself.
+func etag(req model.EvaluateRequest, cfgs []model.StoredConfig) (string, error) {
+	req.Names = sortedCopy(req.Names)
+	req.Tags = sortedCopy(req.Tags)
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	var key strings.Builder
+	key.Write(body)
+	for _, cfg := range cfgs {
+		key.WriteString("\n" + cfg.Name + ":" + strconv.Itoa(cfg.Version))
+		vars := make([]string, 0, len(cfg.Variables))
+		for n := range cfg.Variables {
+			vars = append(vars, n)
+		}
+		sort.Strings(vars)
+		for _, n := range vars {
+			key.WriteString(";" + n + "=" + strconv.Itoa(cfg.Variables[n]))
+		}
+	}
+	h := sha1.Sum([]byte(key.String()))
+	return `W/"` + hex.EncodeToString(h[:8]) + `"`, nil
+}
+
+func set(list []string) map[string]bool {
+	out := make(map[string]bool, len(list))
+	for _, v := range list {
+		if v = strings.TrimSpace(v); v != "" {
+			out[v] = true
+		}
+	}
+	return out
+}
+
+// tagged reports whether tags holds any of want; no wanted tag keeps all.
+func tagged(want map[string]bool, tags []string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	for _, t := range tags {
+		if want[t] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedCopy(list []string) []string {
+	out := append([]string(nil), list...)
+	sort.Strings(out)
+	return out
+}
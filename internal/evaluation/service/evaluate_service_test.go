@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/evaluation/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingConfigs is a config store that cannot be read.
+type failingConfigs struct{ storedConfigs }
+
+func (failingConfigs) Snapshot(ctx context.Context, types []string) ([]model.StoredConfig, error) {
+	return nil, errors.New("db down")
+}
+
+func Test_service_Evaluate(t *testing.T) {
+	configs := storedConfigs{
+		toggle("checkout", `{"enabled":true,"rollout_percentage":25,"tags":["mobile"]}`),
+		experiment("checkout-button", `{"experiment_key":"checkout-button","active":false,"variants":[{"name":"control","weight":50},{"name":"green","weight":50}],"tags":["mobile"]}`),
+		toggle("dark-mode", `{"enabled":false,"tags":["web"]}`),
+		toggle("staff-banner", `{"enabled":true,"rollout_percentage":0,"rules":[{"segments":["staff"],"serve":true}],"tags":["web"]}`),
+		segment("staff", `{"include":["user-7"]}`),
+		{Name: "limits", Type: "rate_limit_policy", Version: 1, Data: json.RawMessage(`{}`)},
+	}
+	ec := model.Context{Key: "user-7"}
+	checkout := model.FlagResult{Flag: "checkout", Version: 3, Value: true, Reason: model.ReasonRollout, Bucket: ptr(12.74), RolloutPercentage: ptr(25)}
+	darkMode := model.FlagResult{Flag: "dark-mode", Version: 3, Reason: model.ReasonDisabled}
+	staffBanner := model.FlagResult{Flag: "staff-banner", Version: 3, Value: true, Reason: model.ReasonRuleMatch, Rule: iptr(0)}
+	button := model.ExperimentResult{Experiment: "checkout-button", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Reason: model.ReasonInactive}
+
+	cases := []struct {
+		name    string
+		configs ConfigSource
+		req     model.EvaluateRequest
+		flags   []model.FlagResult
+		exps    []model.ExperimentResult
+		err     string
+	}{
+		{
+			name:    "when snapshot fails should return error",
+			configs: failingConfigs{},
+			req:     model.EvaluateRequest{Context: ec},
+			err:     "db down",
+		},
+		{
+			name:    "when no filter is given should evaluate every flag and experiment",
+			configs: configs,
+			req:     model.EvaluateRequest{Context: ec},
+			flags:   []model.FlagResult{checkout, darkMode, staffBanner},
+			exps:    []model.ExperimentResult{button},
+		},
+		{
+			name:    "when names are given should evaluate only those",
+			configs: configs,
+			req:     model.EvaluateRequest{Context: ec, Names: []string{"dark-mode", "limits", "nope"}},
+			flags:   []model.FlagResult{darkMode},
+			exps:    []model.ExperimentResult{},
+		},
+		{
+			name:    "when tags are given should evaluate the configs with any of them",
+			configs: configs,
+			req:     model.EvaluateRequest{Context: ec, Tags: []string{"mobile"}},
+			flags:   []model.FlagResult{checkout},
+			exps:    []model.ExperimentResult{button},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := service{configs: tc.configs}
+
+			got, err := svc.Evaluate(context.Background(), tc.req)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.flags, got.Flags)
+			assert.Equal(t, tc.exps, got.Experiments)
+			assert.Regexp(t, `^W/"[0-9a-f]{16}"$`, got.ETag)
+		})
+	}
+}
+
+func Test_service_Evaluate_ETag(t *testing.T) {
+	req := model.EvaluateRequest{Context: model.Context{Key: "user-7", Attributes: map[string]any{"country": "ID", "os": "ios"}}, Tags: []string{"a", "b"}}
+	base := storedConfigs{
+		{Name: "checkout", Type: "feature_toggle", Version: 3, Data: json.RawMessage(`{"enabled":true,"tags":["a"]}`), Variables: map[string]int{"market": 1}},
+	}
+	etagOf := func(configs storedConfigs, req model.EvaluateRequest) string {
+		res, err := service{configs: configs}.Evaluate(context.Background(), req)
+		assert.NoError(t, err)
+		return res.ETag
+	}
+	first := etagOf(base, req)
+
+	reordered := req
+	reordered.Tags = []string{"b", "a"}
+	assert.Equal(t, first, etagOf(base, reordered), "filter order should not matter")
+
+	newVersion := storedConfigs{base[0]}
+	newVersion[0].Version = 4
+	assert.NotEqual(t, first, etagOf(newVersion, req), "a new config version should change it")
+
+	newVariable := storedConfigs{base[0]}
+	newVariable[0].Variables = map[string]int{"market": 2}
+	assert.NotEqual(t, first, etagOf(newVariable, req), "a new variable version should change it")
+
+	newSegment := storedConfigs{segment("staff", `{"include":["user-1"]}`), base[0]}
+	assert.NotEqual(t, first, etagOf(newSegment, req), "a segment should change it")
+	named := req
+	named.Names = []string{"checkout"}
+	before := etagOf(newSegment, named)
+	newSegment[0].Version = 2
+	assert.NotEqual(t, before, etagOf(newSegment, named), "a new segment version should change it whatever the names")
+
+	withHoldout := storedConfigs{base[0], experiment("holdout-q4", `{"experiment_key":"holdout-q4","active":true,"holdout":true,"variants":[{"name":"held-out","weight":15},{"name":"control","weight":85}]}`)}
+	before = etagOf(withHoldout, named)
+	withHoldout[1].Version = 3
+	assert.NotEqual(t, before, etagOf(withHoldout, named), "a new holdout version should change it whatever the names")
+
+	otherUser := req
+	otherUser.Context = model.Context{Key: "user-8", Attributes: req.Context.Attributes}
+	assert.NotEqual(t, first, etagOf(base, otherUser), "another context should change it")
+}
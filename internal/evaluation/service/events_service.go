@@ -0,0 +1,48 @@
+package service
+
+import (
+	"configuration-management-service/internal/evaluation/model"
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Counters returns the event counters of the config name, or of every
+// config when name is empty.
+func (s service) Counters(ctx context.Context, name string) (model.EventCounters, error) {
+	if s.events == nil {
+		return model.EventCounters{Counters: []model.EventCounter{}}, nil
+	}
+	return s.events.Counters(strings.TrimSpace(name)), nil
+}
+
+// RecordServed records an event for every flag and experiment of a bulk
+// result sent to the caller for ec.
+func (s service) RecordServed(ctx context.Context, ec model.Context, res model.EvaluateResult) {
+	for _, f := range res.Flags {
+		s.recordFlag(ec, f)
+	}
+	for _, e := range res.Experiments {
+		s.recordExperiment(ec, e)
+	}
+}
+
+func (s service) recordFlag(ec model.Context, res model.FlagResult) {
+	if s.events == nil {
+		return
+	}
+	s.events.Record(ec.Key, model.Event{
+		Kind: model.EventFlag, Name: res.Flag, Version: res.Version,
+		Variant: strconv.FormatBool(res.Value), Reason: res.Reason,
+	})
+}
+
+func (s service) recordExperiment(ec model.Context, res model.ExperimentResult) {
+	if s.events == nil {
+		return
+	}
+	s.events.Record(ec.Key, model.Event{
+		Kind: model.EventExperiment, Name: res.Experiment, Version: res.Version,
+		Variant: res.Variant, Reason: res.Reason,
+	})
+}
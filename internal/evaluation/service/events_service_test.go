@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"configuration-management-service/internal/evaluation/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorded keeps every event with the raw user key it was recorded for.
+type recorded struct {
+	keys   []string
+	events []model.Event
+}
+
+func (r *recorded) Record(userKey string, e model.Event) {
+	r.keys = append(r.keys, userKey)
+	r.events = append(r.events, e)
+}
+
+func (r *recorded) Counters(name string) model.EventCounters {
+	return model.EventCounters{Counters: []model.EventCounter{{Name: name, Count: int64(len(r.events))}}}
+}
+
+func Test_service_records_events(t *testing.T) {
+	configs := storedConfigs{
+		toggle("checkout", `{"enabled":true,"rollout_percentage":25}`),
+		experiment("button", `{"experiment_key":"checkout-button","active":true,"variants":[{"name":"blue","weight":25},{"name":"control","weight":45},{"name":"green","weight":30}]}`),
+	}
+	ec := model.Context{Key: "user-7"}
+	rec := &recorded{}
+	svc := service{configs: configs, events: rec}
+	ctx := context.Background()
+
+	_, err := svc.EvaluateFlag(ctx, "checkout", ec)
+	assert.NoError(t, err)
+	_, err = svc.AssignExperiment(ctx, "button", model.Context{Key: "user-1"})
+	assert.NoError(t, err)
+	bulk, err := svc.Evaluate(ctx, model.EvaluateRequest{Context: ec})
+	assert.NoError(t, err)
+	assert.Len(t, rec.events, 2, "evaluating a bulk result records nothing until it is served")
+	svc.RecordServed(ctx, ec, bulk)
+	_, err = svc.EvaluateFlag(ctx, "nope", ec)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.Equal(t, []string{"user-7", "user-1", "user-7", "user-7"}, rec.keys)
+	assert.Equal(t, []model.Event{
+		{Kind: model.EventFlag, Name: "checkout", Version: 3, Variant: "true", Reason: model.ReasonRollout},
+		{Kind: model.EventExperiment, Name: "button", Version: 2, Variant: "green", Reason: model.ReasonAssigned},
+		{Kind: model.EventFlag, Name: "checkout", Version: 3, Variant: "true", Reason: model.ReasonRollout},
+		{Kind: model.EventExperiment, Name: "button", Version: 2, Variant: "green", Reason: model.ReasonAssigned},
+	}, rec.events)
+
+	counters, err := svc.Counters(ctx, " checkout ")
+	assert.NoError(t, err)
+	assert.Equal(t, "checkout", counters.Counters[0].Name)
+}
+
+func Test_service_Counters_withoutRecorder(t *testing.T) {
+	got, err := service{}.Counters(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, model.EventCounters{Counters: []model.EventCounter{}}, got)
+}
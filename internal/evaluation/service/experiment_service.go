@@ -0,0 +1,301 @@
+package service
+
+import (
+	"configuration-management-service/internal/evaluation/bucket"
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/pkg/semver"
+	"configuration-management-service/pkg/targeting"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const typeExperimentConfig = "experiment_config"
+
+// controlVariant is the variant contexts outside the experiment get when
+// the experiment names one this way; otherwise they get the first variant.
+const controlVariant = "control"
+
+// experimentConfig is the part of an experiment_config config assignment
+// reads.
+type experimentConfig struct {
+	ExperimentKey string    `json:"experiment_key"`
+	Active        bool      `json:"active"`
+	Variants      []variant `json:"variants"`
+	Audience      *audience `json:"audience"`
+	Salt          string    `json:"salt"`
+	Layer         *layer    `json:"layer"`
+	Holdout       bool      `json:"holdout"`
+	Tags          []string  `json:"tags"`
+}
+
+type variant struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+}
+
+type audience struct {
+	Countries     []string `json:"countries"`
+	OS            []string `json:"os"`
+	MinAppVersion string   `json:"min_app_version"`
+	Segments      []string `json:"segments"`
+}
+
+// layer is the share of a layer an experiment takes: the contexts whose
+// layer bucket is in [Start, End). Active experiments of one layer never
+// overlap, so a context is in at most one of them.
+type layer struct {
+	Name  string  `json:"name"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// holdout is an experiment_config marked holdout. The contexts it assigns a
+// variant other than its control are held out of every other experiment.
+type holdout struct {
+	cfg model.StoredConfig
+	exp experimentConfig
+}
+
+// AssignExperiment returns the variant of the experiment_config name for
+// ec. Contexts in the audience are placed by bucket.Of(experiment_key,
+// ec.Key) on the cumulative variant weights, so a user keeps their variant
+// as long as the variants, weights and salt stay the same. The experiment
+// is read together with the segments and holdouts it depends on, all from
+// one snapshot; experiments are decoded once per version through the
+// compile cache, so finding the holdouts costs no decoding per request.
+func (s service) AssignExperiment(ctx context.Context, name string, ec model.Context) (model.ExperimentResult, error) {
+	name = strings.TrimSpace(name)
+	cfgs, err := s.configs.Snapshot(ctx, []string{typeExperimentConfig, typeSegment})
+	if err != nil {
+		return model.ExperimentResult{}, err
+	}
+	cfg, ok := find(cfgs, name, typeExperimentConfig)
+	if !ok {
+		// latest tells a missing name from a config of another type.
+		if _, err := s.latest(ctx, name, typeExperimentConfig, &experimentConfig{}); err != nil {
+			return model.ExperimentResult{}, err
+		}
+		return model.ExperimentResult{}, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	exp, err := s.cache.experiment(cfg.Name, cfg.Data)
+	if err != nil {
+		return model.ExperimentResult{}, err
+	}
+	holdouts, err := s.holdoutsOf(cfgs)
+	if err != nil {
+		return model.ExperimentResult{}, err
+	}
+
+	res, err := assign(cfg, exp, s.segmentsOf(cfgs), holdouts, ec)
+	if err != nil {
+		return model.ExperimentResult{}, err
+	}
+	s.recordExperiment(ec, res)
+	return res, nil
+}
+
+// assign returns the variant of exp for ec. A context in the audience is
+// first checked against holdouts, then against the experiment's layer
+// range, and then gets the variant its bucket falls in.
+func assign(cfg model.StoredConfig, exp experimentConfig, segs segments, holdouts []holdout, ec model.Context) (model.ExperimentResult, error) {
+	res := model.ExperimentResult{
+		Experiment:    cfg.Name,
+		Version:       cfg.Version,
+		ExperimentKey: exp.ExperimentKey,
+		Variant:       exp.control(),
+	}
+	if !exp.Active {
+		res.Reason = model.ReasonInactive
+		return res, nil
+	}
+	reason, err := exp.Audience.mismatch(ec, segs)
+	if err != nil {
+		return model.ExperimentResult{}, err
+	}
+	if reason != "" {
+		res.Reason = reason
+		return res, nil
+	}
+	if ec.Key == "" {
+		res.Reason = model.ReasonMissingKey
+		return res, nil
+	}
+	if !exp.Holdout {
+		by, err := heldOutBy(holdouts, segs, ec)
+		if err != nil {
+			return model.ExperimentResult{}, err
+		}
+		if by != "" {
+			res.Reason, res.Holdout = model.ReasonHoldout, by
+			return res, nil
+		}
+	}
+	if exp.Layer != nil {
+		b := bucket.Of(exp.Layer.seed(), ec.Key)
+		p := bucket.Percent(b)
+		res.LayerBucket = &p
+		if bucket.In(b, exp.Layer.Start) || !bucket.In(b, exp.Layer.End) {
+			res.Reason = model.ReasonLayerMismatch
+			return res, nil
+		}
+	}
+
+	b := bucket.Of(exp.seed(), ec.Key)
+	p := bucket.Percent(b)
+	res.Variant, res.Eligible, res.Reason, res.Bucket = exp.pick(p), true, model.ReasonAssigned, &p
+	return res, nil
+}
+
+// holdoutsOf returns the holdout experiments among cfgs, in their order.
+func (s service) holdoutsOf(cfgs []model.StoredConfig) ([]holdout, error) {
+	var out []holdout
+	for _, cfg := range cfgs {
+		if cfg.Type != typeExperimentConfig {
+			continue
+		}
+		exp, err := s.cache.experiment(cfg.Name, cfg.Data)
+		if err != nil {
+			return nil, err
+		}
+		if exp.Holdout {
+			out = append(out, holdout{cfg: cfg, exp: exp})
+		}
+	}
+	return out, nil
+}
+
+// heldOutBy returns the name of the first holdout that assigns ec a variant
+// other than its control, or "" when none does. Holdouts are assigned like
+// any experiment, so an inactive one holds no one.
+func heldOutBy(holdouts []holdout, segs segments, ec model.Context) (string, error) {
+	for _, h := range holdouts {
+		res, err := assign(h.cfg, h.exp, segs, nil, ec)
+		if err != nil {
+			return "", err
+		}
+		if res.Eligible && res.Variant != h.exp.control() {
+			return h.cfg.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// experiment returns the decoded experiment_config name.
+func (c *compileCache) experiment(name string, raw json.RawMessage) (experimentConfig, error) {
+	v, err := c.load(name, raw, func() (any, error) {
+		var exp experimentConfig
+		if err := json.Unmarshal(raw, &exp); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", name, err)
+		}
+		return exp, nil
+	})
+	if err != nil {
+		return experimentConfig{}, err
+	}
+	return v.(experimentConfig), nil
+}
+
+// find returns the config name of schemaType among cfgs.
+func find(cfgs []model.StoredConfig, name, schemaType string) (model.StoredConfig, bool) {
+	for _, cfg := range cfgs {
+		if cfg.Name == name && cfg.Type == schemaType {
+			return cfg, true
+		}
+	}
+	return model.StoredConfig{}, false
+}
+
+// seed is the bucket seed of the variants: the experiment key, followed by
+// the salt when there is one, so changing the salt reshuffles every
+// assignment while leaving the layer and holdouts as they are.
+func (e experimentConfig) seed() string {
+	if e.Salt == "" {
+		return e.ExperimentKey
+	}
+	return e.ExperimentKey + "." + e.Salt
+}
+
+// seed is the bucket seed of the layer, shared by its experiments. The
+// prefix keeps it apart from config names, which cannot hold a colon.
+func (l layer) seed() string {
+	return "layer:" + l.Name
+}
+
+func (e experimentConfig) control() string {
+	for _, v := range e.Variants {
+		if v.Name == controlVariant {
+			return v.Name
+		}
+	}
+	if len(e.Variants) == 0 {
+		return ""
+	}
+	return e.Variants[0].Name
+}
+
+// pick returns the variant whose share of [0, 100) holds p. The weights sum
+// to 100; the last weighted variant takes what rounding leaves over.
+func (e experimentConfig) pick(p float64) string {
+	var upper float64
+	last := ""
+	for _, v := range e.Variants {
+		if v.Weight <= 0 {
+			continue
+		}
+		upper += v.Weight
+		last = v.Name
+		if p < upper {
+			return v.Name
+		}
+	}
+	return last
+}
+
+// mismatch returns why ec is outside the audience, or "" when it is in. A
+// nil audience holds everyone; a listed condition needs the attribute, and
+// listed segments need ec in one of them.
+func (a *audience) mismatch(ec model.Context, segs segments) (string, error) {
+	if a == nil {
+		return "", nil
+	}
+	if len(a.Countries) > 0 && !listed(a.Countries, attribute(ec, model.AttrCountry)) {
+		return model.ReasonCountryMismatch, nil
+	}
+	if len(a.OS) > 0 && !listed(a.OS, attribute(ec, model.AttrOS)) {
+		return model.ReasonOSMismatch, nil
+	}
+	if a.MinAppVersion != "" {
+		v := attribute(ec, model.AttrAppVersion)
+		if !semver.Valid(v) || semver.Compare(v, a.MinAppVersion) < 0 {
+			return model.ReasonAppVersionTooLow, nil
+		}
+	}
+	if len(a.Segments) > 0 {
+		in, err := segs.containsAny(a.Segments, targeting.Context{Key: ec.Key, Attributes: ec.Attributes})
+		if err != nil || !in {
+			return model.ReasonSegmentMismatch, err
+		}
+	}
+	return "", nil
+}
+
+// attribute returns the string attribute name of ec, or "".
+func attribute(ec model.Context, name string) string {
+	s, _ := ec.Attributes[name].(string)
+	return strings.TrimSpace(s)
+}
+
+// listed reports whether v is in list, ignoring case: "id" matches "ID".
+func listed(list []string, v string) bool {
+	if v == "" {
+		return false
+	}
+	for _, x := range list {
+		if strings.EqualFold(x, v) {
+			return true
+		}
+	}
+	return false
+}
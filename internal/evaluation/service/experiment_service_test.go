@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"configuration-management-service/internal/evaluation/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func experiment(name, data string) model.StoredConfig {
+	return model.StoredConfig{Name: name, Type: "experiment_config", Version: 2, Data: json.RawMessage(data)}
+}
+
+func Test_service_AssignExperiment(t *testing.T) {
+	const variants = `"variants":[{"name":"blue","weight":25},{"name":"control","weight":45},{"name":"green","weight":30}]`
+	configs := storedConfigs{
+		experiment("checkout", `{"experiment_key":"checkout-button","active":true,`+variants+`}`),
+		experiment("paused", `{"experiment_key":"checkout-button","active":false,`+variants+`}`),
+		experiment("no-control", `{"experiment_key":"search","active":false,"variants":[{"name":"A","weight":50},{"name":"B","weight":50}]}`),
+		experiment("targeted", `{"experiment_key":"checkout-button","active":true,`+variants+`,
+			"audience":{"countries":["ID","SG"],"os":["android"],"min_app_version":"5.2.0"}}`),
+		experiment("staff", `{"experiment_key":"checkout-button","active":true,`+variants+`,"audience":{"segments":["gone","employees"]}}`),
+		segment("employees", `{"include":["user-1"],"clauses":[{"attribute":"email","op":"matches","values":["@example\\.com$"]}]}`),
+		toggle("flag", `{"enabled":true}`),
+	}
+	in := map[string]any{"country": "id", "os": "android", "app_version": "5.10.1"}
+
+	cases := []struct {
+		name string
+		exp  string
+		ec   model.Context
+		res  model.ExperimentResult
+		err  error
+	}{
+		{
+			name: "when experiment does not exist should return ErrNotFound",
+			exp:  "nope",
+			err:  ErrNotFound,
+		},
+		{
+			name: "when config is not an experiment_config should return ErrInvalidInput",
+			exp:  "flag",
+			err:  ErrInvalidInput,
+		},
+		{
+			name: "when experiment is inactive should return control",
+			exp:  "paused",
+			ec:   model.Context{Key: "user-2"},
+			res:  model.ExperimentResult{Experiment: "paused", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Reason: model.ReasonInactive},
+		},
+		{
+			name: "when inactive experiment has no control variant should return the first",
+			exp:  "no-control",
+			res:  model.ExperimentResult{Experiment: "no-control", Version: 2, ExperimentKey: "search", Variant: "A", Reason: model.ReasonInactive},
+		},
+		{
+			name: "when bucket falls in the first weight should assign the first variant",
+			exp:  "checkout",
+			ec:   model.Context{Key: "user-2"},
+			res:  model.ExperimentResult{Experiment: "checkout", Version: 2, ExperimentKey: "checkout-button", Variant: "blue", Eligible: true, Reason: model.ReasonAssigned, Bucket: ptr(20.8)},
+		},
+		{
+			name: "when bucket falls in the middle weight should assign the middle variant",
+			exp:  "checkout",
+			ec:   model.Context{Key: "user-9"},
+			res:  model.ExperimentResult{Experiment: "checkout", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Eligible: true, Reason: model.ReasonAssigned, Bucket: ptr(50.79)},
+		},
+		{
+			name: "when context has no key should return control",
+			exp:  "checkout",
+			res:  model.ExperimentResult{Experiment: "checkout", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Reason: model.ReasonMissingKey},
+		},
+		{
+			name: "when context matches the audience should assign by bucket",
+			exp:  "targeted",
+			ec:   model.Context{Key: "user-1", Attributes: in},
+			res:  model.ExperimentResult{Experiment: "targeted", Version: 2, ExperimentKey: "checkout-button", Variant: "green", Eligible: true, Reason: model.ReasonAssigned, Bucket: ptr(90.86)},
+		},
+		{
+			name: "when country is not targeted should return control",
+			exp:  "targeted",
+			ec:   model.Context{Key: "user-1", Attributes: map[string]any{"country": "MY", "os": "android", "app_version": "5.10.1"}},
+			res:  model.ExperimentResult{Experiment: "targeted", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Reason: model.ReasonCountryMismatch},
+		},
+		{
+			name: "when os attribute is missing should return control",
+			exp:  "targeted",
+			ec:   model.Context{Key: "user-1", Attributes: map[string]any{"country": "ID", "app_version": "5.10.1"}},
+			res:  model.ExperimentResult{Experiment: "targeted", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Reason: model.ReasonOSMismatch},
+		},
+		{
+			name: "when app version is below the minimum by semver should return control",
+			exp:  "targeted",
+			ec:   model.Context{Key: "user-1", Attributes: map[string]any{"country": "ID", "os": "android", "app_version": "5.2.0-rc.1"}},
+			res:  model.ExperimentResult{Experiment: "targeted", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Reason: model.ReasonAppVersionTooLow},
+		},
+		{
+			name: "when app version is not a version should return control",
+			exp:  "targeted",
+			ec:   model.Context{Key: "user-1", Attributes: map[string]any{"country": "ID", "os": "android", "app_version": 6}},
+			res:  model.ExperimentResult{Experiment: "targeted", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Reason: model.ReasonAppVersionTooLow},
+		},
+		{
+			name: "when context is in an audience segment should assign a variant",
+			exp:  "staff",
+			ec:   model.Context{Key: "user-1"},
+			res:  model.ExperimentResult{Experiment: "staff", Version: 2, ExperimentKey: "checkout-button", Variant: "green", Eligible: true, Reason: model.ReasonAssigned, Bucket: ptr(90.86)},
+		},
+		{
+			name: "when context is in no audience segment should return control",
+			exp:  "staff",
+			ec:   model.Context{Key: "user-9", Attributes: map[string]any{"email": "bo@example.org"}},
+			res:  model.ExperimentResult{Experiment: "staff", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Reason: model.ReasonSegmentMismatch},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := service{configs: configs}
+
+			got, err := svc.AssignExperiment(context.Background(), tc.exp, tc.ec)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
+
+func Test_service_AssignExperiment_layersAndHoldouts(t *testing.T) {
+	const variants = `"variants":[{"name":"blue","weight":25},{"name":"control","weight":45},{"name":"green","weight":30}]`
+	configs := storedConfigs{
+		experiment("button", `{"experiment_key":"checkout-button","active":true,`+variants+`,"layer":{"name":"checkout-page","start":0,"end":50}}`),
+		experiment("copy", `{"experiment_key":"checkout-copy","active":true,`+variants+`,"layer":{"name":"checkout-page","start":50,"end":100}}`),
+		experiment("holdout-old", `{"experiment_key":"holdout-old","active":false,"holdout":true,"variants":[{"name":"held-out","weight":99},{"name":"control","weight":1}]}`),
+		experiment("holdout-q4", `{"experiment_key":"holdout-q4","active":true,"holdout":true,"variants":[{"name":"held-out","weight":15},{"name":"control","weight":85}]}`),
+		experiment("salted", `{"experiment_key":"checkout-button","active":true,"salt":"2",`+variants+`}`),
+	}
+
+	cases := []struct {
+		name string
+		exp  string
+		key  string
+		res  model.ExperimentResult
+	}{
+		{
+			name: "when layer bucket is in the range should assign by the experiment bucket",
+			exp:  "button",
+			key:  "user-1",
+			res:  model.ExperimentResult{Experiment: "button", Version: 2, ExperimentKey: "checkout-button", Variant: "green", Eligible: true, Reason: model.ReasonAssigned, Bucket: ptr(90.86), LayerBucket: ptr(26.72)},
+		},
+		{
+			name: "when layer bucket is in the range of another experiment should return control",
+			exp:  "copy",
+			key:  "user-1",
+			res:  model.ExperimentResult{Experiment: "copy", Version: 2, ExperimentKey: "checkout-copy", Variant: "control", Reason: model.ReasonLayerMismatch, LayerBucket: ptr(26.72)},
+		},
+		{
+			name: "when layer bucket is in the upper range should assign the other experiment",
+			exp:  "copy",
+			key:  "user-7",
+			res:  model.ExperimentResult{Experiment: "copy", Version: 2, ExperimentKey: "checkout-copy", Variant: "blue", Eligible: true, Reason: model.ReasonAssigned, Bucket: ptr(16.93), LayerBucket: ptr(55.29)},
+		},
+		{
+			name: "when a holdout holds the context out should return control and name it",
+			exp:  "button",
+			key:  "user-2",
+			res:  model.ExperimentResult{Experiment: "button", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Reason: model.ReasonHoldout, Holdout: "holdout-q4"},
+		},
+		{
+			name: "when the holdout itself is assigned should return its held-out variant",
+			exp:  "holdout-q4",
+			key:  "user-2",
+			res:  model.ExperimentResult{Experiment: "holdout-q4", Version: 2, ExperimentKey: "holdout-q4", Variant: "held-out", Eligible: true, Reason: model.ReasonAssigned, Bucket: ptr(13.29)},
+		},
+		{
+			name: "when experiment has a salt should bucket by key and salt",
+			exp:  "salted",
+			key:  "user-1",
+			res:  model.ExperimentResult{Experiment: "salted", Version: 2, ExperimentKey: "checkout-button", Variant: "control", Eligible: true, Reason: model.ReasonAssigned, Bucket: ptr(66.16)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := service{configs: configs}
+
+			got, err := svc.AssignExperiment(context.Background(), tc.exp, model.Context{Key: tc.key})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
+
+func Test_compileCache_experiment(t *testing.T) {
+	c := newCompileCache()
+	v1 := json.RawMessage(`{"experiment_key":"k","holdout":true,"variants":[{"name":"control","weight":100}]}`)
+	v2 := json.RawMessage(`{"experiment_key":"k","variants":[{"name":"control","weight":100}]}`)
+
+	first, err := c.experiment("hold", v1)
+	assert.NoError(t, err)
+	again, err := c.experiment("hold", v1)
+	assert.NoError(t, err)
+	assert.Same(t, &first.Variants[0], &again.Variants[0], "unchanged experiment should be decoded once")
+
+	changed, err := c.experiment("hold", v2)
+	assert.NoError(t, err)
+	assert.False(t, changed.Holdout, "changed experiment should be decoded again")
+
+	_, err = c.experiment("bad", json.RawMessage(`{"variants":1}`))
+	assert.ErrorContains(t, err, "decode bad:")
+}
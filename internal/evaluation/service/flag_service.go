@@ -0,0 +1,135 @@
+package service
+
+import (
+	"configuration-management-service/internal/evaluation/bucket"
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/pkg/targeting"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const typeFeatureToggle = "feature_toggle"
+
+// featureToggle is the part of a feature_toggle config evaluation reads.
+type featureToggle struct {
+	Enabled           bool            `json:"enabled"`
+	RolloutPercentage *float64        `json:"rollout_percentage"`
+	Rules             json.RawMessage `json:"rules"`
+	Tags              []string        `json:"tags"`
+}
+
+// EvaluateFlag returns the value of the feature_toggle name for ec. An
+// enabled toggle serves the first of its rules whose clauses all match ec,
+// and falls through to enabled and rollout_percentage when none does. A
+// rule naming segments also needs ec in one of them, read from the same
+// snapshot as the toggle. A partial rollout, of a rule or the fallthrough,
+// places the user by bucket.Of(name, ec.Key), so the same user gets the same
+// answer until the percentage moves past their bucket.
+func (s service) EvaluateFlag(ctx context.Context, name string, ec model.Context) (model.FlagResult, error) {
+	var toggle featureToggle
+	cfg, err := s.latest(ctx, name, typeFeatureToggle, &toggle)
+	if err != nil {
+		return model.FlagResult{}, err
+	}
+	rules, err := s.cache.rules(cfg.Name, toggle.Rules)
+	if err != nil {
+		return model.FlagResult{}, err
+	}
+	var segs segments
+	if usesSegments(rules) {
+		toggle = featureToggle{}
+		if cfg, segs, err = s.withSegments(ctx, cfg.Name, typeFeatureToggle, &toggle); err != nil {
+			return model.FlagResult{}, err
+		}
+	}
+
+	res, err := s.evaluateToggle(cfg, toggle, segs, ec)
+	if err != nil {
+		return model.FlagResult{}, err
+	}
+	s.recordFlag(ec, res)
+	return res, nil
+}
+
+// latest reads the latest version of the config name, which must be of
+// type schemaType, and decodes its data into v.
+func (s service) latest(ctx context.Context, name, schemaType string, v any) (model.StoredConfig, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.StoredConfig{}, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+
+	cfg, err := s.configs.Latest(ctx, name)
+	if err != nil {
+		return model.StoredConfig{}, err
+	}
+	if cfg.Type != schemaType {
+		return model.StoredConfig{}, fmt.Errorf("%w: %s is of type %s, not %s", ErrInvalidInput, name, cfg.Type, schemaType)
+	}
+	if err := json.Unmarshal(cfg.Data, v); err != nil {
+		return model.StoredConfig{}, fmt.Errorf("decode %s: %w", name, err)
+	}
+	return cfg, nil
+}
+
+func (s service) evaluateToggle(cfg model.StoredConfig, toggle featureToggle, segs segments, ec model.Context) (model.FlagResult, error) {
+	res := model.FlagResult{Flag: cfg.Name, Version: cfg.Version}
+	if !toggle.Enabled {
+		res.Reason = model.ReasonDisabled
+		return res, nil
+	}
+
+	rules, err := s.cache.rules(cfg.Name, toggle.Rules)
+	if err != nil {
+		return model.FlagResult{}, err
+	}
+	tc := targeting.Context{Key: ec.Key, Attributes: ec.Attributes}
+	for i, r := range rules {
+		if !r.match.Match(tc) {
+			continue
+		}
+		if len(r.segments) > 0 {
+			in, err := segs.containsAny(r.segments, tc)
+			if err != nil {
+				return model.FlagResult{}, err
+			}
+			if !in {
+				continue
+			}
+		}
+		i := i
+		res.Rule = &i
+		switch {
+		case r.serve != nil:
+			res.Value, res.Reason = *r.serve, model.ReasonRuleMatch
+		case ec.Key == "":
+			res.Reason, res.RolloutPercentage = model.ReasonMissingKey, r.pct
+		default:
+			place(&res, cfg.Name, ec.Key, r.pct)
+			res.Reason = model.ReasonRuleMatch
+		}
+		return res, nil
+	}
+
+	pct := toggle.RolloutPercentage
+	switch {
+	case pct == nil || *pct >= 100:
+		res.Value, res.Reason = true, model.ReasonEnabled
+	case ec.Key == "":
+		res.Reason, res.RolloutPercentage = model.ReasonMissingKey, pct
+	default:
+		place(&res, cfg.Name, ec.Key, pct)
+		res.Reason = model.ReasonRollout
+	}
+	return res, nil
+}
+
+// place decides res by the bucket of key in the flag name against pct.
+func place(res *model.FlagResult, name, key string, pct *float64) {
+	b := bucket.Of(name, key)
+	p := bucket.Percent(b)
+	res.Value = bucket.In(b, *pct)
+	res.Bucket, res.RolloutPercentage = &p, pct
+}
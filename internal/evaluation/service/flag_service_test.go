@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/pkg/targeting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// storedConfigs is a config store holding cfgs, sorted by name.
+type storedConfigs []model.StoredConfig
+
+func (s storedConfigs) Latest(ctx context.Context, name string) (model.StoredConfig, error) {
+	for _, c := range s {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return model.StoredConfig{}, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+func (s storedConfigs) Snapshot(ctx context.Context, types []string) ([]model.StoredConfig, error) {
+	var out []model.StoredConfig
+	for _, c := range s {
+		for _, t := range types {
+			if c.Type == t {
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+func toggle(name, data string) model.StoredConfig {
+	return model.StoredConfig{Name: name, Type: "feature_toggle", Version: 3, Data: json.RawMessage(data)}
+}
+
+func ptr(f float64) *float64 { return &f }
+
+func iptr(i int) *int { return &i }
+
+func Test_service_EvaluateFlag(t *testing.T) {
+	configs := storedConfigs{
+		toggle("off", `{"enabled":false,"rollout_percentage":100}`),
+		toggle("on", `{"enabled":true}`),
+		toggle("full", `{"enabled":true,"rollout_percentage":100}`),
+		toggle("checkout", `{"enabled":true,"rollout_percentage":25}`),
+		toggle("targeted", `{"enabled":true,"rollout_percentage":10,"rules":[
+			{"clauses":[{"attribute":"email","op":"matches","values":["@example\\.com$"]}],"serve":true},
+			{"clauses":[{"attribute":"country","op":"in","values":["ID"]},{"attribute":"app_version","op":"semver_gte","values":["5.2.0"]}],"rollout_percentage":50},
+			{"clauses":[{"attribute":"country","op":"in","values":["SG"]}],"serve":false}
+		]}`),
+		toggle("killed", `{"enabled":false,"rules":[{"clauses":[{"attribute":"key","op":"in","values":["user-1"]}],"serve":true}]}`),
+		model.StoredConfig{Name: "limits", Type: "rate_limit_policy", Version: 1, Data: json.RawMessage(`{}`)},
+	}
+
+	cases := []struct {
+		name string
+		flag string
+		ec   model.Context
+		res  model.FlagResult
+		err  error
+	}{
+		{
+			name: "when name is blank should return ErrInvalidInput",
+			flag: " ",
+			err:  ErrInvalidInput,
+		},
+		{
+			name: "when flag does not exist should return ErrNotFound",
+			flag: "nope",
+			err:  ErrNotFound,
+		},
+		{
+			name: "when config is not a feature_toggle should return ErrInvalidInput",
+			flag: "limits",
+			err:  ErrInvalidInput,
+		},
+		{
+			name: "when toggle is disabled should be off whatever the rollout",
+			flag: "off",
+			ec:   model.Context{Key: "user-1"},
+			res:  model.FlagResult{Flag: "off", Version: 3, Reason: model.ReasonDisabled},
+		},
+		{
+			name: "when toggle has no rollout should be on without a key",
+			flag: "on",
+			res:  model.FlagResult{Flag: "on", Version: 3, Value: true, Reason: model.ReasonEnabled},
+		},
+		{
+			name: "when rollout is 100 should be on for everyone",
+			flag: "full",
+			res:  model.FlagResult{Flag: "full", Version: 3, Value: true, Reason: model.ReasonEnabled},
+		},
+		{
+			name: "when partial rollout has no user key should be off",
+			flag: "checkout",
+			res:  model.FlagResult{Flag: "checkout", Version: 3, Reason: model.ReasonMissingKey, RolloutPercentage: ptr(25)},
+		},
+		{
+			name: "when user bucket is below the percentage should be on",
+			flag: "checkout",
+			ec:   model.Context{Key: "user-7"},
+			res:  model.FlagResult{Flag: "checkout", Version: 3, Value: true, Reason: model.ReasonRollout, Bucket: ptr(12.74), RolloutPercentage: ptr(25)},
+		},
+		{
+			name: "when user bucket is above the percentage should be off",
+			flag: "checkout",
+			ec:   model.Context{Key: "user-1"},
+			res:  model.FlagResult{Flag: "checkout", Version: 3, Reason: model.ReasonRollout, Bucket: ptr(93.79), RolloutPercentage: ptr(25)},
+		},
+		{
+			name: "when toggle is disabled should not look at its rules",
+			flag: "killed",
+			ec:   model.Context{Key: "user-1"},
+			res:  model.FlagResult{Flag: "killed", Version: 3, Reason: model.ReasonDisabled},
+		},
+		{
+			name: "when first rule matches should serve its value",
+			flag: "targeted",
+			ec:   model.Context{Key: "user-1", Attributes: map[string]any{"email": "ana@example.com", "country": "SG"}},
+			res:  model.FlagResult{Flag: "targeted", Version: 3, Value: true, Reason: model.ReasonRuleMatch, Rule: iptr(0)},
+		},
+		{
+			name: "when rollout rule matches and bucket is inside should be on",
+			flag: "targeted",
+			ec:   model.Context{Key: "user-7", Attributes: map[string]any{"country": "ID", "app_version": "5.10.0"}},
+			res:  model.FlagResult{Flag: "targeted", Version: 3, Value: true, Reason: model.ReasonRuleMatch, Rule: iptr(1), Bucket: ptr(37.66), RolloutPercentage: ptr(50)},
+		},
+		{
+			name: "when rollout rule matches and bucket is outside should be off",
+			flag: "targeted",
+			ec:   model.Context{Key: "user-1", Attributes: map[string]any{"country": "ID", "app_version": "5.2.0"}},
+			res:  model.FlagResult{Flag: "targeted", Version: 3, Reason: model.ReasonRuleMatch, Rule: iptr(1), Bucket: ptr(80.02), RolloutPercentage: ptr(50)},
+		},
+		{
+			name: "when rollout rule matches without a user key should be off",
+			flag: "targeted",
+			ec:   model.Context{Attributes: map[string]any{"country": "ID", "app_version": "6.0.0"}},
+			res:  model.FlagResult{Flag: "targeted", Version: 3, Reason: model.ReasonMissingKey, Rule: iptr(1), RolloutPercentage: ptr(50)},
+		},
+		{
+			name: "when a later rule matches should serve its value",
+			flag: "targeted",
+			ec:   model.Context{Key: "user-7", Attributes: map[string]any{"country": "SG"}},
+			res:  model.FlagResult{Flag: "targeted", Version: 3, Reason: model.ReasonRuleMatch, Rule: iptr(2)},
+		},
+		{
+			name: "when no rule matches should fall through to the rollout",
+			flag: "targeted",
+			ec:   model.Context{Key: "user-7", Attributes: map[string]any{"country": "ID", "app_version": "5.1.9"}},
+			res:  model.FlagResult{Flag: "targeted", Version: 3, Reason: model.ReasonRollout, Bucket: ptr(37.66), RolloutPercentage: ptr(10)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := service{configs: configs, cache: newCompileCache()}
+
+			got, err := svc.EvaluateFlag(context.Background(), tc.flag, tc.ec)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
+
+func Test_compileCache_rules(t *testing.T) {
+	c := newCompileCache()
+	v1 := json.RawMessage(`[{"clauses":[{"attribute":"country","op":"in","values":["ID"]}],"serve":true}]`)
+	v2 := json.RawMessage(`[{"clauses":[{"attribute":"country","op":"in","values":["SG"]}],"serve":true}]`)
+	id := targeting.Context{Attributes: map[string]any{"country": "ID"}}
+
+	first, err := c.rules("flag", v1)
+	assert.NoError(t, err)
+	again, err := c.rules("flag", v1)
+	assert.NoError(t, err)
+	assert.Same(t, &first[0], &again[0], "unchanged rules should be compiled once")
+
+	changed, err := c.rules("flag", v2)
+	assert.NoError(t, err)
+	assert.False(t, changed[0].match.Match(id), "changed rules should be recompiled")
+
+	_, err = c.rules("bad", json.RawMessage(`[{"clauses":[{"attribute":"email","op":"matches","values":["("]}],"serve":true}]`))
+	assert.EqualError(t, err, "bad rules.0: clauses.0.values.0: invalid regular expression: error parsing regexp: missing closing ): `(`")
+}
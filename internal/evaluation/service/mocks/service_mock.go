@@ -0,0 +1,241 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/evaluation/service/service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "configuration-management-service/internal/evaluation/model"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConfigSource is a mock of ConfigSource interface.
+type MockConfigSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigSourceMockRecorder
+}
+
+// MockConfigSourceMockRecorder is the mock recorder for MockConfigSource.
+type MockConfigSourceMockRecorder struct {
+	mock *MockConfigSource
+}
+
+// NewMockConfigSource creates a new mock instance.
+func NewMockConfigSource(ctrl *gomock.Controller) *MockConfigSource {
+	mock := &MockConfigSource{ctrl: ctrl}
+	mock.recorder = &MockConfigSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigSource) EXPECT() *MockConfigSourceMockRecorder {
+	return m.recorder
+}
+
+// Latest mocks base method.
+func (m *MockConfigSource) Latest(ctx context.Context, name string) (model.StoredConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Latest", ctx, name)
+	ret0, _ := ret[0].(model.StoredConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Latest indicates an expected call of Latest.
+func (mr *MockConfigSourceMockRecorder) Latest(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockConfigSource)(nil).Latest), ctx, name)
+}
+
+// Snapshot mocks base method.
+func (m *MockConfigSource) Snapshot(ctx context.Context, types []string) ([]model.StoredConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, types)
+	ret0, _ := ret[0].([]model.StoredConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockConfigSourceMockRecorder) Snapshot(ctx, types interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockConfigSource)(nil).Snapshot), ctx, types)
+}
+
+// MockEventRecorder is a mock of EventRecorder interface.
+type MockEventRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRecorderMockRecorder
+}
+
+// MockEventRecorderMockRecorder is the mock recorder for MockEventRecorder.
+type MockEventRecorderMockRecorder struct {
+	mock *MockEventRecorder
+}
+
+// NewMockEventRecorder creates a new mock instance.
+func NewMockEventRecorder(ctrl *gomock.Controller) *MockEventRecorder {
+	mock := &MockEventRecorder{ctrl: ctrl}
+	mock.recorder = &MockEventRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRecorder) EXPECT() *MockEventRecorderMockRecorder {
+	return m.recorder
+}
+
+// Counters mocks base method.
+func (m *MockEventRecorder) Counters(name string) model.EventCounters {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Counters", name)
+	ret0, _ := ret[0].(model.EventCounters)
+	return ret0
+}
+
+// Counters indicates an expected call of Counters.
+func (mr *MockEventRecorderMockRecorder) Counters(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Counters", reflect.TypeOf((*MockEventRecorder)(nil).Counters), name)
+}
+
+// Record mocks base method.
+func (m *MockEventRecorder) Record(userKey string, e model.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", userKey, e)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockEventRecorderMockRecorder) Record(userKey, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockEventRecorder)(nil).Record), userKey, e)
+}
+
+// MockIService is a mock of IService interface.
+type MockIService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceMockRecorder
+}
+
+// MockIServiceMockRecorder is the mock recorder for MockIService.
+type MockIServiceMockRecorder struct {
+	mock *MockIService
+}
+
+// NewMockIService creates a new mock instance.
+func NewMockIService(ctrl *gomock.Controller) *MockIService {
+	mock := &MockIService{ctrl: ctrl}
+	mock.recorder = &MockIServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIService) EXPECT() *MockIServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignExperiment mocks base method.
+func (m *MockIService) AssignExperiment(ctx context.Context, name string, ec model.Context) (model.ExperimentResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignExperiment", ctx, name, ec)
+	ret0, _ := ret[0].(model.ExperimentResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignExperiment indicates an expected call of AssignExperiment.
+func (mr *MockIServiceMockRecorder) AssignExperiment(ctx, name, ec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignExperiment", reflect.TypeOf((*MockIService)(nil).AssignExperiment), ctx, name, ec)
+}
+
+// Counters mocks base method.
+func (m *MockIService) Counters(ctx context.Context, name string) (model.EventCounters, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Counters", ctx, name)
+	ret0, _ := ret[0].(model.EventCounters)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Counters indicates an expected call of Counters.
+func (mr *MockIServiceMockRecorder) Counters(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Counters", reflect.TypeOf((*MockIService)(nil).Counters), ctx, name)
+}
+
+// Evaluate mocks base method.
+func (m *MockIService) Evaluate(ctx context.Context, req model.EvaluateRequest) (model.EvaluateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx, req)
+	ret0, _ := ret[0].(model.EvaluateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockIServiceMockRecorder) Evaluate(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockIService)(nil).Evaluate), ctx, req)
+}
+
+// EvaluateFlag mocks base method.
+func (m *MockIService) EvaluateFlag(ctx context.Context, name string, ec model.Context) (model.FlagResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateFlag", ctx, name, ec)
+	ret0, _ := ret[0].(model.FlagResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvaluateFlag indicates an expected call of EvaluateFlag.
+func (mr *MockIServiceMockRecorder) EvaluateFlag(ctx, name, ec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateFlag", reflect.TypeOf((*MockIService)(nil).EvaluateFlag), ctx, name, ec)
+}
+
+// NextRuns mocks base method.
+func (m *MockIService) NextRuns(ctx context.Context, name string, from time.Time, count int) (model.ScheduleRuns, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextRuns", ctx, name, from, count)
+	ret0, _ := ret[0].(model.ScheduleRuns)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextRuns indicates an expected call of NextRuns.
+func (mr *MockIServiceMockRecorder) NextRuns(ctx, name, from, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextRuns", reflect.TypeOf((*MockIService)(nil).NextRuns), ctx, name, from, count)
+}
+
+// RecordServed mocks base method.
+func (m *MockIService) RecordServed(ctx context.Context, ec model.Context, res model.EvaluateResult) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordServed", ctx, ec, res)
+}
+
+// RecordServed indicates an expected call of RecordServed.
+func (mr *MockIServiceMockRecorder) RecordServed(ctx, ec, res interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordServed", reflect.TypeOf((*MockIService)(nil).RecordServed), ctx, ec, res)
+}
+
+// ScheduleStatus mocks base method.
+func (m *MockIService) ScheduleStatus(ctx context.Context, name string, at time.Time) (model.ScheduleStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleStatus", ctx, name, at)
+	ret0, _ := ret[0].(model.ScheduleStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleStatus indicates an expected call of ScheduleStatus.
+func (mr *MockIServiceMockRecorder) ScheduleStatus(ctx, name, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleStatus", reflect.TypeOf((*MockIService)(nil).ScheduleStatus), ctx, name, at)
+}
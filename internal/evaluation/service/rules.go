@@ -0,0 +1,97 @@
+package service
+
+import (
+	"configuration-management-service/pkg/targeting"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// toggleRule is one targeting rule of a feature_toggle. Exactly one of Serve
+// and RolloutPercentage is set.
+type toggleRule struct {
+	Clauses           []targeting.Clause `json:"clauses"`
+	Segments          []string           `json:"segments"`
+	Serve             *bool              `json:"serve"`
+	RolloutPercentage *float64           `json:"rollout_percentage"`
+}
+
+type compiledRule struct {
+	match    targeting.Matcher
+	segments []string
+	serve    *bool
+	pct      *float64
+}
+
+// compileCache keeps what was compiled from the rules of each toggle, the
+// clauses of each segment and the data of each experiment for as long as
+// they are unchanged, so regular expressions, versions and experiments are
+// parsed once per version rather than once per evaluation. Config names are
+// unique across types, so entries are keyed by name alone. A nil cache
+// compiles every time.
+type compileCache struct {
+	byName sync.Map // name -> cached
+}
+
+type cached struct {
+	raw string
+	v   any
+}
+
+func newCompileCache() *compileCache {
+	return &compileCache{}
+}
+
+// load returns what build compiled from raw for name, building it when the
+// cache holds nothing or something compiled from other data.
+func (c *compileCache) load(name string, raw []byte, build func() (any, error)) (any, error) {
+	if c != nil {
+		if hit, ok := c.byName.Load(name); ok && hit.(cached).raw == string(raw) {
+			return hit.(cached).v, nil
+		}
+	}
+	v, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if c != nil {
+		c.byName.Store(name, cached{raw: string(raw), v: v})
+	}
+	return v, nil
+}
+
+// rules returns the compiled rules of the toggle name.
+func (c *compileCache) rules(name string, raw json.RawMessage) ([]compiledRule, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	v, err := c.load(name, raw, func() (any, error) {
+		var rules []toggleRule
+		if err := json.Unmarshal(raw, &rules); err != nil {
+			return nil, fmt.Errorf("decode %s rules: %w", name, err)
+		}
+		out := make([]compiledRule, len(rules))
+		for i, r := range rules {
+			m, err := targeting.Compile(r.Clauses)
+			if err != nil {
+				return nil, fmt.Errorf("%s rules.%d: %w", name, i, err)
+			}
+			out[i] = compiledRule{match: m, segments: r.Segments, serve: r.Serve, pct: r.RolloutPercentage}
+		}
+		return out, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]compiledRule), nil
+}
+
+// usesSegments reports whether any rule names a segment.
+func usesSegments(rules []compiledRule) bool {
+	for _, r := range rules {
+		if len(r.segments) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/pkg/schedule"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // rule zones must not depend on the host's zoneinfo
+)
+
+const typeScheduleRule = "schedule_rule"
+
+// scheduleRule is the data of a schedule_rule config.
+type scheduleRule struct {
+	Active   bool           `json:"active"`
+	Timezone string         `json:"timezone"`
+	Cron     string         `json:"cron"`
+	Windows  []model.Window `json:"windows"`
+}
+
+// ScheduleStatus reports whether the schedule_rule name is active at at: it
+// must be active and, when it lists windows, at must be in one of them. A
+// zero at is now.
+func (s service) ScheduleStatus(ctx context.Context, name string, at time.Time) (model.ScheduleStatus, error) {
+	var rule scheduleRule
+	cfg, err := s.latest(ctx, name, typeScheduleRule, &rule)
+	if err != nil {
+		return model.ScheduleStatus{}, err
+	}
+	loc, err := rule.location()
+	if err != nil {
+		return model.ScheduleStatus{}, err
+	}
+	if at.IsZero() {
+		at = s.now()
+	}
+
+	res := model.ScheduleStatus{Schedule: cfg.Name, Version: cfg.Version, Timezone: rule.Timezone, At: at.In(loc)}
+	switch {
+	case !rule.Active:
+		res.Reason = model.ReasonScheduleInactive
+	case len(rule.Windows) == 0:
+		res.Active, res.Reason = true, model.ReasonNoWindows
+	default:
+		res.Reason = model.ReasonOutsideWindows
+		for _, w := range rule.Windows {
+			// Windows hold their start but not their end.
+			if !at.Before(w.Start) && at.Before(w.End) {
+				w := model.Window{Start: w.Start.In(loc), End: w.End.In(loc)}
+				res.Active, res.Reason, res.Window = true, model.ReasonInWindow, &w
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// NextRuns returns the next count fire times of the cron expression of the
+// schedule_rule name after from, in the rule's time zone. A zero from is
+// now.
+func (s service) NextRuns(ctx context.Context, name string, from time.Time, count int) (model.ScheduleRuns, error) {
+	if count <= 0 {
+		return model.ScheduleRuns{}, fmt.Errorf("%w: count must be positive", ErrInvalidInput)
+	}
+	var rule scheduleRule
+	cfg, err := s.latest(ctx, name, typeScheduleRule, &rule)
+	if err != nil {
+		return model.ScheduleRuns{}, err
+	}
+	if strings.TrimSpace(rule.Cron) == "" {
+		return model.ScheduleRuns{}, fmt.Errorf("%w: %s has no cron expression", ErrInvalidInput, cfg.Name)
+	}
+	loc, err := rule.location()
+	if err != nil {
+		return model.ScheduleRuns{}, err
+	}
+	sched, err := schedule.Parse(rule.Cron)
+	if err != nil {
+		return model.ScheduleRuns{}, fmt.Errorf("%s: cron: %w", cfg.Name, err)
+	}
+	if from.IsZero() {
+		from = s.now()
+	}
+
+	return model.ScheduleRuns{
+		Schedule: cfg.Name,
+		Version:  cfg.Version,
+		Timezone: rule.Timezone,
+		Cron:     rule.Cron,
+		From:     from.In(loc),
+		Runs:     sched.List(from, loc, count),
+	}, nil
+}
+
+// location loads the rule's zone. Stored rules passed validation, so a
+// zone that does not load is an internal error.
+func (r scheduleRule) location() (*time.Location, error) {
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("timezone: %w", err)
+	}
+	return loc, nil
+}
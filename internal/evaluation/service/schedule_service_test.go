@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"configuration-management-service/internal/evaluation/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scheduleConfig(name, data string) model.StoredConfig {
+	return model.StoredConfig{Name: name, Type: "schedule_rule", Version: 4, Data: json.RawMessage(data)}
+}
+
+func Test_service_ScheduleStatus(t *testing.T) {
+	const windows = `"windows":[{"start":"2025-12-24T00:00:00+07:00","end":"2025-12-26T00:00:00+07:00"},{"start":"2025-12-31T17:00:00Z","end":"2026-01-01T17:00:00Z"}]`
+	configs := storedConfigs{
+		scheduleConfig("always", `{"active":true,"timezone":"Asia/Jakarta"}`),
+		scheduleConfig("holidays", `{"active":true,"timezone":"Asia/Jakarta",`+windows+`}`),
+		scheduleConfig("paused", `{"active":false,"timezone":"Asia/Jakarta",`+windows+`}`),
+		toggle("flag", `{"enabled":true}`),
+	}
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Date(2025, 12, 25, 12, 0, 0, 0, time.UTC)
+	at := func(s string) time.Time {
+		v, _ := time.Parse(time.RFC3339, s)
+		return v
+	}
+
+	cases := []struct {
+		name     string
+		schedule string
+		at       time.Time
+		res      model.ScheduleStatus
+		err      error
+	}{
+		{
+			name:     "when schedule does not exist should return ErrNotFound",
+			schedule: "nope",
+			err:      ErrNotFound,
+		},
+		{
+			name:     "when config is not a schedule_rule should return ErrInvalidInput",
+			schedule: "flag",
+			err:      ErrInvalidInput,
+		},
+		{
+			name:     "when rule is not active should be inactive in a window",
+			schedule: "paused",
+			res:      model.ScheduleStatus{Schedule: "paused", Version: 4, Timezone: "Asia/Jakarta", At: now.In(jakarta), Reason: model.ReasonScheduleInactive},
+		},
+		{
+			name:     "when rule lists no windows should be active at any time",
+			schedule: "always",
+			at:       at("2030-01-01T00:00:00Z"),
+			res:      model.ScheduleStatus{Schedule: "always", Version: 4, Timezone: "Asia/Jakarta", At: at("2030-01-01T00:00:00Z").In(jakarta), Active: true, Reason: model.ReasonNoWindows},
+		},
+		{
+			name:     "when no instant is given should check now in the rule's zone",
+			schedule: "holidays",
+			res: model.ScheduleStatus{Schedule: "holidays", Version: 4, Timezone: "Asia/Jakarta", At: now.In(jakarta), Active: true, Reason: model.ReasonInWindow,
+				Window: &model.Window{Start: at("2025-12-24T00:00:00+07:00").In(jakarta), End: at("2025-12-26T00:00:00+07:00").In(jakarta)}},
+		},
+		{
+			name:     "when instant is the start of a window should be active",
+			schedule: "holidays",
+			at:       at("2026-01-01T00:00:00+07:00"),
+			res: model.ScheduleStatus{Schedule: "holidays", Version: 4, Timezone: "Asia/Jakarta", At: at("2026-01-01T00:00:00+07:00").In(jakarta), Active: true, Reason: model.ReasonInWindow,
+				Window: &model.Window{Start: at("2025-12-31T17:00:00Z").In(jakarta), End: at("2026-01-01T17:00:00Z").In(jakarta)}},
+		},
+		{
+			name:     "when instant is the end of a window should be outside it",
+			schedule: "holidays",
+			at:       at("2025-12-25T17:00:00Z"),
+			res:      model.ScheduleStatus{Schedule: "holidays", Version: 4, Timezone: "Asia/Jakarta", At: at("2025-12-25T17:00:00Z").In(jakarta), Reason: model.ReasonOutsideWindows},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := service{configs: configs, clock: func() time.Time { return now }}
+
+			got, err := svc.ScheduleStatus(context.Background(), tc.schedule, tc.at)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
+
+func Test_service_NextRuns(t *testing.T) {
+	configs := storedConfigs{
+		scheduleConfig("nightly", `{"active":true,"timezone":"America/New_York","cron":"30 2 * * *"}`),
+		scheduleConfig("windows-only", `{"active":true,"timezone":"Asia/Jakarta"}`),
+	}
+	newYork, _ := time.LoadLocation("America/New_York")
+	now := time.Date(2025, 3, 8, 17, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		schedule string
+		from     time.Time
+		count    int
+		res      model.ScheduleRuns
+		err      string
+	}{
+		{
+			name:     "when count is not positive should return ErrInvalidInput",
+			schedule: "nightly",
+			err:      "invalid input: count must be positive",
+		},
+		{
+			name:     "when rule has no cron should return ErrInvalidInput",
+			schedule: "windows-only",
+			count:    3,
+			err:      "invalid input: windows-only has no cron expression",
+		},
+		{
+			name:     "when run is skipped by spring forward should list it when the clocks jump",
+			schedule: "nightly",
+			count:    3,
+			res: model.ScheduleRuns{Schedule: "nightly", Version: 4, Timezone: "America/New_York", Cron: "30 2 * * *", From: now.In(newYork), Runs: []time.Time{
+				time.Date(2025, 3, 9, 3, 0, 0, 0, newYork),
+				time.Date(2025, 3, 10, 2, 30, 0, 0, newYork),
+				time.Date(2025, 3, 11, 2, 30, 0, 0, newYork),
+			}},
+		},
+		{
+			name:     "when from is given should list the runs after it",
+			schedule: "nightly",
+			from:     time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC),
+			count:    2,
+			res: model.ScheduleRuns{Schedule: "nightly", Version: 4, Timezone: "America/New_York", Cron: "30 2 * * *", From: time.Date(2025, 11, 1, 8, 0, 0, 0, newYork), Runs: []time.Time{
+				time.Date(2025, 11, 2, 2, 30, 0, 0, newYork),
+				time.Date(2025, 11, 3, 2, 30, 0, 0, newYork),
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := service{configs: configs, clock: func() time.Time { return now }}
+
+			got, err := svc.NextRuns(context.Background(), tc.schedule, tc.from, tc.count)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.res.Runs, got.Runs)
+			got.Runs, tc.res.Runs = nil, nil
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/pkg/targeting"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const typeSegment = "segment"
+
+// segmentConfig is the data of a segment config. A context is in the
+// segment when its key is included, or when it is not excluded and the
+// segment has clauses that all match.
+type segmentConfig struct {
+	Include []string           `json:"include"`
+	Exclude []string           `json:"exclude"`
+	Clauses []targeting.Clause `json:"clauses"`
+}
+
+type compiledSegment struct {
+	include map[string]bool
+	exclude map[string]bool
+	match   targeting.Matcher
+}
+
+func (s compiledSegment) contains(tc targeting.Context) bool {
+	switch {
+	case tc.Key != "" && s.exclude[tc.Key]:
+		return false
+	case tc.Key != "" && s.include[tc.Key]:
+		return true
+	}
+	return len(s.match) > 0 && s.match.Match(tc)
+}
+
+// segments holds the segments of one snapshot by name, compiled on first
+// use.
+type segments struct {
+	cfgs  map[string]model.StoredConfig
+	cache *compileCache
+}
+
+// segmentsOf returns the segments among cfgs.
+func (s service) segmentsOf(cfgs []model.StoredConfig) segments {
+	out := segments{cfgs: map[string]model.StoredConfig{}, cache: s.cache}
+	for _, c := range cfgs {
+		if c.Type == typeSegment {
+			out.cfgs[c.Name] = c
+		}
+	}
+	return out
+}
+
+// containsAny reports whether tc is in any of the named segments. A name
+// missing from the snapshot holds no one.
+func (ss segments) containsAny(names []string, tc targeting.Context) (bool, error) {
+	for _, name := range names {
+		cfg, ok := ss.cfgs[name]
+		if !ok {
+			continue
+		}
+		seg, err := ss.compile(cfg)
+		if err != nil {
+			return false, err
+		}
+		if seg.contains(tc) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (ss segments) compile(cfg model.StoredConfig) (compiledSegment, error) {
+	v, err := ss.cache.load(cfg.Name, cfg.Data, func() (any, error) {
+		var seg segmentConfig
+		if err := json.Unmarshal(cfg.Data, &seg); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", cfg.Name, err)
+		}
+		m, err := targeting.Compile(seg.Clauses)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		return compiledSegment{include: set(seg.Include), exclude: set(seg.Exclude), match: m}, nil
+	})
+	if err != nil {
+		return compiledSegment{}, err
+	}
+	return v.(compiledSegment), nil
+}
+
+// withSegments reads the config name again together with every segment,
+// all from one snapshot, so the segments it references are the versions
+// current with it. v receives the config's data.
+func (s service) withSegments(ctx context.Context, name, schemaType string, v any) (model.StoredConfig, segments, error) {
+	cfgs, err := s.configs.Snapshot(ctx, []string{schemaType, typeSegment})
+	if err != nil {
+		return model.StoredConfig{}, segments{}, err
+	}
+	for _, cfg := range cfgs {
+		if cfg.Name != name || cfg.Type != schemaType {
+			continue
+		}
+		if err := json.Unmarshal(cfg.Data, v); err != nil {
+			return model.StoredConfig{}, segments{}, fmt.Errorf("decode %s: %w", name, err)
+		}
+		return cfg, s.segmentsOf(cfgs), nil
+	}
+	return model.StoredConfig{}, segments{}, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"configuration-management-service/internal/evaluation/model"
+	"configuration-management-service/pkg/targeting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func segment(name, data string) model.StoredConfig {
+	return model.StoredConfig{Name: name, Type: "segment", Version: 1, Data: json.RawMessage(data)}
+}
+
+func Test_segments_containsAny(t *testing.T) {
+	segs := service{}.segmentsOf([]model.StoredConfig{
+		segment("staff", `{"include":["user-1","user-2"],"exclude":["user-3"],"clauses":[{"attribute":"email","op":"matches","values":["@example\\.com$"]}]}`),
+		segment("testers", `{"include":["user-9"]}`),
+		toggle("staff-only", `{"enabled":true}`),
+	})
+	staff := map[string]any{"email": "a@example.com"}
+
+	cases := []struct {
+		name  string
+		names []string
+		tc    targeting.Context
+		want  bool
+	}{
+		{name: "when key is included should hold it", names: []string{"staff"}, tc: targeting.Context{Key: "user-1"}, want: true},
+		{name: "when key is excluded should not hold it even if clauses match", names: []string{"staff"}, tc: targeting.Context{Key: "user-3", Attributes: staff}},
+		{name: "when clauses match should hold the context", names: []string{"staff"}, tc: targeting.Context{Key: "user-4", Attributes: staff}, want: true},
+		{name: "when clauses match without a key should hold the context", names: []string{"staff"}, tc: targeting.Context{Attributes: staff}, want: true},
+		{name: "when segment has no clauses should hold only included keys", names: []string{"testers"}, tc: targeting.Context{Key: "user-4", Attributes: staff}},
+		{name: "when any named segment holds the context should hold it", names: []string{"testers", "staff"}, tc: targeting.Context{Key: "user-9"}, want: true},
+		{name: "when segment is missing should hold no one", names: []string{"gone", "staff-only"}, tc: targeting.Context{Key: "user-1"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := segs.containsAny(tc.names, tc.tc)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// movingConfigs serves an older toggle from Latest than from Snapshot, as
+// when a write lands between the two reads.
+type movingConfigs struct {
+	storedConfigs
+	stale model.StoredConfig
+}
+
+func (m movingConfigs) Latest(ctx context.Context, name string) (model.StoredConfig, error) {
+	return m.stale, nil
+}
+
+func Test_service_EvaluateFlag_segments(t *testing.T) {
+	configs := storedConfigs{
+		segment("staff", `{"include":["user-1"],"exclude":["user-2"]}`),
+		toggle("staff-only", `{"enabled":true,"rollout_percentage":0,"rules":[{"segments":["staff"],"clauses":[{"attribute":"country","op":"in","values":["ID"]}],"serve":true}]}`),
+	}
+	id := map[string]any{"country": "ID"}
+
+	cases := []struct {
+		name    string
+		configs ConfigSource
+		ec      model.Context
+		res     model.FlagResult
+	}{
+		{
+			name:    "when context is in the segment and matches the clauses should serve the rule",
+			configs: configs,
+			ec:      model.Context{Key: "user-1", Attributes: id},
+			res:     model.FlagResult{Flag: "staff-only", Version: 3, Value: true, Reason: model.ReasonRuleMatch, Rule: iptr(0)},
+		},
+		{
+			name:    "when context is in the segment but misses a clause should fall through",
+			configs: configs,
+			ec:      model.Context{Key: "user-1", Attributes: map[string]any{"country": "SG"}},
+			res:     model.FlagResult{Flag: "staff-only", Version: 3, Reason: model.ReasonRollout, Bucket: ptr(83.79), RolloutPercentage: ptr(0)},
+		},
+		{
+			name:    "when context is excluded from the segment should fall through",
+			configs: configs,
+			ec:      model.Context{Key: "user-2", Attributes: id},
+			res:     model.FlagResult{Flag: "staff-only", Version: 3, Reason: model.ReasonRollout, Bucket: ptr(68.39), RolloutPercentage: ptr(0)},
+		},
+		{
+			name: "when the toggle moved after Latest should evaluate the snapshot version with its segments",
+			configs: movingConfigs{
+				storedConfigs: storedConfigs{
+					segment("staff", `{"include":["user-2"]}`),
+					model.StoredConfig{Name: "staff-only", Type: "feature_toggle", Version: 4, Data: json.RawMessage(`{"enabled":true,"rules":[{"segments":["staff"],"serve":false}]}`)},
+				},
+				stale: toggle("staff-only", `{"enabled":true,"rules":[{"segments":["staff"],"serve":true}]}`),
+			},
+			ec:  model.Context{Key: "user-2"},
+			res: model.FlagResult{Flag: "staff-only", Version: 4, Reason: model.ReasonRuleMatch, Rule: iptr(0)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := service{configs: tc.configs, cache: newCompileCache()}
+
+			got, err := svc.EvaluateFlag(context.Background(), "staff-only", tc.ec)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"configuration-management-service/internal/evaluation/model"
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+// ConfigSource reads the latest resolved versions of configs.
+type ConfigSource interface {
+	// Latest returns the config name, or ErrNotFound for an unknown name.
+	Latest(ctx context.Context, name string) (model.StoredConfig, error)
+	// Snapshot returns every config of the given types, sorted by name, as
+	// of one instant.
+	Snapshot(ctx context.Context, types []string) ([]model.StoredConfig, error)
+}
+
+// EventRecorder receives an event for every flag value and experiment
+// variant served, and counts them.
+type EventRecorder interface {
+	Record(userKey string, e model.Event)
+	Counters(name string) model.EventCounters
+}
+
+type IService interface {
+	EvaluateFlag(ctx context.Context, name string, ec model.Context) (model.FlagResult, error)
+	AssignExperiment(ctx context.Context, name string, ec model.Context) (model.ExperimentResult, error)
+	Evaluate(ctx context.Context, req model.EvaluateRequest) (model.EvaluateResult, error)
+	RecordServed(ctx context.Context, ec model.Context, res model.EvaluateResult)
+	Counters(ctx context.Context, name string) (model.EventCounters, error)
+	ScheduleStatus(ctx context.Context, name string, at time.Time) (model.ScheduleStatus, error)
+	NextRuns(ctx context.Context, name string, from time.Time, count int) (model.ScheduleRuns, error)
+}
+
+type service struct {
+	configs ConfigSource
+	cache   *compileCache
+	events  EventRecorder
+	clock   func() time.Time
+}
+
+func NewService(configs ConfigSource, events EventRecorder) IService {
+	return service{configs: configs, cache: newCompileCache(), events: events, clock: time.Now}
+}
+
+// now is the time schedules are checked at when the caller gives none.
+func (s service) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock()
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handler) Create(c echo.Context) error {
+	if !httpx.IsJSON(c) {
+		return httpx.WriteError(c, http.StatusUnsupportedMediaType, "content-type must be application/json", nil)
+	}
+
+	var req model.MigrationCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.WriteError(c, http.StatusBadRequest, "invalid JSON", err.Error())
+	}
+
+	m, err := h.srv.Create(c.Request().Context(), req)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusCreated, m)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/internal/migration/service"
+	srvMock "configuration-management-service/internal/migration/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate(t *testing.T) {
+	type input struct {
+		ct   string
+		body string
+	}
+	type expected struct {
+		code int
+		json string
+	}
+
+	req := model.MigrationCreateRequest{ID: "m1", Type: "service_client", Operations: []model.Operation{{Op: "rename", Path: "/timeout_ms", To: "timeout"}}}
+	body := `{"id":"m1","type":"service_client","operations":[{"op":"rename","path":"/timeout_ms","to":"timeout"}]}`
+
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		in       input
+		ex       expected
+	}{
+		{
+			name:     "when unsupported media type should status code 415",
+			in:       input{ct: "text/plain", body: body},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusUnsupportedMediaType,
+				json: `{"error":{"code":"Unsupported Media Type","message":"content-type must be application/json","details":null}}`,
+			},
+		},
+		{
+			name: "when invalid operations should status code 400",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"id":"m1","type":"service_client","operations":[]}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Create(gomock.Any(), model.MigrationCreateRequest{ID: "m1", Type: "service_client", Operations: []model.Operation{}}).
+					Return(model.Migration{}, fmt.Errorf("%w: operations are required", service.ErrInvalidInput))
+			},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid input","details":"invalid input: operations are required"}}`,
+			},
+		},
+		{
+			name: "when id taken should status code 409",
+			in:   input{ct: echo.MIMEApplicationJSON, body: body},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Create(gomock.Any(), req).Return(model.Migration{}, fmt.Errorf("%w: migration m1", service.ErrAlreadyExists))
+			},
+			ex: expected{
+				code: http.StatusConflict,
+				json: `{"error":{"code":"Conflict","message":"already exists: migration m1","details":null}}`,
+			},
+		},
+		{
+			name: "when success should status code 201",
+			in:   input{ct: echo.MIMEApplicationJSON, body: body},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Create(gomock.Any(), req).
+					Return(model.Migration{ID: "m1", Type: "service_client", Operations: req.Operations, CreatedAt: "2025-10-01T00:00:00Z"}, nil)
+			},
+			ex: expected{
+				code: http.StatusCreated,
+				json: `{"id":"m1","type":"service_client","operations":[{"op":"rename","path":"/timeout_ms","to":"timeout"}],"created_at":"2025-10-01T00:00:00Z"}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			r := httptest.NewRequest(http.MethodPost, "/migrations", bytes.NewBufferString(tc.in.body))
+			r.Header.Set(echo.HeaderContentType, tc.in.ct)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(r, rec)
+
+			_ = h.Create(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.ex.json, string(b))
+		})
+	}
+}
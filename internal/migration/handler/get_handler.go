@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handler) Get(c echo.Context) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return httpx.WriteError(c, http.StatusBadRequest, "id is required", nil)
+	}
+
+	m, err := h.srv.Get(c.Request().Context(), id)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, m)
+}
+
+// List returns the stored migrations, of one type with ?type=.
+func (h *handler) List(c echo.Context) error {
+	res, err := h.srv.List(c.Request().Context(), c.QueryParam("type"))
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]any{"migrations": res})
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/internal/migration/service"
+	srvMock "configuration-management-service/internal/migration/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	cases := []struct {
+		name     string
+		id       string
+		mockFunc func(m *srvMock.MockIService)
+		code     int
+		json     string
+	}{
+		{
+			name:     "when id missing should status code 400",
+			id:       " ",
+			mockFunc: func(m *srvMock.MockIService) {},
+			code:     http.StatusBadRequest,
+			json:     `{"error":{"code":"Bad Request","message":"id is required","details":null}}`,
+		},
+		{
+			name: "when not found should status code 404",
+			id:   "m1",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "m1").Return(model.Migration{}, service.ErrNotFound)
+			},
+			code: http.StatusNotFound,
+			json: `{"error":{"code":"Not Found","message":"not found","details":null}}`,
+		},
+		{
+			name: "when success should status code 200",
+			id:   "m1",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "m1").Return(model.Migration{ID: "m1", Type: "service_client", Operations: []model.Operation{{Op: "delete", Path: "/legacy"}}}, nil)
+			},
+			code: http.StatusOK,
+			json: `{"id":"m1","type":"service_client","operations":[{"op":"delete","path":"/legacy"}],"created_at":""}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/migrations/_placeholder", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+
+			_ = h.Get(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.json, string(b))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		code     int
+		json     string
+	}{
+		{
+			name: "when service error should status code 500",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().List(gomock.Any(), "service_client").Return(nil, errors.New("db down"))
+			},
+			code: http.StatusInternalServerError,
+			json: `{"error":{"code":"Internal Server Error","message":"internal error","details":null}}`,
+		},
+		{
+			name: "when success should return migrations of the type",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().List(gomock.Any(), "service_client").Return([]model.Migration{{ID: "m1", Type: "service_client", Operations: []model.Operation{{Op: "delete", Path: "/a"}}}}, nil)
+			},
+			code: http.StatusOK,
+			json: `{"migrations":[{"id":"m1","type":"service_client","operations":[{"op":"delete","path":"/a"}],"created_at":""}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/migrations?type=service_client", nil), rec)
+
+			_ = h.List(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.json, string(b))
+		})
+	}
+}
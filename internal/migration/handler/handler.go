@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"configuration-management-service/internal/migration/service"
+	"configuration-management-service/pkg/httpx"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IHandler interface {
+	Create(c echo.Context) error
+	Get(c echo.Context) error
+	List(c echo.Context) error
+	DryRun(c echo.Context) error
+	Apply(c echo.Context) error
+}
+
+type handler struct {
+	srv service.IService
+}
+
+func NewHandler(srv service.IService) IHandler {
+	return &handler{srv: srv}
+}
+
+func (h *handler) writeServiceError(c echo.Context, err error) error {
+	var failed *service.FailedError
+	switch {
+	case errors.As(err, &failed):
+		return httpx.WriteError(c, http.StatusConflict, "migration fails for some configs", failed.Report)
+	case errors.Is(err, service.ErrAlreadyExists),
+		errors.Is(err, service.ErrAlreadyApplied),
+		errors.Is(err, service.ErrConflict):
+		return httpx.WriteError(c, http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, service.ErrNotFound):
+		return httpx.WriteError(c, http.StatusNotFound, err.Error(), nil)
+	case errors.Is(err, service.ErrInvalidInput):
+		return httpx.WriteError(c, http.StatusBadRequest, "invalid input", err.Error())
+	default:
+		return httpx.WriteError(c, http.StatusInternalServerError, "internal error", nil)
+	}
+}
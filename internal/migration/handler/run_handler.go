@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DryRun reports the per-config diff of a migration without writing.
+func (h *handler) DryRun(c echo.Context) error {
+	return h.run(c, true)
+}
+
+// Apply appends the migrated version of every affected config.
+func (h *handler) Apply(c echo.Context) error {
+	return h.run(c, false)
+}
+
+func (h *handler) run(c echo.Context, dryRun bool) error {
+	id := strings.TrimSpace(c.Param("id"))
+	if id == "" {
+		return httpx.WriteError(c, http.StatusBadRequest, "id is required", nil)
+	}
+
+	report, err := h.srv.Run(c.Request().Context(), id, dryRun)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/internal/migration/service"
+	srvMock "configuration-management-service/internal/migration/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	diff := []model.ConfigDiff{{Name: "svc", Version: 2, Changes: []model.Change{
+		{Op: "add", Path: "/timeout", New: json.RawMessage(`200`)},
+		{Op: "remove", Path: "/timeout_ms", Old: json.RawMessage(`200`)},
+	}}}
+
+	cases := []struct {
+		name     string
+		dryRun   bool
+		mockFunc func(m *srvMock.MockIService)
+		code     int
+		json     string
+	}{
+		{
+			name:   "when dry run should status code 200 with diff",
+			dryRun: true,
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Run(gomock.Any(), "m1", true).
+					Return(model.Report{ID: "m1", Type: "service_client", DryRun: true, Checked: 3, Affected: 1, Configs: diff}, nil)
+			},
+			code: http.StatusOK,
+			json: `{"id":"m1","type":"service_client","dry_run":true,"checked":3,"affected":1,"configs":[{"name":"svc","version":2,"changes":[{"op":"add","path":"/timeout","new":200},{"op":"remove","path":"/timeout_ms","old":200}]}]}`,
+		},
+		{
+			name: "when applied should status code 200 with new versions",
+			mockFunc: func(m *srvMock.MockIService) {
+				applied := []model.ConfigDiff{diff[0]}
+				applied[0].NewVersion = 3
+				m.EXPECT().Run(gomock.Any(), "m1", false).
+					Return(model.Report{ID: "m1", Type: "service_client", Checked: 3, Affected: 1, Configs: applied}, nil)
+			},
+			code: http.StatusOK,
+			json: `{"id":"m1","type":"service_client","dry_run":false,"checked":3,"affected":1,"configs":[{"name":"svc","version":2,"new_version":3,"changes":[{"op":"add","path":"/timeout","new":200},{"op":"remove","path":"/timeout_ms","old":200}]}]}`,
+		},
+		{
+			name: "when a config fails should status code 409 with report",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Run(gomock.Any(), "m1", false).Return(model.Report{}, &service.FailedError{Report: model.Report{
+					ID: "m1", Type: "service_client", Checked: 1,
+					Configs: []model.ConfigDiff{{Name: "svc", Version: 2, Changes: []model.Change{}, Error: "invalid input: timeout is required"}},
+				}})
+			},
+			code: http.StatusConflict,
+			json: `{"error":{"code":"Conflict","message":"migration fails for some configs","details":{"id":"m1","type":"service_client","dry_run":false,"checked":1,"affected":0,"configs":[{"name":"svc","version":2,"changes":[],"error":"invalid input: timeout is required"}]}}}`,
+		},
+		{
+			name: "when applied before should status code 409",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Run(gomock.Any(), "m1", false).Return(model.Report{}, fmt.Errorf("%w: m1", service.ErrAlreadyApplied))
+			},
+			code: http.StatusConflict,
+			json: `{"error":{"code":"Conflict","message":"migration already applied: m1","details":null}}`,
+		},
+		{
+			name: "when not found should status code 404",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Run(gomock.Any(), "m1", false).Return(model.Report{}, service.ErrNotFound)
+			},
+			code: http.StatusNotFound,
+			json: `{"error":{"code":"Not Found","message":"not found","details":null}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/migrations/_placeholder/apply", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues("m1")
+
+			if tc.dryRun {
+				_ = h.DryRun(c)
+			} else {
+				_ = h.Apply(c)
+			}
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.json, string(b))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"configuration-management-service/pkg/jsonx"
+	"encoding/json"
+)
+
+// Operation kinds of a data migration.
+const (
+	// OpRename renames the member at Path to To, in the same object.
+	OpRename = "rename"
+	// OpMove moves the value at From to Path, creating missing parent objects.
+	OpMove = "move"
+	// OpSetDefault sets Path to Value when neither the config nor its base has it.
+	OpSetDefault = "set-default"
+	// OpDelete removes the value at Path.
+	OpDelete = "delete"
+	// OpMapValue replaces the value at Path by the To of the first mapping
+	// whose From equals it.
+	OpMapValue = "map-value"
+)
+
+// Operation is one step of a data migration. Paths are JSON pointers into
+// the config data; a "*" segment matches every member of an object or
+// array (not allowed in move).
+type Operation struct {
+	Op      string          `json:"op"`
+	Path    string          `json:"path"`
+	From    string          `json:"from,omitempty"`
+	To      string          `json:"to,omitempty"`
+	Value   json.RawMessage `json:"value,omitempty"`
+	Mapping []ValueMapping  `json:"mapping,omitempty"`
+}
+
+type ValueMapping struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// Migration rewrites the stored configs of one type, operations in order.
+type Migration struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	Operations []Operation `json:"operations"`
+	CreatedAt  string      `json:"created_at"`
+}
+
+type MigrationCreateRequest struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	Operations []Operation `json:"operations"`
+}
+
+// TransformFunc rewrites the own data of one config. inherited is the
+// effective data of its base after the migration, nil without a base.
+type TransformFunc func(own, inherited json.RawMessage) (json.RawMessage, error)
+
+// ConfigResult is the outcome of a migration for one config. Before and
+// After are its own data with secret fields redacted.
+type ConfigResult struct {
+	Name       string
+	Version    int
+	NewVersion int
+	Changed    bool
+	Before     json.RawMessage
+	After      json.RawMessage
+	Error      string
+}
+
+// Change is one difference in the own data of a config, JSON Patch style.
+type Change = jsonx.Change
+
+// ConfigDiff lists the changes a migration makes to one config. NewVersion
+// is set once the migration is applied.
+type ConfigDiff struct {
+	Name       string   `json:"name"`
+	Version    int      `json:"version"`
+	NewVersion int      `json:"new_version,omitempty"`
+	Changes    []Change `json:"changes"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// Report is the outcome of a dry run or an apply. Configs lists the configs
+// that change or fail; Checked counts every config of the type.
+type Report struct {
+	ID       string       `json:"id"`
+	Type     string       `json:"type"`
+	DryRun   bool         `json:"dry_run"`
+	Checked  int          `json:"checked"`
+	Affected int          `json:"affected"`
+	Configs  []ConfigDiff `json:"configs"`
+}
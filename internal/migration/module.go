@@ -0,0 +1,52 @@
+package migration
+
+import (
+	"configuration-management-service/internal/migration/handler"
+	"configuration-management-service/internal/migration/repository"
+	"configuration-management-service/internal/migration/service"
+	"database/sql"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IModule interface {
+	RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc)
+	Service() service.IService
+}
+
+type module struct {
+	srv  service.IService
+	repo repository.IRepo
+	h    handler.IHandler
+}
+
+func New(repo repository.IRepo, migrator service.ConfigMigrator) IModule {
+	srv := service.NewService(repo, migrator)
+	return &module{
+		srv:  srv,
+		repo: repo,
+		h:    handler.NewHandler(srv),
+	}
+}
+
+// InitModule wires the migration store; migrator rewrites the configs.
+func InitModule(db *sql.DB, migrator service.ConfigMigrator) IModule {
+	return New(repository.NewRepo(db), migrator)
+}
+
+func (m *module) Service() service.IService {
+	return m.srv
+}
+
+func (m *module) RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc) {
+	if g == nil {
+		return
+	}
+
+	migrations := g.Group("/migrations")
+	migrations.POST("", m.h.Create, writeLimit)
+	migrations.GET("", m.h.List)
+	migrations.GET("/:id", m.h.Get)
+	migrations.POST("/:id/dry-run", m.h.DryRun)
+	migrations.POST("/:id/apply", m.h.Apply)
+}
@@ -0,0 +1,366 @@
+// Package ops applies the operations of a data migration to config data.
+//
+// Operations work on the own data of a config, the overlay it stores, so a
+// config that extends a base only changes where it overrides the base; the
+// base is migrated on its own. set-default is the exception that looks at
+// the inherited data: a default the base already provides is not copied
+// into the overlay. Operations on a missing path are no-ops, so a migration
+// can be run over configs that never had the field.
+package ops
+
+import (
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/pkg/jsonx"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// wildcard is the path segment matching every member of an object or array.
+const wildcard = "*"
+
+// Validate checks operations before a migration is stored.
+func Validate(operations []model.Operation) error {
+	if len(operations) == 0 {
+		return errors.New("operations are required")
+	}
+	for i, op := range operations {
+		if err := validate(op); err != nil {
+			return fmt.Errorf("operations[%d] (%s): %w", i, op.Op, err)
+		}
+	}
+	return nil
+}
+
+func validate(op model.Operation) error {
+	segs, err := split(op.Path)
+	if err != nil {
+		return fmt.Errorf("path: %w", err)
+	}
+	switch op.Op {
+	case model.OpRename:
+		if op.To == "" || op.To == wildcard {
+			return errors.New("to must name the new member")
+		}
+		if segs[len(segs)-1] == wildcard {
+			return errors.New("path must end with a member name")
+		}
+	case model.OpMove:
+		from, err := split(op.From)
+		if err != nil {
+			return fmt.Errorf("from: %w", err)
+		}
+		if hasWildcard(segs) || hasWildcard(from) {
+			return errors.New("move does not take wildcards")
+		}
+		if op.From == op.Path || strings.HasPrefix(op.Path, op.From+"/") {
+			return errors.New("path must not be from or inside it")
+		}
+	case model.OpSetDefault:
+		if len(op.Value) == 0 {
+			return errors.New("value is required")
+		}
+		if !json.Valid(op.Value) {
+			return errors.New("value is not valid JSON")
+		}
+		if segs[len(segs)-1] == wildcard {
+			return errors.New("path must end with a member name")
+		}
+	case model.OpDelete:
+	case model.OpMapValue:
+		if len(op.Mapping) == 0 {
+			return errors.New("mapping is required")
+		}
+		for j, m := range op.Mapping {
+			if !json.Valid(m.From) || !json.Valid(m.To) {
+				return fmt.Errorf("mapping[%d]: from and to must be JSON values", j)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown op %q", op.Op)
+	}
+	return nil
+}
+
+// Apply runs operations in order over own and returns the new own data.
+// inherited is the effective data of the base, nil without one.
+func Apply(operations []model.Operation, own, inherited json.RawMessage) (json.RawMessage, error) {
+	doc, err := jsonx.Decode(own)
+	if err != nil {
+		return nil, err
+	}
+	var inh any
+	if len(inherited) > 0 {
+		if inh, err = jsonx.Decode(inherited); err != nil {
+			return nil, err
+		}
+	}
+	for i, op := range operations {
+		if doc, err = apply(op, doc, inh); err != nil {
+			return nil, fmt.Errorf("operations[%d] (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return jsonx.Encode(doc)
+}
+
+func apply(op model.Operation, doc, inherited any) (any, error) {
+	segs, err := split(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case model.OpRename:
+		for _, p := range expand(doc, segs) {
+			to := append(append([]string{}, p[:len(p)-1]...), op.To)
+			if _, ok := get(doc, to); ok {
+				return nil, fmt.Errorf("%s already exists", join(to))
+			}
+			v, _ := get(doc, p)
+			if doc, err = set(doc, to, v, false); err != nil {
+				return nil, err
+			}
+			doc = remove(doc, p)
+		}
+	case model.OpMove:
+		from, err := split(op.From)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := get(doc, from)
+		if !ok {
+			return doc, nil
+		}
+		if _, ok := get(doc, segs); ok {
+			return nil, fmt.Errorf("%s already exists", op.Path)
+		}
+		doc = remove(doc, from)
+		if doc, err = set(doc, segs, v, true); err != nil {
+			return nil, err
+		}
+	case model.OpSetDefault:
+		targets := [][]string{segs}
+		if hasWildcard(segs) {
+			targets = nil
+			for _, p := range expand(doc, segs[:len(segs)-1]) {
+				targets = append(targets, append(p, segs[len(segs)-1]))
+			}
+		}
+		for _, p := range targets {
+			if _, ok := get(doc, p); ok {
+				continue
+			}
+			if _, ok := get(inherited, p); ok {
+				continue
+			}
+			v, err := jsonx.Decode(op.Value)
+			if err != nil {
+				return nil, err
+			}
+			if doc, err = set(doc, p, v, true); err != nil {
+				return nil, err
+			}
+		}
+	case model.OpDelete:
+		paths := expand(doc, segs)
+		// Remove later array items first so earlier indices stay valid.
+		for i := len(paths) - 1; i >= 0; i-- {
+			doc = remove(doc, paths[i])
+		}
+	case model.OpMapValue:
+		for _, p := range expand(doc, segs) {
+			v, _ := get(doc, p)
+			for _, m := range op.Mapping {
+				from, err := jsonx.Decode(m.From)
+				if err != nil {
+					return nil, err
+				}
+				if !reflect.DeepEqual(v, from) {
+					continue
+				}
+				to, err := jsonx.Decode(m.To)
+				if err != nil {
+					return nil, err
+				}
+				if doc, err = set(doc, p, to, false); err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown op %q", op.Op)
+	}
+	return doc, nil
+}
+
+// split parses a JSON pointer; the root pointer is not a valid target.
+func split(ptr string) ([]string, error) {
+	if !strings.HasPrefix(ptr, "/") || ptr == "/" {
+		return nil, fmt.Errorf("%q is not a JSON pointer below the root", ptr)
+	}
+	segs := strings.Split(ptr[1:], "/")
+	for i, s := range segs {
+		segs[i] = strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+	}
+	return segs, nil
+}
+
+func join(segs []string) string {
+	var b strings.Builder
+	for _, s := range segs {
+		b.WriteString("/" + strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+func hasWildcard(segs []string) bool {
+	for _, s := range segs {
+		if s == wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// expand returns the concrete paths matching segs that exist in doc, in
+// document order.
+func expand(doc any, segs []string) [][]string {
+	if len(segs) == 0 {
+		return [][]string{{}}
+	}
+	var keys []string
+	switch n := doc.(type) {
+	case map[string]any:
+		if segs[0] != wildcard {
+			if _, ok := n[segs[0]]; ok {
+				keys = []string{segs[0]}
+			}
+			break
+		}
+		for k := range n {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+	case []any:
+		if segs[0] != wildcard {
+			if i, ok := index(segs[0], len(n)); ok {
+				keys = []string{strconv.Itoa(i)}
+			}
+			break
+		}
+		for i := range n {
+			keys = append(keys, strconv.Itoa(i))
+		}
+	}
+
+	var out [][]string
+	for _, k := range keys {
+		child, _ := get(doc, []string{k})
+		for _, rest := range expand(child, segs[1:]) {
+			out = append(out, append([]string{k}, rest...))
+		}
+	}
+	return out
+}
+
+func index(seg string, n int) (int, bool) {
+	i, err := strconv.Atoi(seg)
+	if err != nil || i < 0 || i >= n || strconv.Itoa(i) != seg {
+		return 0, false
+	}
+	return i, true
+}
+
+func get(doc any, segs []string) (any, bool) {
+	cur := doc
+	for _, s := range segs {
+		switch n := cur.(type) {
+		case map[string]any:
+			v, ok := n[s]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []any:
+			i, ok := index(s, len(n))
+			if !ok {
+				return nil, false
+			}
+			cur = n[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// set stores v at segs and returns the updated node. With create, missing
+// parents are added as objects.
+func set(node any, segs []string, v any, create bool) (any, error) {
+	if len(segs) == 0 {
+		return v, nil
+	}
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[segs[0]]
+		if !ok && len(segs) > 1 {
+			if !create {
+				return nil, fmt.Errorf("%s is missing", segs[0])
+			}
+			child = map[string]any{}
+		}
+		next, err := set(child, segs[1:], v, create)
+		if err != nil {
+			return nil, err
+		}
+		n[segs[0]] = next
+		return n, nil
+	case []any:
+		i, ok := index(segs[0], len(n))
+		if !ok {
+			return nil, fmt.Errorf("index %s is out of range", segs[0])
+		}
+		next, err := set(n[i], segs[1:], v, create)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = next
+		return n, nil
+	default:
+		return nil, fmt.Errorf("cannot set %s inside a scalar", segs[0])
+	}
+}
+
+// remove deletes the value at segs, if any, and returns the updated node.
+func remove(node any, segs []string) any {
+	if len(segs) == 0 {
+		return node
+	}
+	switch n := node.(type) {
+	case map[string]any:
+		if len(segs) == 1 {
+			delete(n, segs[0])
+			return n
+		}
+		if child, ok := n[segs[0]]; ok {
+			n[segs[0]] = remove(child, segs[1:])
+		}
+		return n
+	case []any:
+		i, ok := index(segs[0], len(n))
+		if !ok {
+			return n
+		}
+		if len(segs) == 1 {
+			return append(n[:i:i], n[i+1:]...)
+		}
+		n[i] = remove(n[i], segs[1:])
+		return n
+	default:
+		return node
+	}
+}
@@ -0,0 +1,182 @@
+package ops
+
+import (
+	"encoding/json"
+	"testing"
+
+	"configuration-management-service/internal/migration/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		ops  []model.Operation
+		err  string
+	}{
+		{name: "when empty should return error", err: "operations are required"},
+		{
+			name: "when op unknown should return error",
+			ops:  []model.Operation{{Op: "copy", Path: "/a"}},
+			err:  `operations[0] (copy): unknown op "copy"`,
+		},
+		{
+			name: "when path is the root should return error",
+			ops:  []model.Operation{{Op: model.OpDelete, Path: "/"}},
+			err:  `operations[0] (delete): path: "/" is not a JSON pointer below the root`,
+		},
+		{
+			name: "when rename has no target should return error",
+			ops:  []model.Operation{{Op: model.OpRename, Path: "/timeout_ms"}},
+			err:  "operations[0] (rename): to must name the new member",
+		},
+		{
+			name: "when move has wildcard should return error",
+			ops:  []model.Operation{{Op: model.OpMove, From: "/a/*", Path: "/b"}},
+			err:  "operations[0] (move): move does not take wildcards",
+		},
+		{
+			name: "when move into itself should return error",
+			ops:  []model.Operation{{Op: model.OpMove, From: "/a", Path: "/a/b"}},
+			err:  "operations[0] (move): path must not be from or inside it",
+		},
+		{
+			name: "when set-default has no value should return error",
+			ops:  []model.Operation{{Op: model.OpSetDefault, Path: "/a"}},
+			err:  "operations[0] (set-default): value is required",
+		},
+		{
+			name: "when map-value has no mapping should return error",
+			ops:  []model.Operation{{Op: model.OpMapValue, Path: "/a"}},
+			err:  "operations[0] (map-value): mapping is required",
+		},
+		{
+			name: "when valid should return nil",
+			ops: []model.Operation{
+				{Op: model.OpRename, Path: "/timeout_ms", To: "timeout"},
+				{Op: model.OpMove, From: "/retry", Path: "/policy/retry"},
+				{Op: model.OpSetDefault, Path: "/windows/*/label", Value: json.RawMessage(`""`)},
+				{Op: model.OpDelete, Path: "/legacy"},
+				{Op: model.OpMapValue, Path: "/channel", Mapping: []model.ValueMapping{{From: json.RawMessage(`"mail"`), To: json.RawMessage(`"email"`)}}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.ops)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	type input struct {
+		ops       []model.Operation
+		own       string
+		inherited string
+	}
+
+	cases := []struct {
+		name string
+		in   input
+		want string
+		err  string
+	}{
+		{
+			name: "when rename should keep the value under the new name",
+			in:   input{ops: []model.Operation{{Op: model.OpRename, Path: "/timeout_ms", To: "timeout"}}, own: `{"name":"svc","timeout_ms":200}`},
+			want: `{"name":"svc","timeout":200}`,
+		},
+		{
+			name: "when rename target exists should return error",
+			in:   input{ops: []model.Operation{{Op: model.OpRename, Path: "/a", To: "b"}}, own: `{"a":1,"b":2}`},
+			err:  "operations[0] (rename /a): /b already exists",
+		},
+		{
+			name: "when rename with wildcard should rename in every item",
+			in:   input{ops: []model.Operation{{Op: model.OpRename, Path: "/variants/*/w", To: "weight"}}, own: `{"variants":[{"name":"A","w":50},{"name":"B"}]}`},
+			want: `{"variants":[{"name":"A","weight":50},{"name":"B"}]}`,
+		},
+		{
+			name: "when move should create missing parents",
+			in:   input{ops: []model.Operation{{Op: model.OpMove, From: "/retries", Path: "/policy/retries"}}, own: `{"retries":3}`},
+			want: `{"policy":{"retries":3}}`,
+		},
+		{
+			name: "when move source missing should change nothing",
+			in:   input{ops: []model.Operation{{Op: model.OpMove, From: "/retries", Path: "/policy/retries"}}, own: `{"name":"svc"}`},
+			want: `{"name":"svc"}`,
+		},
+		{
+			name: "when set-default missing should set it",
+			in:   input{ops: []model.Operation{{Op: model.OpSetDefault, Path: "/timeout", Value: json.RawMessage(`1000`)}}, own: `{}`},
+			want: `{"timeout":1000}`,
+		},
+		{
+			name: "when set-default inherited should not copy it",
+			in:   input{ops: []model.Operation{{Op: model.OpSetDefault, Path: "/timeout", Value: json.RawMessage(`1000`)}}, own: `{}`, inherited: `{"timeout":500}`},
+			want: `{}`,
+		},
+		{
+			name: "when set-default present should keep the value",
+			in:   input{ops: []model.Operation{{Op: model.OpSetDefault, Path: "/timeout", Value: json.RawMessage(`1000`)}}, own: `{"timeout":null}`},
+			want: `{"timeout":null}`,
+		},
+		{
+			name: "when set-default with wildcard should fill every item",
+			in:   input{ops: []model.Operation{{Op: model.OpSetDefault, Path: "/windows/*/label", Value: json.RawMessage(`"x"`)}}, own: `{"windows":[{"label":"a"},{}]}`},
+			want: `{"windows":[{"label":"a"},{"label":"x"}]}`,
+		},
+		{
+			name: "when delete with wildcard should remove every match",
+			in:   input{ops: []model.Operation{{Op: model.OpDelete, Path: "/tags/*"}}, own: `{"tags":["a","b"],"keep":1}`},
+			want: `{"tags":[],"keep":1}`,
+		},
+		{
+			name: "when delete missing should change nothing",
+			in:   input{ops: []model.Operation{{Op: model.OpDelete, Path: "/legacy/x"}}, own: `{"a":1}`},
+			want: `{"a":1}`,
+		},
+		{
+			name: "when map-value should replace matching values only",
+			in: input{ops: []model.Operation{{Op: model.OpMapValue, Path: "/rules/*/channel", Mapping: []model.ValueMapping{
+				{From: json.RawMessage(`"mail"`), To: json.RawMessage(`"email"`)},
+				{From: json.RawMessage(`1`), To: json.RawMessage(`"sms"`)},
+			}}}, own: `{"rules":[{"channel":"mail"},{"channel":"push"},{"channel":1}]}`},
+			want: `{"rules":[{"channel":"email"},{"channel":"push"},{"channel":"sms"}]}`,
+		},
+		{
+			name: "when operations chain should apply them in order",
+			in: input{ops: []model.Operation{
+				{Op: model.OpRename, Path: "/timeout_ms", To: "timeout"},
+				{Op: model.OpSetDefault, Path: "/timeout", Value: json.RawMessage(`1000`)},
+			}, own: `{"timeout_ms":200}`},
+			want: `{"timeout":200}`,
+		},
+		{
+			name: "when own data is malformed should return error",
+			in:   input{ops: []model.Operation{{Op: model.OpDelete, Path: "/a"}}, own: `{`},
+			err:  "unexpected EOF",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var inherited json.RawMessage
+			if tc.in.inherited != "" {
+				inherited = json.RawMessage(tc.in.inherited)
+			}
+			got, err := Apply(tc.in.ops, json.RawMessage(tc.in.own), inherited)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"configuration-management-service/internal/migration/model"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+func (r *repo) Create(ctx context.Context, m model.Migration) (model.Migration, error) {
+	ops, err := json.Marshal(m.Operations)
+	if err != nil {
+		return model.Migration{}, err
+	}
+
+	const qIns = `INSERT INTO data_migrations(id, type, operations) VALUES(?, ?, ?)`
+	if _, err := r.db.ExecContext(ctx, qIns, m.ID, m.Type, string(ops)); err != nil {
+		if isUniqueViolation(err) {
+			return model.Migration{}, ErrAlreadyExists
+		}
+		return model.Migration{}, fmt.Errorf("create.insert: %w", err)
+	}
+	return r.Get(ctx, m.ID)
+}
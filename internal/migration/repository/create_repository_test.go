@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/migration/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Create(t *testing.T) {
+	const qIns = `INSERT INTO data_migrations(id, type, operations) VALUES(?, ?, ?)`
+	const qSel = `SELECT id, type, operations, created_at FROM data_migrations WHERE id = ?`
+	const ops = `[{"op":"rename","path":"/timeout_ms","to":"timeout"}]`
+
+	in := model.Migration{ID: "m1", Type: "service_client", Operations: []model.Operation{{Op: "rename", Path: "/timeout_ms", To: "timeout"}}}
+
+	type exRes struct {
+		res model.Migration
+		err error
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		ex       exRes
+	}{
+		{
+			name: "when id taken should return ErrAlreadyExists",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(qIns).WithArgs("m1", "service_client", ops).
+					WillReturnError(errors.New("UNIQUE constraint failed: data_migrations.id"))
+			},
+			ex: exRes{err: ErrAlreadyExists},
+		},
+		{
+			name: "when insert error should return error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(qIns).WithArgs("m1", "service_client", ops).WillReturnError(errors.New("db down"))
+			},
+			ex: exRes{err: errors.New("create.insert: db down")},
+		},
+		{
+			name: "when success should return stored migration",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(qIns).WithArgs("m1", "service_client", ops).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery(qSel).WithArgs("m1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "type", "operations", "created_at"}).
+						AddRow("m1", "service_client", ops, "2025-10-01T00:00:00Z"))
+			},
+			ex: exRes{res: model.Migration{ID: "m1", Type: "service_client", Operations: in.Operations, CreatedAt: "2025-10-01T00:00:00Z"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.Create(context.Background(), in)
+
+			if tc.ex.err != nil {
+				assert.EqualError(t, err, tc.ex.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.ex.res, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
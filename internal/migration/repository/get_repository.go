@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"configuration-management-service/internal/migration/model"
+	"context"
+)
+
+func (r *repo) Get(ctx context.Context, id string) (model.Migration, error) {
+	const q = `
+		SELECT id, type, operations, created_at
+		FROM data_migrations
+		WHERE id = ?
+	`
+	return scanMigration(r.db.QueryRowContext(ctx, q, id))
+}
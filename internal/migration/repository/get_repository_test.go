@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"configuration-management-service/internal/migration/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Get(t *testing.T) {
+	const qSel = `SELECT id, type, operations, created_at FROM data_migrations WHERE id = ?`
+
+	type exRes struct {
+		res model.Migration
+		err error
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		ex       exRes
+	}{
+		{
+			name: "when missing should return ErrNotFound",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(qSel).WithArgs("m1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "type", "operations", "created_at"}))
+			},
+			ex: exRes{err: ErrNotFound},
+		},
+		{
+			name: "when success should decode operations",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(qSel).WithArgs("m1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "type", "operations", "created_at"}).
+						AddRow("m1", "service_client", `[{"op":"delete","path":"/legacy"}]`, "2025-10-01T00:00:00Z"))
+			},
+			ex: exRes{res: model.Migration{ID: "m1", Type: "service_client", Operations: []model.Operation{{Op: "delete", Path: "/legacy"}}, CreatedAt: "2025-10-01T00:00:00Z"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.Get(context.Background(), "m1")
+
+			assert.Equal(t, tc.ex.err, err)
+			assert.Equal(t, tc.ex.res, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
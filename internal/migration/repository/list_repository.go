@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"configuration-management-service/internal/migration/model"
+	"context"
+)
+
+func (r *repo) List(ctx context.Context, schemaType string) ([]model.Migration, error) {
+	const q = `
+		SELECT id, type, operations, created_at
+		FROM data_migrations
+		WHERE ? = '' OR type = ?
+		ORDER BY created_at ASC, id ASC
+	`
+	rows, err := r.db.QueryContext(ctx, q, schemaType, schemaType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.Migration{}
+	for rows.Next() {
+		m, err := scanMigration(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
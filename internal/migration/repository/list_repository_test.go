@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/migration/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_List(t *testing.T) {
+	const q = `SELECT id, type, operations, created_at FROM data_migrations WHERE ? = '' OR type = ? ORDER BY created_at ASC, id ASC`
+
+	type exRes struct {
+		res []model.Migration
+		err error
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		ex       exRes
+	}{
+		{
+			name: "when query error should return error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("service_client", "service_client").WillReturnError(errors.New("db down"))
+			},
+			ex: exRes{err: errors.New("db down")},
+		},
+		{
+			name: "when none should return empty list",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("service_client", "service_client").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "type", "operations", "created_at"}))
+			},
+			ex: exRes{res: []model.Migration{}},
+		},
+		{
+			name: "when success should return migrations",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("service_client", "service_client").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "type", "operations", "created_at"}).
+						AddRow("m1", "service_client", `[{"op":"delete","path":"/a"}]`, "2025-10-01T00:00:00Z").
+						AddRow("m2", "service_client", `[{"op":"delete","path":"/b"}]`, "2025-10-02T00:00:00Z"))
+			},
+			ex: exRes{res: []model.Migration{
+				{ID: "m1", Type: "service_client", Operations: []model.Operation{{Op: "delete", Path: "/a"}}, CreatedAt: "2025-10-01T00:00:00Z"},
+				{ID: "m2", Type: "service_client", Operations: []model.Operation{{Op: "delete", Path: "/b"}}, CreatedAt: "2025-10-02T00:00:00Z"},
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.List(context.Background(), "service_client")
+
+			if tc.ex.err != nil {
+				assert.EqualError(t, err, tc.ex.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.ex.res, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"configuration-management-service/internal/migration/model"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+)
+
+type IRepo interface {
+	Create(ctx context.Context, m model.Migration) (model.Migration, error)
+	Get(ctx context.Context, id string) (model.Migration, error)
+	// List returns the migrations of schemaType ("" = all), oldest first.
+	List(ctx context.Context, schemaType string) ([]model.Migration, error)
+}
+
+type repo struct {
+	db *sql.DB
+}
+
+func NewRepo(db *sql.DB) IRepo {
+	return &repo{db: db}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMigration(row rowScanner) (model.Migration, error) {
+	var m model.Migration
+	var ops string
+	if err := row.Scan(&m.ID, &m.Type, &ops, &m.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Migration{}, ErrNotFound
+		}
+		return model.Migration{}, err
+	}
+	if err := json.Unmarshal([]byte(ops), &m.Operations); err != nil {
+		return model.Migration{}, err
+	}
+	return m, nil
+}
+
+func isUniqueViolation(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unique constraint") ||
+		strings.Contains(msg, "unique") && strings.Contains(msg, "constraint") ||
+		strings.Contains(msg, "constraint failed")
+}
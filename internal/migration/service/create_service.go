@@ -0,0 +1,34 @@
+package service
+
+import (
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/internal/migration/ops"
+	"configuration-management-service/internal/migration/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (s service) Create(ctx context.Context, req model.MigrationCreateRequest) (model.Migration, error) {
+	id := strings.TrimSpace(req.ID)
+	if !idPattern.MatchString(id) {
+		return model.Migration{}, fmt.Errorf("%w: id must match %s", ErrInvalidInput, idPattern.String())
+	}
+	schemaType := strings.TrimSpace(req.Type)
+	if !typePattern.MatchString(schemaType) {
+		return model.Migration{}, fmt.Errorf("%w: type must match %s", ErrInvalidInput, typePattern.String())
+	}
+	if err := ops.Validate(req.Operations); err != nil {
+		return model.Migration{}, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+
+	m, err := s.repo.Create(ctx, model.Migration{ID: id, Type: schemaType, Operations: req.Operations})
+	if err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return model.Migration{}, fmt.Errorf("%w: migration %s", ErrAlreadyExists, id)
+		}
+		return model.Migration{}, err
+	}
+	return m, nil
+}
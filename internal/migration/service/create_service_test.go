@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/internal/migration/repository"
+	repoMock "configuration-management-service/internal/migration/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Create(t *testing.T) {
+	rename := []model.Operation{{Op: model.OpRename, Path: "/timeout_ms", To: "timeout"}}
+
+	cases := []struct {
+		name     string
+		req      model.MigrationCreateRequest
+		mockFunc func(m *repoMock.MockIRepo)
+		res      model.Migration
+		err      error
+	}{
+		{
+			name:     "when id invalid should return ErrInvalidInput",
+			req:      model.MigrationCreateRequest{ID: "-bad id", Type: "service_client", Operations: rename},
+			mockFunc: func(m *repoMock.MockIRepo) {},
+			err:      ErrInvalidInput,
+		},
+		{
+			name:     "when type invalid should return ErrInvalidInput",
+			req:      model.MigrationCreateRequest{ID: "m1", Type: "Service Client", Operations: rename},
+			mockFunc: func(m *repoMock.MockIRepo) {},
+			err:      ErrInvalidInput,
+		},
+		{
+			name: "when operation invalid should return ErrInvalidInput",
+			req: model.MigrationCreateRequest{ID: "m1", Type: "service_client", Operations: []model.Operation{
+				{Op: model.OpSetDefault, Path: "/timeout"},
+			}},
+			mockFunc: func(m *repoMock.MockIRepo) {},
+			err:      ErrInvalidInput,
+		},
+		{
+			name: "when id taken should return ErrAlreadyExists",
+			req:  model.MigrationCreateRequest{ID: "m1", Type: "service_client", Operations: rename},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Create(gomock.Any(), model.Migration{ID: "m1", Type: "service_client", Operations: rename}).
+					Return(model.Migration{}, repository.ErrAlreadyExists)
+			},
+			err: ErrAlreadyExists,
+		},
+		{
+			name: "when repo fails should return error",
+			req:  model.MigrationCreateRequest{ID: "m1", Type: "service_client", Operations: rename},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(model.Migration{}, errors.New("db down"))
+			},
+			err: errors.New("db down"),
+		},
+		{
+			name: "when success should store migration",
+			req: model.MigrationCreateRequest{ID: " m1 ", Type: "service_client", Operations: []model.Operation{
+				{Op: model.OpSetDefault, Path: "/timeout", Value: json.RawMessage(`1000`)},
+			}},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				ops := []model.Operation{{Op: model.OpSetDefault, Path: "/timeout", Value: json.RawMessage(`1000`)}}
+				m.EXPECT().Create(gomock.Any(), model.Migration{ID: "m1", Type: "service_client", Operations: ops}).
+					Return(model.Migration{ID: "m1", Type: "service_client", Operations: ops, CreatedAt: "2025-10-01T00:00:00Z"}, nil)
+			},
+			res: model.Migration{ID: "m1", Type: "service_client", Operations: []model.Operation{
+				{Op: model.OpSetDefault, Path: "/timeout", Value: json.RawMessage(`1000`)},
+			}, CreatedAt: "2025-10-01T00:00:00Z"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo}
+
+			got, err := svc.Create(context.Background(), tc.req)
+			switch {
+			case tc.err == nil:
+				assert.NoError(t, err)
+			case errors.Is(tc.err, ErrInvalidInput), errors.Is(tc.err, ErrAlreadyExists):
+				assert.ErrorIs(t, err, tc.err)
+			default:
+				assert.EqualError(t, err, tc.err.Error())
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/internal/migration/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+func (s service) Get(ctx context.Context, id string) (model.Migration, error) {
+	m, err := s.repo.Get(ctx, strings.TrimSpace(id))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return model.Migration{}, ErrNotFound
+		}
+		return model.Migration{}, err
+	}
+	return m, nil
+}
+
+func (s service) List(ctx context.Context, schemaType string) ([]model.Migration, error) {
+	return s.repo.List(ctx, strings.TrimSpace(schemaType))
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/internal/migration/repository"
+	repoMock "configuration-management-service/internal/migration/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Get(t *testing.T) {
+	cases := []struct {
+		name     string
+		mockFunc func(m *repoMock.MockIRepo)
+		res      model.Migration
+		err      error
+	}{
+		{
+			name: "when missing should return ErrNotFound",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Get(gomock.Any(), "m1").Return(model.Migration{}, repository.ErrNotFound)
+			},
+			err: ErrNotFound,
+		},
+		{
+			name: "when repo fails should return error",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Get(gomock.Any(), "m1").Return(model.Migration{}, errors.New("db down"))
+			},
+			err: errors.New("db down"),
+		},
+		{
+			name: "when success should return migration",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Get(gomock.Any(), "m1").Return(model.Migration{ID: "m1", Type: "service_client"}, nil)
+			},
+			res: model.Migration{ID: "m1", Type: "service_client"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo}
+
+			got, err := svc.Get(context.Background(), " m1 ")
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
+
+func Test_service_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repoMock.NewMockIRepo(ctrl)
+	repo.EXPECT().List(gomock.Any(), "service_client").Return([]model.Migration{{ID: "m1"}}, nil)
+	svc := service{repo: repo}
+
+	got, err := svc.List(context.Background(), " service_client ")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Migration{{ID: "m1"}}, got)
+}
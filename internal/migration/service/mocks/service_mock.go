@@ -0,0 +1,134 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "configuration-management-service/internal/migration/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConfigMigrator is a mock of ConfigMigrator interface.
+type MockConfigMigrator struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigMigratorMockRecorder
+}
+
+// MockConfigMigratorMockRecorder is the mock recorder for MockConfigMigrator.
+type MockConfigMigratorMockRecorder struct {
+	mock *MockConfigMigrator
+}
+
+// NewMockConfigMigrator creates a new mock instance.
+func NewMockConfigMigrator(ctrl *gomock.Controller) *MockConfigMigrator {
+	mock := &MockConfigMigrator{ctrl: ctrl}
+	mock.recorder = &MockConfigMigratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigMigrator) EXPECT() *MockConfigMigratorMockRecorder {
+	return m.recorder
+}
+
+// Migrate mocks base method.
+func (m *MockConfigMigrator) Migrate(ctx context.Context, schemaType, migrationID string, fn model.TransformFunc, dryRun bool) ([]model.ConfigResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrate", ctx, schemaType, migrationID, fn, dryRun)
+	ret0, _ := ret[0].([]model.ConfigResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Migrate indicates an expected call of Migrate.
+func (mr *MockConfigMigratorMockRecorder) Migrate(ctx, schemaType, migrationID, fn, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockConfigMigrator)(nil).Migrate), ctx, schemaType, migrationID, fn, dryRun)
+}
+
+// MockIService is a mock of IService interface.
+type MockIService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceMockRecorder
+}
+
+// MockIServiceMockRecorder is the mock recorder for MockIService.
+type MockIServiceMockRecorder struct {
+	mock *MockIService
+}
+
+// NewMockIService creates a new mock instance.
+func NewMockIService(ctrl *gomock.Controller) *MockIService {
+	mock := &MockIService{ctrl: ctrl}
+	mock.recorder = &MockIServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIService) EXPECT() *MockIServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIService) Create(ctx context.Context, req model.MigrationCreateRequest) (model.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(model.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIServiceMockRecorder) Create(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIService)(nil).Create), ctx, req)
+}
+
+// Get mocks base method.
+func (m *MockIService) Get(ctx context.Context, id string) (model.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(model.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockIService) List(ctx context.Context, schemaType string) ([]model.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, schemaType)
+	ret0, _ := ret[0].([]model.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIServiceMockRecorder) List(ctx, schemaType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIService)(nil).List), ctx, schemaType)
+}
+
+// Run mocks base method.
+func (m *MockIService) Run(ctx context.Context, id string, dryRun bool) (model.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, id, dryRun)
+	ret0, _ := ret[0].(model.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIServiceMockRecorder) Run(ctx, id, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIService)(nil).Run), ctx, id, dryRun)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/internal/migration/ops"
+	"configuration-management-service/pkg/jsonx"
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Run dry-runs or applies a stored migration over the latest version of
+// every config of its type. A config whose base changes gets a new version
+// even when its own data does not, so it is re-pinned to the new base.
+func (s service) Run(ctx context.Context, id string, dryRun bool) (model.Report, error) {
+	m, err := s.Get(ctx, id)
+	if err != nil {
+		return model.Report{}, err
+	}
+
+	transform := func(own, inherited json.RawMessage) (json.RawMessage, error) {
+		return ops.Apply(m.Operations, own, inherited)
+	}
+	results, runErr := s.migrator.Migrate(ctx, m.Type, m.ID, transform, dryRun)
+	if runErr != nil && !(errors.Is(runErr, ErrInvalidInput) && results != nil) {
+		return model.Report{}, runErr
+	}
+
+	report, err := newReport(m, dryRun, results)
+	if err != nil {
+		return model.Report{}, err
+	}
+	if runErr != nil {
+		return model.Report{}, &FailedError{Report: report}
+	}
+	return report, nil
+}
+
+func newReport(m model.Migration, dryRun bool, results []model.ConfigResult) (model.Report, error) {
+	report := model.Report{ID: m.ID, Type: m.Type, DryRun: dryRun, Checked: len(results), Configs: []model.ConfigDiff{}}
+	for _, r := range results {
+		if !r.Changed && r.Error == "" {
+			continue
+		}
+		changes, err := jsonx.Diff(r.Before, r.After)
+		if err != nil {
+			return model.Report{}, err
+		}
+		if r.Changed {
+			report.Affected++
+		}
+		report.Configs = append(report.Configs, model.ConfigDiff{
+			Name:       r.Name,
+			Version:    r.Version,
+			NewVersion: r.NewVersion,
+			Changes:    changes,
+			Error:      r.Error,
+		})
+	}
+	return report, nil
+}
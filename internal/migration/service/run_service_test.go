@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"configuration-management-service/internal/migration/model"
+	repoMock "configuration-management-service/internal/migration/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Run(t *testing.T) {
+	stored := model.Migration{ID: "m1", Type: "service_client", Operations: []model.Operation{
+		{Op: model.OpRename, Path: "/timeout_ms", To: "timeout"},
+	}}
+
+	// runTransform applies the migration to each stored config the way the
+	// config module does.
+	runTransform := func(results []model.ConfigResult, err error) ConfigMigrator {
+		return ConfigMigratorFunc(func(ctx context.Context, schemaType, migrationID string, fn model.TransformFunc, dryRun bool) ([]model.ConfigResult, error) {
+			if schemaType != "service_client" || migrationID != "m1" {
+				return nil, fmt.Errorf("unexpected migration %s/%s", schemaType, migrationID)
+			}
+			for i, r := range results {
+				if r.Error != "" {
+					continue
+				}
+				after, ferr := fn(r.Before, nil)
+				if ferr != nil {
+					return nil, ferr
+				}
+				results[i].After = after
+			}
+			return results, err
+		})
+	}
+
+	type input struct {
+		dryRun   bool
+		migrator ConfigMigrator
+	}
+
+	cases := []struct {
+		name     string
+		in       input
+		mockFunc func(m *repoMock.MockIRepo)
+		res      model.Report
+		err      error
+	}{
+		{
+			name:     "when migration missing should return ErrNotFound",
+			in:       input{migrator: runTransform(nil, nil)},
+			mockFunc: func(m *repoMock.MockIRepo) { m.EXPECT().Get(gomock.Any(), "m1").Return(model.Migration{}, ErrNotFound) },
+			err:      ErrNotFound,
+		},
+		{
+			name: "when dry run should report diffs of changed configs",
+			in: input{dryRun: true, migrator: runTransform([]model.ConfigResult{
+				{Name: "a", Version: 2, Changed: true, Before: json.RawMessage(`{"timeout_ms":200}`)},
+				{Name: "b", Version: 1, Before: json.RawMessage(`{"name":"b"}`)},
+			}, nil)},
+			mockFunc: func(m *repoMock.MockIRepo) { m.EXPECT().Get(gomock.Any(), "m1").Return(stored, nil) },
+			res: model.Report{ID: "m1", Type: "service_client", DryRun: true, Checked: 2, Affected: 1, Configs: []model.ConfigDiff{
+				{Name: "a", Version: 2, Changes: []model.Change{
+					{Op: "add", Path: "/timeout", New: json.RawMessage(`200`)},
+					{Op: "remove", Path: "/timeout_ms", Old: json.RawMessage(`200`)},
+				}},
+			}},
+		},
+		{
+			name: "when applied should report new versions",
+			in: input{migrator: runTransform([]model.ConfigResult{
+				{Name: "a", Version: 2, NewVersion: 3, Changed: true, Before: json.RawMessage(`{"timeout_ms":200}`)},
+			}, nil)},
+			mockFunc: func(m *repoMock.MockIRepo) { m.EXPECT().Get(gomock.Any(), "m1").Return(stored, nil) },
+			res: model.Report{ID: "m1", Type: "service_client", Checked: 1, Affected: 1, Configs: []model.ConfigDiff{
+				{Name: "a", Version: 2, NewVersion: 3, Changes: []model.Change{
+					{Op: "add", Path: "/timeout", New: json.RawMessage(`200`)},
+					{Op: "remove", Path: "/timeout_ms", Old: json.RawMessage(`200`)},
+				}},
+			}},
+		},
+		{
+			name: "when a config fails should return FailedError with the report",
+			in: input{migrator: runTransform([]model.ConfigResult{
+				{Name: "a", Version: 2, Before: json.RawMessage(`{"timeout_ms":200}`), After: json.RawMessage(`{"timeout_ms":200}`), Error: "invalid input: timeout is required"},
+			}, fmt.Errorf("%w: migration fails for 1 of 1 configs", ErrInvalidInput))},
+			mockFunc: func(m *repoMock.MockIRepo) { m.EXPECT().Get(gomock.Any(), "m1").Return(stored, nil) },
+			err: &FailedError{Report: model.Report{ID: "m1", Type: "service_client", Checked: 1, Configs: []model.ConfigDiff{
+				{Name: "a", Version: 2, Changes: []model.Change{}, Error: "invalid input: timeout is required"},
+			}}},
+		},
+		{
+			name:     "when applied before should return ErrAlreadyApplied",
+			in:       input{migrator: runTransform(nil, ErrAlreadyApplied)},
+			mockFunc: func(m *repoMock.MockIRepo) { m.EXPECT().Get(gomock.Any(), "m1").Return(stored, nil) },
+			err:      ErrAlreadyApplied,
+		},
+		{
+			name:     "when migrator fails should return error",
+			in:       input{migrator: runTransform(nil, errors.New("db down"))},
+			mockFunc: func(m *repoMock.MockIRepo) { m.EXPECT().Get(gomock.Any(), "m1").Return(stored, nil) },
+			err:      errors.New("db down"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo, migrator: tc.in.migrator}
+
+			got, err := svc.Run(context.Background(), "m1", tc.in.dryRun)
+			if tc.err != nil {
+				assert.Equal(t, tc.err, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
+
+func TestFailedError(t *testing.T) {
+	err := &FailedError{Report: model.Report{Checked: 3, Configs: []model.ConfigDiff{{Error: "x"}, {Name: "ok"}}}}
+	assert.ErrorIs(t, err, ErrFailed)
+	assert.EqualError(t, err, "migration fails: 1 of 3 configs")
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"configuration-management-service/internal/migration/model"
+	"configuration-management-service/internal/migration/repository"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	ErrNotFound       = errors.New("not found")
+	ErrAlreadyExists  = errors.New("already exists")
+	ErrInvalidInput   = errors.New("invalid input")
+	ErrAlreadyApplied = errors.New("migration already applied")
+	ErrConflict       = errors.New("configs changed during the migration")
+	ErrFailed         = errors.New("migration fails")
+)
+
+// FailedError carries the report of a migration that some configs cannot
+// take; nothing was written.
+type FailedError struct {
+	Report model.Report
+}
+
+func (e *FailedError) Error() string {
+	failed := 0
+	for _, c := range e.Report.Configs {
+		if c.Error != "" {
+			failed++
+		}
+	}
+	return fmt.Sprintf("%s: %d of %d configs", ErrFailed, failed, e.Report.Checked)
+}
+
+func (e *FailedError) Is(target error) bool { return target == ErrFailed }
+
+// ConfigMigrator runs a transform over the stored configs of a type. It
+// returns ErrInvalidInput with the results when any config fails,
+// ErrAlreadyApplied when migrationID was applied before and ErrConflict
+// when a config changed while the migration ran.
+type ConfigMigrator interface {
+	Migrate(ctx context.Context, schemaType, migrationID string, fn model.TransformFunc, dryRun bool) ([]model.ConfigResult, error)
+}
+
+// ConfigMigratorFunc adapts a function to ConfigMigrator.
+type ConfigMigratorFunc func(ctx context.Context, schemaType, migrationID string, fn model.TransformFunc, dryRun bool) ([]model.ConfigResult, error)
+
+func (f ConfigMigratorFunc) Migrate(ctx context.Context, schemaType, migrationID string, fn model.TransformFunc, dryRun bool) ([]model.ConfigResult, error) {
+	return f(ctx, schemaType, migrationID, fn, dryRun)
+}
+
+var (
+	// idPattern keeps migration IDs readable in version history, e.g.
+	// "2025-10-service-client-timeout".
+	idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,127}$`)
+	// typePattern matches the naming of config types.
+	typePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+)
+
+type IService interface {
+	Create(ctx context.Context, req model.MigrationCreateRequest) (model.Migration, error)
+	Get(ctx context.Context, id string) (model.Migration, error)
+	List(ctx context.Context, schemaType string) ([]model.Migration, error)
+	Run(ctx context.Context, id string, dryRun bool) (model.Report, error)
+}
+
+type service struct {
+	repo     repository.IRepo
+	migrator ConfigMigrator
+}
+
+func NewService(repo repository.IRepo, migrator ConfigMigrator) IService {
+	return service{repo: repo, migrator: migrator}
+}
@@ -0,0 +1,251 @@
+package fieldcrypt
+
+import (
+	"configuration-management-service/pkg/jsonx"
+	"configuration-management-service/pkg/keyring"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Redacted replaces every secret leaf when the caller may not reveal it.
+const Redacted = "[REDACTED]"
+
+var (
+	ErrNoKey = errors.New("no encryption key configured for secret fields")
+	// ErrInvalidSecret rejects a secret leaf the client cannot have written:
+	// a redaction placeholder with no stored value behind it, or a sealed
+	// token that was not sealed for this config.
+	ErrInvalidSecret = errors.New("invalid secret field value")
+)
+
+// ICrypter seals and opens the secret fields of config data. Paths are JSON
+// pointers (e.g. "/headers") as reported by the schema validator; every
+// scalar leaf under a path is sealed on its own so objects keep their shape.
+//
+// Seal takes the stored (sealed) data of the version being replaced, nil on
+// create: a Redacted leaf keeps the sealed value at the same pointer in prev,
+// and a sealed token is only accepted when it opens under name.
+type ICrypter interface {
+	Seal(name string, paths []string, data, prev json.RawMessage) (json.RawMessage, error)
+	Open(name string, paths []string, data json.RawMessage, reveal bool) (json.RawMessage, error)
+	Rotate(name string, paths []string, data json.RawMessage) (json.RawMessage, bool, error)
+}
+
+type crypter struct {
+	kr *keyring.Keyring
+}
+
+func New(kr *keyring.Keyring) ICrypter {
+	return crypter{kr: kr}
+}
+
+func (c crypter) Seal(name string, paths []string, data, prev json.RawMessage) (json.RawMessage, error) {
+	var stored any
+	if len(prev) > 0 {
+		var err error
+		if stored, err = jsonx.Decode(prev); err != nil {
+			return nil, err
+		}
+	}
+	return c.transform(paths, data, func(ptr string, leaf any) (any, bool, error) {
+		s, isString := leaf.(string)
+		if isString && s == Redacted {
+			kept, ok := resolve(stored, ptr).(string)
+			if !ok || !keyring.IsSealed(kept) {
+				return nil, false, fmt.Errorf("%w: %s has no stored value to keep", ErrInvalidSecret, Redacted)
+			}
+			return kept, true, nil
+		}
+		if isString && keyring.IsSealed(s) {
+			if _, err := c.kr.Open(s, []byte(name)); err != nil {
+				return nil, false, fmt.Errorf("%w: sealed value does not belong to this config", ErrInvalidSecret)
+			}
+			return leaf, false, nil
+		}
+		if c.kr.ActiveKeyID() == "" {
+			return nil, false, ErrNoKey
+		}
+		pt, err := jsonx.Encode(leaf)
+		if err != nil {
+			return nil, false, err
+		}
+		tok, err := c.kr.Seal(pt, []byte(name))
+		if err != nil {
+			return nil, false, err
+		}
+		return tok, true, nil
+	})
+}
+
+func (c crypter) Open(name string, paths []string, data json.RawMessage, reveal bool) (json.RawMessage, error) {
+	return c.transform(paths, data, func(_ string, leaf any) (any, bool, error) {
+		s, ok := leaf.(string)
+		if !ok || !keyring.IsSealed(s) {
+			return leaf, false, nil
+		}
+		if !reveal {
+			return Redacted, true, nil
+		}
+		pt, err := c.kr.Open(s, []byte(name))
+		if err != nil {
+			return nil, false, err
+		}
+		v, err := jsonx.Decode(pt)
+		if err != nil {
+			return nil, false, err
+		}
+		return v, true, nil
+	})
+}
+
+// Rotate re-seals every leaf that was sealed with a key other than the active one.
+func (c crypter) Rotate(name string, paths []string, data json.RawMessage) (json.RawMessage, bool, error) {
+	changed := false
+	out, err := c.transform(paths, data, func(_ string, leaf any) (any, bool, error) {
+		s, ok := leaf.(string)
+		if !ok || !keyring.IsSealed(s) {
+			return leaf, false, nil
+		}
+		kid, err := keyring.KeyID(s)
+		if err != nil {
+			return nil, false, err
+		}
+		if kid == c.kr.ActiveKeyID() {
+			return leaf, false, nil
+		}
+		pt, err := c.kr.Open(s, []byte(name))
+		if err != nil {
+			return nil, false, err
+		}
+		tok, err := c.kr.Seal(pt, []byte(name))
+		if err != nil {
+			return nil, false, err
+		}
+		changed = true
+		return tok, true, nil
+	})
+	return out, changed, err
+}
+
+// leafFunc receives each leaf with its JSON pointer in the document.
+type leafFunc func(ptr string, leaf any) (any, bool, error)
+
+// transform applies fn to every scalar leaf below the given paths. The data is
+// returned untouched (byte for byte) when nothing changed.
+func (c crypter) transform(paths []string, data json.RawMessage, fn leafFunc) (json.RawMessage, error) {
+	if len(paths) == 0 || len(data) == 0 {
+		return data, nil
+	}
+	doc, err := jsonx.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	changed := false
+	for _, p := range paths {
+		parent, key, ok := lookup(doc, p)
+		if !ok {
+			continue
+		}
+		v, ch, err := walk(p, parent[key], fn)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		if ch {
+			parent[key] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return data, nil
+	}
+	return jsonx.Encode(doc)
+}
+
+func walk(ptr string, v any, fn leafFunc) (any, bool, error) {
+	switch t := v.(type) {
+	case map[string]any:
+		changed := false
+		for k, child := range t {
+			nv, ch, err := walk(ptr+"/"+escape(k), child, fn)
+			if err != nil {
+				return nil, false, err
+			}
+			if ch {
+				t[k] = nv
+				changed = true
+			}
+		}
+		return t, changed, nil
+	case []any:
+		changed := false
+		for i, child := range t {
+			nv, ch, err := walk(ptr+"/"+strconv.Itoa(i), child, fn)
+			if err != nil {
+				return nil, false, err
+			}
+			if ch {
+				t[i] = nv
+				changed = true
+			}
+		}
+		return t, changed, nil
+	case nil:
+		return v, false, nil
+	default:
+		return fn(ptr, v)
+	}
+}
+
+// resolve returns the value at a JSON pointer, or nil when there is none.
+func resolve(doc any, pointer string) any {
+	cur := doc
+	for _, s := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		switch t := cur.(type) {
+		case map[string]any:
+			cur = t[unescape(s)]
+		case []any:
+			i, err := strconv.Atoi(s)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil
+			}
+			cur = t[i]
+		default:
+			return nil
+		}
+	}
+	return cur
+}
+
+func escape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func unescape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+}
+
+// lookup resolves a JSON pointer to the object holding its last segment.
+func lookup(doc any, pointer string) (map[string]any, string, bool) {
+	segs := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	cur, ok := doc.(map[string]any)
+	if !ok {
+		return nil, "", false
+	}
+	for i, s := range segs {
+		s = unescape(s)
+		v, exists := cur[s]
+		if !exists {
+			return nil, "", false
+		}
+		if i == len(segs)-1 {
+			return cur, s, true
+		}
+		if cur, ok = v.(map[string]any); !ok {
+			return nil, "", false
+		}
+	}
+	return nil, "", false
+}
@@ -0,0 +1,162 @@
+package fieldcrypt
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"configuration-management-service/pkg/keyring"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newKeyring(t *testing.T, active string, ids ...string) *keyring.Keyring {
+	keys := map[string][]byte{}
+	for i, id := range ids {
+		keys[id] = bytes.Repeat([]byte{byte(i + 1)}, 32)
+	}
+	kr, err := keyring.New(active, keys)
+	assert.NoError(t, err)
+	return kr
+}
+
+func TestCrypter_SealOpen(t *testing.T) {
+	c := New(newKeyring(t, "k1", "k1"))
+	paths := []string{"/headers"}
+	data := json.RawMessage(`{"name":"pay","headers":{"Authorization":"Bearer abc","X-Retry":3}}`)
+
+	sealed, err := c.Seal("pay", paths, data, nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(sealed), "Bearer abc")
+	assert.Contains(t, string(sealed), `"name":"pay"`)
+
+	redacted, err := c.Open("pay", paths, sealed, false)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"pay","headers":{"Authorization":"[REDACTED]","X-Retry":"[REDACTED]"}}`, string(redacted))
+
+	revealed, err := c.Open("pay", paths, sealed, true)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(data), string(revealed))
+
+	// sealing twice must not double-encrypt
+	again, err := c.Seal("pay", paths, sealed, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, string(sealed), string(again))
+}
+
+func TestCrypter_Passthrough(t *testing.T) {
+	cases := []struct {
+		name  string
+		paths []string
+		data  string
+	}{
+		{name: "when no secret paths should return data untouched", paths: nil, data: `{"b":1, "a":2}`},
+		{name: "when secret field absent should return data untouched", paths: []string{"/headers"}, data: `{"b":1, "a":2}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New(nil)
+			out, err := c.Seal("x", tc.paths, json.RawMessage(tc.data), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.data, string(out))
+		})
+	}
+}
+
+func TestCrypter_SealWithoutKey(t *testing.T) {
+	c := New(nil)
+	_, err := c.Seal("x", []string{"/headers"}, json.RawMessage(`{"headers":{"a":"b"}}`), nil)
+	assert.ErrorIs(t, err, ErrNoKey)
+}
+
+func TestCrypter_Rotate(t *testing.T) {
+	paths := []string{"/headers"}
+	old := New(newKeyring(t, "k1", "k1", "k2"))
+	sealed, err := old.Seal("pay", paths, json.RawMessage(`{"headers":{"a":"b"}}`), nil)
+	assert.NoError(t, err)
+
+	rotated := New(newKeyring(t, "k2", "k1", "k2"))
+	out, changed, err := rotated.Rotate("pay", paths, sealed)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, strings.Contains(string(out), "enc:v1:k2:"))
+
+	_, changed, err = rotated.Rotate("pay", paths, out)
+	assert.NoError(t, err)
+	assert.False(t, changed, "already on active key")
+
+	plain, err := rotated.Open("pay", paths, out, true)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"headers":{"a":"b"}}`, string(plain))
+}
+
+func TestCrypter_SealClientLeaves(t *testing.T) {
+	c := New(newKeyring(t, "k1", "k1"))
+	paths := []string{"/headers"}
+	prev, err := c.Seal("pay", paths, json.RawMessage(`{"headers":{"auth":"secret","list":["a"]}}`), nil)
+	assert.NoError(t, err)
+	foreign, err := c.Seal("other", paths, json.RawMessage(`{"headers":{"auth":"stolen"}}`), nil)
+	assert.NoError(t, err)
+	var foreignDoc struct {
+		Headers map[string]string `json:"headers"`
+	}
+	assert.NoError(t, json.Unmarshal(foreign, &foreignDoc))
+
+	cases := []struct {
+		name    string
+		data    string
+		prev    json.RawMessage
+		want    string
+		wantErr error
+	}{
+		{
+			name: "when leaves are redacted should keep the stored values",
+			data: `{"headers":{"auth":"[REDACTED]","list":["[REDACTED]"]}}`,
+			prev: prev,
+			want: `{"headers":{"auth":"secret","list":["a"]}}`,
+		},
+		{
+			name: "when one leaf changes should keep the redacted one",
+			data: `{"headers":{"auth":"[REDACTED]","list":["b"]}}`,
+			prev: prev,
+			want: `{"headers":{"auth":"secret","list":["b"]}}`,
+		},
+		{
+			name:    "when redacted leaf has no stored value should fail",
+			data:    `{"headers":{"auth":"[REDACTED]","extra":"[REDACTED]"}}`,
+			prev:    prev,
+			wantErr: ErrInvalidSecret,
+		},
+		{
+			name:    "when creating with a redacted leaf should fail",
+			data:    `{"headers":{"auth":"[REDACTED]"}}`,
+			wantErr: ErrInvalidSecret,
+		},
+		{
+			name:    "when sealed token belongs to another config should fail",
+			data:    `{"headers":{"auth":"` + foreignDoc.Headers["auth"] + `"}}`,
+			prev:    prev,
+			wantErr: ErrInvalidSecret,
+		},
+		{
+			name:    "when sealed token is forged should fail",
+			data:    `{"headers":{"auth":"enc:v1:k1:AAAAAAAAAAAAAAAAAAAAAAAAAAAA"}}`,
+			wantErr: ErrInvalidSecret,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := c.Seal("pay", paths, json.RawMessage(tc.data), tc.prev)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotContains(t, string(out), Redacted)
+			plain, err := c.Open("pay", paths, out, true)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(plain))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/pkg/httpx"
 	"net/http"
 	"strings"
 
@@ -9,13 +10,14 @@ import (
 )
 
 func (h *handler) Create(c echo.Context) error {
-	if !isJSON(c) {
-		return writeErr(c, http.StatusUnsupportedMediaType, "content-type must be application/json", nil)
+	format, ok := httpx.ContentFormat(c)
+	if !ok {
+		return writeErr(c, http.StatusUnsupportedMediaType, unsupportedBody, nil)
 	}
 
 	var req model.RemoteConfigCreateRequest
-	if err := c.Bind(&req); err != nil {
-		return writeErr(c, http.StatusBadRequest, "invalid JSON", err.Error())
+	if err := httpx.Bind(c, format, &req); err != nil {
+		return writeErr(c, http.StatusBadRequest, "invalid "+string(format), err.Error())
 	}
 
 	req.Name = strings.TrimSpace(req.Name)
@@ -24,10 +26,10 @@ func (h *handler) Create(c echo.Context) error {
 		return writeErr(c, http.StatusBadRequest, "type and name are required", nil)
 	}
 
-	cfg, err := h.srv.Create(c.Request().Context(), req.Type, req.Name, req.Data)
+	cfg, err := h.srv.Create(c.Request().Context(), req.Type, req.Name, req.Data, req.Extends)
 	if err != nil {
 		return h.writeServiceError(c, err)
 	}
 
-	return c.JSON(http.StatusCreated, cfg)
+	return respond(c, http.StatusCreated, cfg)
 }
@@ -3,11 +3,13 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"configuration-management-service/internal/remote_config/fieldcrypt"
 	"configuration-management-service/internal/remote_config/model"
 	"configuration-management-service/internal/remote_config/service"
 	srvMock "configuration-management-service/internal/remote_config/service/mocks"
@@ -38,7 +40,7 @@ func TestCreate(t *testing.T) {
 			mockFunc: func(m *srvMock.MockIService) {},
 			ex: expected{
 				code: http.StatusUnsupportedMediaType,
-				json: `{"error":{"code":"Unsupported Media Type","message":"content-type must be application/json","details":null}}`,
+				json: `{"error":{"code":"Unsupported Media Type","message":"content-type must be application/json, application/yaml or application/toml","details":null}}`,
 			},
 		},
 		{
@@ -63,7 +65,7 @@ func TestCreate(t *testing.T) {
 			name: "when config is already exists should status code 409 and error message",
 			in:   input{ct: echo.MIMEApplicationJSON, body: `{"type":"feature_toggle","name":"qris","data":{"enabled":true}}`},
 			mockFunc: func(m *srvMock.MockIService) {
-				m.EXPECT().Create(gomock.Any(), "feature_toggle", "qris", json.RawMessage(`{"enabled":true}`)).
+				m.EXPECT().Create(gomock.Any(), "feature_toggle", "qris", json.RawMessage(`{"enabled":true}`), "").
 					Return(model.RemoteConfig{}, service.ErrAlreadyExists)
 			},
 			ex: expected{
@@ -71,11 +73,74 @@ func TestCreate(t *testing.T) {
 				json: `{"error":{"code":"Conflict","message":"already exists","details":null}}`,
 			},
 		},
+		{
+			name: "when no encryption key is configured should status code 503 and error message",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"type":"service_client","name":"pay","data":{"headers":{"a":"b"}}}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Create(gomock.Any(), "service_client", "pay", json.RawMessage(`{"headers":{"a":"b"}}`), "").
+					Return(model.RemoteConfig{}, fmt.Errorf("/headers: %w", fieldcrypt.ErrNoKey))
+			},
+			ex: expected{
+				code: http.StatusServiceUnavailable,
+				json: `{"error":{"code":"Service Unavailable","message":"secret fields cannot be written: no encryption key configured","details":null}}`,
+			},
+		},
+		{
+			name: "when data breaks schema should status code 400 with every violation",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"type":"feature_toggle","name":"qris","data":{"rollout_percentage":200}}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Create(gomock.Any(), "feature_toggle", "qris", json.RawMessage(`{"rollout_percentage":200}`), "").
+					Return(model.RemoteConfig{}, &service.ValidationError{Report: model.ValidationReport{Violations: []model.Violation{
+						{Pointer: "/enabled", Keyword: "required", Message: "(root): enabled is required"},
+						{Pointer: "/rollout_percentage", Keyword: "maximum", Expected: 100, Actual: 200, Message: "rollout_percentage: Must be less than or equal to 100"},
+					}}})
+			},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid input","details":{"violations":[
+					{"pointer":"/enabled","keyword":"required","message":"(root): enabled is required"},
+					{"pointer":"/rollout_percentage","keyword":"maximum","expected":100,"actual":200,"message":"rollout_percentage: Must be less than or equal to 100"}
+				]}}}`,
+			},
+		},
+		{
+			name:     "when invalid yaml should status code 400 and error message",
+			in:       input{ct: "application/yaml", body: "name: [qris"},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid YAML","details":"yaml: line 1: did not find expected ',' or ']'"}}`,
+			},
+		},
+		{
+			name: "when body is yaml should create the config from its JSON form",
+			in:   input{ct: "application/yaml; charset=utf-8", body: "type: feature_toggle\nname: qris\ndata:\n  enabled: true\n  since: 2024-05-01\n"},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Create(gomock.Any(), "feature_toggle", "qris", json.RawMessage(`{"enabled":true,"since":"2024-05-01"}`), "").
+					Return(model.RemoteConfig{Name: "qris", Type: "feature_toggle", Version: 1, Data: json.RawMessage(`{"enabled":true,"since":"2024-05-01"}`)}, nil)
+			},
+			ex: expected{
+				code: http.StatusCreated,
+				json: `{"name":"qris","type":"feature_toggle","version":1,"data":{"enabled":true,"since":"2024-05-01"},"created_at":""}`,
+			},
+		},
+		{
+			name: "when body is toml should create the config from its JSON form",
+			in:   input{ct: "application/toml", body: "type = \"feature_toggle\"\nname = \"qris\"\n\n[data]\nenabled = true\nrollout_percentage = 25\n"},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Create(gomock.Any(), "feature_toggle", "qris", json.RawMessage(`{"enabled":true,"rollout_percentage":25}`), "").
+					Return(model.RemoteConfig{Name: "qris", Type: "feature_toggle", Version: 1, Data: json.RawMessage(`{"enabled":true,"rollout_percentage":25}`)}, nil)
+			},
+			ex: expected{
+				code: http.StatusCreated,
+				json: `{"name":"qris","type":"feature_toggle","version":1,"data":{"enabled":true,"rollout_percentage":25},"created_at":""}`,
+			},
+		},
 		{
 			name: "when success",
 			in:   input{ct: echo.MIMEApplicationJSON, body: `{"type":"feature_toggle","name":"qris","data":{"enabled":true}}`},
 			mockFunc: func(m *srvMock.MockIService) {
-				m.EXPECT().Create(gomock.Any(), "feature_toggle", "qris", json.RawMessage(`{"enabled":true}`)).
+				m.EXPECT().Create(gomock.Any(), "feature_toggle", "qris", json.RawMessage(`{"enabled":true}`), "").
 					Return(model.RemoteConfig{Name: "qris", Type: "feature_toggle", Version: 1, Data: json.RawMessage(`{"enabled":true}`)}, nil)
 			},
 			ex: expected{
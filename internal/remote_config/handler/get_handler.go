@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/pkg/auth"
 	"net/http"
 	"strconv"
 	"strings"
@@ -23,12 +25,41 @@ func (h *handler) Get(c echo.Context) error {
 		v = &iv
 	}
 
-	cfg, err := h.srv.Get(c.Request().Context(), name, v)
+	var opts model.ReadOptions
+	if q := strings.TrimSpace(c.QueryParam("reveal")); q != "" {
+		reveal, err := strconv.ParseBool(q)
+		if err != nil {
+			return writeErr(c, http.StatusBadRequest, "invalid reveal", "reveal must be a boolean")
+		}
+		if reveal && !auth.HasPermission(c.Request().Context(), auth.PermReveal) {
+			return writeErr(c, http.StatusForbidden, "reveal permission required", nil)
+		}
+		opts.Reveal = reveal
+	}
+	if q := strings.TrimSpace(c.QueryParam("resolve_secrets")); q != "" {
+		resolve, err := strconv.ParseBool(q)
+		if err != nil {
+			return writeErr(c, http.StatusBadRequest, "invalid resolve_secrets", "resolve_secrets must be a boolean")
+		}
+		if resolve && !auth.HasPermission(c.Request().Context(), auth.PermReveal) {
+			return writeErr(c, http.StatusForbidden, "reveal permission required", nil)
+		}
+		opts.ResolveSecrets = resolve
+	}
+
+	switch view := strings.TrimSpace(c.QueryParam("view")); view {
+	case "", model.ViewResolved, model.ViewRaw:
+		opts.View = view
+	default:
+		return writeErr(c, http.StatusBadRequest, "invalid view", "view must be one of: resolved, raw")
+	}
+
+	cfg, err := h.srv.Get(c.Request().Context(), name, v, opts)
 	if err != nil {
 		return h.writeServiceError(c, err)
 	}
 
-	etag := weakETag(cfg.Name, cfg.Version)
+	etag := weakETag(cfg.Name, cfg.Version, cfg.Variables)
 	c.Response().Header().Set("ETag", etag)
 	c.Response().Header().Set("Cache-Control", "no-cache")
 
@@ -36,5 +67,5 @@ func (h *handler) Get(c echo.Context) error {
 		return c.NoContent(http.StatusNotModified)
 	}
 
-	return c.JSON(http.StatusOK, cfg)
+	return respond(c, http.StatusOK, cfg)
 }
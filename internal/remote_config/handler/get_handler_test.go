@@ -4,10 +4,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	neturl "net/url"
 	"testing"
 
 	"configuration-management-service/internal/remote_config/model"
 	srvMock "configuration-management-service/internal/remote_config/service/mocks"
+	"configuration-management-service/pkg/auth"
 
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
@@ -18,11 +20,17 @@ func TestGet(t *testing.T) {
 	type input struct {
 		name    string // path param
 		version string // query param, empty means nil
+		reveal  string // reveal query param
+		resolve string // resolve_secrets query param
+		view    string // view query param
+		grant   bool   // request carries auth.PermReveal
 		ifNone  string // If-None-Match header
+		accept  string // Accept header
 	}
 	type expected struct {
 		code int
 		json string
+		body string // expected body when it is not JSON
 		etag string
 	}
 
@@ -48,7 +56,7 @@ func TestGet(t *testing.T) {
 			name: "when latest success, no If-None-Match",
 			in:   input{name: "qris"},
 			mockFunc: func(m *srvMock.MockIService) {
-				m.EXPECT().Get(gomock.Any(), "qris", (*int)(nil)).
+				m.EXPECT().Get(gomock.Any(), "qris", (*int)(nil), model.ReadOptions{}).
 					Return(model.RemoteConfig{
 						Name:    "qris",
 						Type:    "feature_toggle",
@@ -59,14 +67,14 @@ func TestGet(t *testing.T) {
 			ex: expected{
 				code: http.StatusOK,
 				json: `{"name":"qris","type":"feature_toggle","version":5,"data":{"enabled":true},"created_at":""}`,
-				etag: weakETag("qris", 5),
+				etag: weakETag("qris", 5, nil),
 			},
 		},
 		{
 			name: "when If-None-Match matches should 304",
-			in:   input{name: "qris", ifNone: weakETag("qris", 5)},
+			in:   input{name: "qris", ifNone: weakETag("qris", 5, nil)},
 			mockFunc: func(m *srvMock.MockIService) {
-				m.EXPECT().Get(gomock.Any(), "qris", (*int)(nil)).
+				m.EXPECT().Get(gomock.Any(), "qris", (*int)(nil), model.ReadOptions{}).
 					Return(model.RemoteConfig{
 						Name:    "qris",
 						Type:    "feature_toggle",
@@ -77,7 +85,82 @@ func TestGet(t *testing.T) {
 			ex: expected{
 				code: http.StatusNotModified,
 				json: ``,
-				etag: weakETag("qris", 5),
+				etag: weakETag("qris", 5, nil),
+			},
+		},
+		{
+			name: "when rendered with variables should report versions and vary etag",
+			in:   input{name: "pay", ifNone: weakETag("pay", 2, nil)},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "pay", (*int)(nil), model.ReadOptions{}).
+					Return(model.RemoteConfig{
+						Name:      "pay",
+						Type:      "service_client",
+						Version:   2,
+						Data:      []byte(`{"base_url":"https://pay.example.com"}`),
+						Variables: map[string]int{"domain": 3},
+					}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"name":"pay","type":"service_client","version":2,"data":{"base_url":"https://pay.example.com"},"created_at":"","variables":{"domain":3}}`,
+				etag: weakETag("pay", 2, map[string]int{"domain": 3}),
+			},
+		},
+		{
+			name:     "when unknown view should status code 400",
+			in:       input{name: "qris", view: "merged"},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid view","details":"view must be one of: resolved, raw"}}`,
+			},
+		},
+		{
+			name: "when raw view should pass view to service",
+			in:   input{name: "eu", view: "raw"},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "eu", (*int)(nil), model.ReadOptions{View: model.ViewRaw}).
+					Return(model.RemoteConfig{Name: "eu", Type: "service_client", Version: 1, Data: []byte(`{"base_url":"https://eu"}`), Extends: "base", BaseVersion: 1}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"name":"eu","type":"service_client","version":1,"data":{"base_url":"https://eu"},"created_at":"","extends":"base","base_version":1}`,
+			},
+		},
+		{
+			name:     "when reveal without permission should status code 403",
+			in:       input{name: "qris", reveal: "true"},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusForbidden,
+				json: `{"error":{"code":"Forbidden","message":"reveal permission required","details":null}}`,
+			},
+		},
+		{
+			name:     "when resolve_secrets without permission should status code 403",
+			in:       input{name: "qris", resolve: "1"},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusForbidden,
+				json: `{"error":{"code":"Forbidden","message":"reveal permission required","details":null}}`,
+			},
+		},
+		{
+			name: "when reveal with permission should ask service to reveal",
+			in:   input{name: "pay", reveal: "true", grant: true},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "pay", (*int)(nil), model.ReadOptions{Reveal: true}).
+					Return(model.RemoteConfig{
+						Name:    "pay",
+						Type:    "service_client",
+						Version: 1,
+						Data:    []byte(`{"headers":{"Authorization":"Bearer abc"}}`),
+					}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"name":"pay","type":"service_client","version":1,"data":{"headers":{"Authorization":"Bearer abc"}},"created_at":""}`,
 			},
 		},
 		{
@@ -85,7 +168,7 @@ func TestGet(t *testing.T) {
 			in:   input{name: "qris", version: "2"},
 			mockFunc: func(m *srvMock.MockIService) {
 				v := 2
-				m.EXPECT().Get(gomock.Any(), "qris", &v).
+				m.EXPECT().Get(gomock.Any(), "qris", &v, model.ReadOptions{}).
 					Return(model.RemoteConfig{
 						Name:    "qris",
 						Type:    "feature_toggle",
@@ -96,7 +179,43 @@ func TestGet(t *testing.T) {
 			ex: expected{
 				code: http.StatusOK,
 				json: `{"name":"qris","type":"feature_toggle","version":2,"data":{"enabled":true},"created_at":""}`,
-				etag: weakETag("qris", 2),
+				etag: weakETag("qris", 2, nil),
+			},
+		},
+		{
+			name: "when Accept prefers YAML should write the config as YAML in field order",
+			in:   input{name: "qris", accept: "application/json;q=0.5, application/yaml"},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "qris", (*int)(nil), model.ReadOptions{}).
+					Return(model.RemoteConfig{Name: "qris", Type: "feature_toggle", Version: 2, Data: []byte(`{"enabled":true,"tags":["a","1"]}`)}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				body: "name: qris\ntype: feature_toggle\nversion: 2\ndata:\n  enabled: true\n  tags:\n    - a\n    - \"1\"\ncreated_at: \"\"\n",
+			},
+		},
+		{
+			name: "when Accept is TOML should write the config as TOML",
+			in:   input{name: "qris", accept: "application/toml"},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "qris", (*int)(nil), model.ReadOptions{}).
+					Return(model.RemoteConfig{Name: "qris", Type: "feature_toggle", Version: 2, Data: []byte(`{"enabled":true,"rollout_percentage":25}`)}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				body: "created_at = \"\"\nname = \"qris\"\ntype = \"feature_toggle\"\nversion = 2\n\n[data]\n  enabled = true\n  rollout_percentage = 25\n",
+			},
+		},
+		{
+			name: "when Accept is TOML and data holds null should status code 406",
+			in:   input{name: "limit", accept: "application/toml"},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "limit", (*int)(nil), model.ReadOptions{}).
+					Return(model.RemoteConfig{Name: "limit", Type: "threshold_policy", Version: 1, Data: []byte(`{"min":null}`)}, nil)
+			},
+			ex: expected{
+				code: http.StatusNotAcceptable,
+				json: `{"error":{"code":"Not Acceptable","message":"response cannot be written as TOML","details":"not representable as TOML: null at /data/min"}}`,
 			},
 		},
 	}
@@ -112,14 +231,29 @@ func TestGet(t *testing.T) {
 			h := NewHandler(srv)
 
 			// Always use a placeholder path segment; inject param separately.
-			url := "/configs/_placeholder"
+			q := neturl.Values{}
 			if tc.in.version != "" {
-				url += "?version=" + tc.in.version
+				q.Set("version", tc.in.version)
+			}
+			if tc.in.reveal != "" {
+				q.Set("reveal", tc.in.reveal)
+			}
+			if tc.in.resolve != "" {
+				q.Set("resolve_secrets", tc.in.resolve)
+			}
+			if tc.in.view != "" {
+				q.Set("view", tc.in.view)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/configs/_placeholder?"+q.Encode(), nil)
+			if tc.in.grant {
+				req = req.WithContext(auth.WithPermissions(req.Context(), auth.PermReveal))
 			}
-			req := httptest.NewRequest(http.MethodGet, url, nil)
 			if tc.in.ifNone != "" {
 				req.Header.Set("If-None-Match", tc.in.ifNone)
 			}
+			if tc.in.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tc.in.accept)
+			}
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("name")
@@ -132,9 +266,12 @@ func TestGet(t *testing.T) {
 			b, _ := io.ReadAll(res.Body)
 
 			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
-			if tc.ex.json != "" {
+			switch {
+			case tc.ex.body != "":
+				assert.Equal(t, tc.ex.body, string(b))
+			case tc.ex.json != "":
 				assert.JSONEq(t, tc.ex.json, string(b))
-			} else {
+			default:
 				assert.Equal(t, "", string(b))
 			}
 			if tc.ex.etag != "" {
@@ -1,13 +1,15 @@
 package handler
 
 import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
 	"configuration-management-service/internal/remote_config/service"
+	"configuration-management-service/pkg/httpx"
 	"crypto/sha1"
 	"encoding/hex"
 	"errors"
 	"net/http"
+	"sort"
 	"strconv"
-	"strings"
 
 	"github.com/labstack/echo/v4"
 )
@@ -18,6 +20,7 @@ type IHandler interface {
 	Get(c echo.Context) error
 	List(c echo.Context) error
 	Rollback(c echo.Context) error
+	Validate(c echo.Context) error
 }
 
 type handler struct {
@@ -30,34 +33,53 @@ func NewHandler(srv service.IService) IHandler {
 
 // --- helpers ---
 func (h *handler) writeServiceError(c echo.Context, err error) error {
+	var verr *service.ValidationError
 	switch {
+	case errors.As(err, &verr):
+		return writeErr(c, http.StatusBadRequest, "invalid input", verr.Report)
 	case errors.Is(err, service.ErrNotFound):
 		return writeErr(c, http.StatusNotFound, err.Error(), nil)
-	case errors.Is(err, service.ErrAlreadyExists):
+	case errors.Is(err, service.ErrAlreadyExists), errors.Is(err, service.ErrConflict):
 		return writeErr(c, http.StatusConflict, err.Error(), nil)
 	case errors.Is(err, service.ErrInvalidInput):
 		return writeErr(c, http.StatusBadRequest, "invalid input", err.Error())
+	case errors.Is(err, fieldcrypt.ErrNoKey):
+		// The request is fine; the server cannot store secrets until a key is set.
+		return writeErr(c, http.StatusServiceUnavailable, "secret fields cannot be written: no encryption key configured", nil)
 	default:
 		return writeErr(c, http.StatusInternalServerError, "internal error", nil)
 	}
 }
 
 func writeErr(c echo.Context, code int, msg string, details any) error {
-	return c.JSON(code, map[string]any{
-		"error": map[string]any{
-			"code":    http.StatusText(code),
-			"message": msg,
-			"details": details,
-		},
-	})
+	return httpx.WriteError(c, code, msg, details)
 }
 
 func isJSON(c echo.Context) bool {
-	ct := c.Request().Header.Get(echo.HeaderContentType)
-	return strings.HasPrefix(ct, echo.MIMEApplicationJSON)
+	return httpx.IsJSON(c)
 }
 
-func weakETag(name string, version int) string {
-	h := sha1.Sum([]byte(name + ":" + strconv.Itoa(version)))
+// unsupportedBody answers a config write in a format other than JSON, YAML
+// or TOML.
+const unsupportedBody = "content-type must be application/json, application/yaml or application/toml"
+
+// respond writes v as JSON, YAML or TOML, following the Accept header.
+func respond(c echo.Context, code int, v any) error {
+	return httpx.Respond(c, code, v)
+}
+
+// weakETag identifies a rendered read: the config version plus the version
+// of every variable rendered into it.
+func weakETag(name string, version int, vars map[string]int) string {
+	key := name + ":" + strconv.Itoa(version)
+	names := make([]string, 0, len(vars))
+	for n := range vars {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		key += ";" + n + "=" + strconv.Itoa(vars[n])
+	}
+	h := sha1.Sum([]byte(key))
 	return `W/"` + hex.EncodeToString(h[:8]) + `"`
 }
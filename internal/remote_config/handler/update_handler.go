@@ -2,6 +2,7 @@ package handler
 
 import (
 	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/pkg/httpx"
 	"net/http"
 	"strings"
 
@@ -9,8 +10,9 @@ import (
 )
 
 func (h *handler) Update(c echo.Context) error {
-	if !isJSON(c) {
-		return writeErr(c, http.StatusUnsupportedMediaType, "content-type must be application/json", nil)
+	format, ok := httpx.ContentFormat(c)
+	if !ok {
+		return writeErr(c, http.StatusUnsupportedMediaType, unsupportedBody, nil)
 	}
 
 	name := strings.TrimSpace(c.Param("name"))
@@ -19,13 +21,13 @@ func (h *handler) Update(c echo.Context) error {
 	}
 
 	var req model.RemoteConfigUpdateRequest
-	if err := c.Bind(&req); err != nil {
-		return writeErr(c, http.StatusBadRequest, "invalid JSON", err.Error())
+	if err := httpx.Bind(c, format, &req); err != nil {
+		return writeErr(c, http.StatusBadRequest, "invalid "+string(format), err.Error())
 	}
 
-	cfg, err := h.srv.Update(c.Request().Context(), name, req.Data)
+	cfg, err := h.srv.Update(c.Request().Context(), name, req.Data, req.Extends)
 	if err != nil {
 		return h.writeServiceError(c, err)
 	}
-	return c.JSON(http.StatusOK, cfg)
+	return respond(c, http.StatusOK, cfg)
 }
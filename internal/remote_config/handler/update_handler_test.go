@@ -40,7 +40,7 @@ func TestUpdate(t *testing.T) {
 			mockFunc: func(m *srvMock.MockIService) {},
 			ex: expected{
 				code: http.StatusUnsupportedMediaType,
-				json: `{"error":{"code":"Unsupported Media Type","message":"content-type must be application/json","details":null}}`,
+				json: `{"error":{"code":"Unsupported Media Type","message":"content-type must be application/json, application/yaml or application/toml","details":null}}`,
 			},
 		},
 		{
@@ -66,7 +66,7 @@ func TestUpdate(t *testing.T) {
 			name: "service not found → 404",
 			in:   input{ct: echo.MIMEApplicationJSON, name: "qris", body: `{"data":{"enabled":true}}`},
 			mockFunc: func(m *srvMock.MockIService) {
-				m.EXPECT().Update(gomock.Any(), "qris", json.RawMessage(`{"enabled":true}`)).
+				m.EXPECT().Update(gomock.Any(), "qris", json.RawMessage(`{"enabled":true}`), (*string)(nil)).
 					Return(model.RemoteConfig{}, service.ErrNotFound)
 			},
 			ex: expected{
@@ -78,7 +78,7 @@ func TestUpdate(t *testing.T) {
 			name: "success",
 			in:   input{ct: echo.MIMEApplicationJSON, name: "qris", body: `{"data":{"enabled":true}}`},
 			mockFunc: func(m *srvMock.MockIService) {
-				m.EXPECT().Update(gomock.Any(), "qris", json.RawMessage(`{"enabled":true}`)).
+				m.EXPECT().Update(gomock.Any(), "qris", json.RawMessage(`{"enabled":true}`), (*string)(nil)).
 					Return(model.RemoteConfig{Name: "qris", Type: "feature_toggle", Version: 2, Data: json.RawMessage(`{"enabled":true}`)}, nil)
 			},
 			ex: expected{
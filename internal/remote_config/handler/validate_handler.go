@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Validate dry-runs a create or update: the same checks run, nothing is
+// stored, and the response describes the version that would be written.
+func (h *handler) Validate(c echo.Context) error {
+	format, ok := httpx.ContentFormat(c)
+	if !ok {
+		return writeErr(c, http.StatusUnsupportedMediaType, unsupportedBody, nil)
+	}
+
+	var req model.RemoteConfigValidateRequest
+	if err := httpx.Bind(c, format, &req); err != nil {
+		return writeErr(c, http.StatusBadRequest, "invalid "+string(format), err.Error())
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return writeErr(c, http.StatusBadRequest, "name is required", nil)
+	}
+
+	res, err := h.srv.DryRun(c.Request().Context(), req.Type, req.Name, req.Data, req.Extends)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return respond(c, http.StatusOK, res)
+}
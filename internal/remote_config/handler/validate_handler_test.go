@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/service"
+	srvMock "configuration-management-service/internal/remote_config/service/mocks"
+	"configuration-management-service/pkg/jsonx"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	type input struct {
+		ct   string
+		body string
+	}
+	type expected struct {
+		code int
+		json string
+	}
+	base := "checkout-base"
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		in       input
+		ex       expected
+	}{
+		{
+			name:     "when unsupported media type should status code 415",
+			in:       input{ct: "text/plain", body: `{}`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusUnsupportedMediaType,
+				json: `{"error":{"code":"Unsupported Media Type","message":"content-type must be application/json, application/yaml or application/toml","details":null}}`,
+			},
+		},
+		{
+			name:     "when name is missing should status code 400",
+			in:       input{ct: echo.MIMEApplicationJSON, body: `{"type":"feature_toggle","data":{}}`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"name is required","details":null}}`,
+			},
+		},
+		{
+			name: "when data breaks schema should status code 400 with every violation",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"name":"checkout","data":{"rollout_percentage":200}}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().DryRun(gomock.Any(), "", "checkout", json.RawMessage(`{"rollout_percentage":200}`), (*string)(nil)).
+					Return(model.DryRun{}, &service.ValidationError{Report: model.ValidationReport{Violations: []model.Violation{
+						{Pointer: "/rollout_percentage", Keyword: "maximum", Expected: 100, Actual: 200, Message: "rollout_percentage: Must be less than or equal to 100"},
+					}}})
+			},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid input","details":{"violations":[
+					{"pointer":"/rollout_percentage","keyword":"maximum","expected":100,"actual":200,"message":"rollout_percentage: Must be less than or equal to 100"}
+				]}}}`,
+			},
+		},
+		{
+			name: "when update of a missing config should status code 404",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"name":"checkout","data":{"enabled":true}}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().DryRun(gomock.Any(), "", "checkout", json.RawMessage(`{"enabled":true}`), (*string)(nil)).
+					Return(model.DryRun{}, service.ErrNotFound)
+			},
+			ex: expected{
+				code: http.StatusNotFound,
+				json: `{"error":{"code":"Not Found","message":"not found","details":null}}`,
+			},
+		},
+		{
+			name: "when valid should status code 200 with the would-be version",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"type":"feature_toggle","name":"checkout","data":{"enabled":false},"extends":"checkout-base"}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().DryRun(gomock.Any(), "feature_toggle", "checkout", json.RawMessage(`{"enabled":false}`), &base).
+					Return(model.DryRun{Name: "checkout", Type: "feature_toggle", Operation: model.OperationUpdate, Version: 3, CurrentVersion: 2, SchemaVersion: 1,
+						Extends: base, BaseVersion: 7, Data: json.RawMessage(`{"enabled":false}`),
+						Changes: []jsonx.Change{{Op: "replace", Path: "/enabled", Old: json.RawMessage(`true`), New: json.RawMessage(`false`)}}}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"name":"checkout","type":"feature_toggle","operation":"update","version":3,"current_version":2,"schema_version":1,
+					"extends":"checkout-base","base_version":7,"data":{"enabled":false},
+					"changes":[{"op":"replace","path":"/enabled","old":true,"new":false}]}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodPost, "/configs/validate", bytes.NewBufferString(tc.in.body))
+			req.Header.Set(echo.HeaderContentType, tc.in.ct)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			_ = h.Validate(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.ex.json, string(b))
+		})
+	}
+}
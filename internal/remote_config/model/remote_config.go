@@ -1,25 +1,167 @@
 package model
 
-import "encoding/json"
+import (
+	"configuration-management-service/pkg/jsonx"
+	"encoding/json"
+)
 
 type RemoteConfig struct {
-	Name      string          `json:"name"`
-	Type      string          `json:"type"`
-	Version   int             `json:"version"`
-	Data      json.RawMessage `json:"data"`
-	CreatedAt string          `json:"created_at"`
+	Name        string          `json:"name"`
+	Type        string          `json:"type"`
+	Version     int             `json:"version"`
+	Data        json.RawMessage `json:"data"`
+	CreatedAt   string          `json:"created_at"`
+	Extends     string          `json:"extends,omitempty"`
+	BaseVersion int             `json:"base_version,omitempty"`
+	// SchemaVersion is the version of the type's schema that validated this version.
+	SchemaVersion int `json:"schema_version,omitempty"`
+	// Variables maps each variable rendered into Data to the version used.
+	Variables map[string]int `json:"variables,omitempty"`
+	// MigrationID names the data migration that wrote this version.
+	MigrationID string `json:"migration_id,omitempty"`
+	// Defaulted lists JSON pointers filled from schema defaults at read time.
+	Defaulted []string `json:"defaulted,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+// VersionMeta is stored alongside the data of every version.
+type VersionMeta struct {
+	// Extends names the base config this one is overlaid on ("" = none).
+	Extends string
+	// BaseVersion pins the base version the overlay was resolved against,
+	// so the effective value of every historical version stays reproducible.
+	BaseVersion int
+	// SchemaVersion records the schema version the data was validated against.
+	SchemaVersion int
+	// MigrationID tags versions written by a data migration.
+	MigrationID string
+	// Layer is the range an active experiment_config takes in its layer,
+	// nil for any other version.
+	Layer *Layer
+}
+
+// Layer is a share of an experiment layer: the contexts whose layer bucket
+// is in [Start, End).
+type Layer struct {
+	Name  string
+	Start float64
+	End   float64
 }
 
 type RemoteConfigCreateRequest struct {
-	Type string          `json:"type"`
-	Name string          `json:"name"`
-	Data json.RawMessage `json:"data"`
+	Type    string          `json:"type"`
+	Name    string          `json:"name"`
+	Data    json.RawMessage `json:"data"`
+	Extends string          `json:"extends,omitempty"`
 }
 
 type RemoteConfigUpdateRequest struct {
 	Data json.RawMessage `json:"data"`
+	// Extends is kept from the latest version when omitted; "" removes it.
+	Extends *string `json:"extends,omitempty"`
+}
+
+// RemoteConfigValidateRequest is the body of a dry run: the create body for
+// a new config, or a name plus data for the next version of an existing one.
+type RemoteConfigValidateRequest struct {
+	Type string          `json:"type,omitempty"`
+	Name string          `json:"name"`
+	Data json.RawMessage `json:"data"`
+	// Extends is kept from the latest version when omitted; "" removes it.
+	Extends *string `json:"extends,omitempty"`
+}
+
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+)
+
+// DryRun is the version a create or update would write. Data is the own
+// data to be stored and Changes its diff against the current latest
+// version; secret values are redacted in both.
+type DryRun struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Operation string `json:"operation"`
+	// Version is the version number the write would get.
+	Version        int             `json:"version"`
+	CurrentVersion int             `json:"current_version,omitempty"`
+	SchemaVersion  int             `json:"schema_version"`
+	Extends        string          `json:"extends,omitempty"`
+	BaseVersion    int             `json:"base_version,omitempty"`
+	Data           json.RawMessage `json:"data"`
+	Changes        []jsonx.Change  `json:"changes"`
+	// Dependents lists the configs extending this one that were validated
+	// against the new value.
+	Dependents []string `json:"dependents,omitempty"`
+	Warnings   []string `json:"warnings,omitempty"`
 }
 
 type RemoteConfigRollbackRequest struct {
 	Version int `json:"version"`
 }
+
+const (
+	// ViewResolved merges every config with its base chain and renders
+	// variables (default).
+	ViewResolved = "resolved"
+	// ViewRaw returns the stored overlay only, templates unrendered.
+	ViewRaw = "raw"
+)
+
+// ReadOptions controls how a stored config is presented to the caller.
+type ReadOptions struct {
+	// Reveal returns decrypted secret fields instead of redacted placeholders.
+	Reveal bool
+	// ResolveSecrets replaces ${secret:<path>} references with provider values.
+	// It implies Reveal.
+	ResolveSecrets bool
+	// View is ViewResolved or ViewRaw; empty means ViewResolved.
+	View string
+}
+
+// Violation is one schema rule the config data breaks. Pointer is a JSON
+// pointer into the data; for a missing or disallowed property it points at
+// that property.
+type Violation struct {
+	Pointer  string `json:"pointer"`
+	Keyword  string `json:"keyword"`
+	Expected any    `json:"expected,omitempty"`
+	Actual   any    `json:"actual,omitempty"`
+	Message  string `json:"message"`
+}
+
+// ValidationReport lists every violation of a rejected write. Config names
+// the dependent config that failed when the change broke one of them.
+type ValidationReport struct {
+	Config     string      `json:"config,omitempty"`
+	Violations []Violation `json:"violations"`
+}
+
+// MigrateFunc rewrites the own data of one config for a data migration.
+// inherited is the effective data of its base after the migration, nil
+// for a config without a base. Both are in plain text.
+type MigrateFunc func(own, inherited json.RawMessage) (json.RawMessage, error)
+
+// MigrationItem is one new version written in a batch: by a data
+// migration, or by a write together with the dependents it re-pins.
+type MigrationItem struct {
+	Name string
+	// FromVersion is the latest version the data was computed from; the
+	// write fails if the config moved on since.
+	FromVersion int
+	Data        json.RawMessage
+	Meta        VersionMeta
+}
+
+// MigrationResult is the outcome of a data migration for one config.
+// Before and After are its own data with secret fields redacted.
+type MigrationResult struct {
+	Name       string
+	Version    int
+	NewVersion int
+	Changed    bool
+	Before     json.RawMessage
+	After      json.RawMessage
+	Error      string
+}
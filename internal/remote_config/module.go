@@ -1,10 +1,16 @@
 package remote_config
 
 import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
 	"configuration-management-service/internal/remote_config/handler"
+	"configuration-management-service/internal/remote_config/render"
 	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/internal/remote_config/secretref"
 	"configuration-management-service/internal/remote_config/service"
 	"configuration-management-service/internal/remote_config/validator"
+	"configuration-management-service/pkg/config"
+	"configuration-management-service/pkg/keyring"
+	"context"
 	"database/sql"
 
 	"github.com/labstack/echo/v4"
@@ -12,6 +18,10 @@ import (
 
 type IModule interface {
 	RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc)
+	Reencrypt(ctx context.Context) (int, error)
+	ActiveKeyID() string
+	SchemaChanged(schemaType string)
+	Service() service.IService
 }
 
 type module struct {
@@ -19,13 +29,27 @@ type module struct {
 	repo      repository.IRepo
 	h         handler.IHandler
 	validator validator.ISchemaValidator
+	kr        *keyring.Keyring
 }
 
 func New(
 	repo repository.IRepo,
 	schemaValidator validator.ISchemaValidator,
+	crypter fieldcrypt.ICrypter,
+	resolver secretref.IResolver,
+	renderer render.IRenderer,
 ) IModule {
-	srv := service.NewService(repo, schemaValidator)
+	return newModule(repo, schemaValidator, crypter, resolver, renderer)
+}
+
+func newModule(
+	repo repository.IRepo,
+	schemaValidator validator.ISchemaValidator,
+	crypter fieldcrypt.ICrypter,
+	resolver secretref.IResolver,
+	renderer render.IRenderer,
+) *module {
+	srv := service.NewService(repo, schemaValidator, crypter, resolver, renderer)
 	h := handler.NewHandler(srv)
 
 	return &module{
@@ -36,14 +60,49 @@ func New(
 	}
 }
 
-func NewWithDB(db *sql.DB) IModule {
-	schemaValidator := validator.NewSchemaValidator()
+func NewWithDB(db *sql.DB, kr *keyring.Keyring, resolver secretref.IResolver, vars render.VariableSource, schemas validator.SchemaSource) IModule {
+	schemaValidator := validator.NewSchemaValidator(schemas, validator.BuiltinRules())
 	repo := repository.NewRepo(db)
-	return New(repo, schemaValidator)
+	m := newModule(repo, schemaValidator, fieldcrypt.New(kr), resolver, render.NewRenderer(vars))
+	m.kr = kr
+	return m
+}
+
+// InitModule wires the module from configuration; vars supplies the shared
+// variables configs may reference (nil = none) and schemas the registry
+// configs are validated against.
+func InitModule(db *sql.DB, cfg config.App, vars render.VariableSource, schemas validator.SchemaSource) (IModule, error) {
+	kr, err := keyring.Load(cfg.KeyringFile, cfg.KeyringKeys, cfg.KeyringActive)
+	if err != nil {
+		return nil, err
+	}
+
+	var providers []secretref.SecretProvider
+	if cfg.SecretsDir != "" {
+		providers = append(providers, secretref.NewFileProvider(cfg.SecretsDir))
+	}
+	providers = append(providers, secretref.NewEnvProvider(cfg.SecretsEnvPrefix))
+
+	return NewWithDB(db, kr, secretref.NewResolver(providers...), vars, schemas), nil
+}
+
+// Service exposes the config store to modules that check stored configs.
+func (m *module) Service() service.IService {
+	return m.srv
+}
+
+func (m *module) Reencrypt(ctx context.Context) (int, error) {
+	return m.srv.Reencrypt(ctx)
+}
+
+// SchemaChanged makes validation read the latest schema of schemaType again.
+func (m *module) SchemaChanged(schemaType string) {
+	m.validator.Invalidate(schemaType)
 }
 
-func InitModule(db *sql.DB) IModule {
-	return NewWithDB(db)
+// ActiveKeyID names the key Reencrypt seals with; "" when no keyring is set.
+func (m *module) ActiveKeyID() string {
+	return m.kr.ActiveKeyID()
 }
 
 func (m *module) RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc) {
@@ -53,6 +112,7 @@ func (m *module) RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc) {
 
 	cfgs := g.Group("/configs")
 	cfgs.POST("", m.h.Create, writeLimit)
+	cfgs.POST("/validate", m.h.Validate, writeLimit)
 	cfgs.PUT("/:name", m.h.Update, writeLimit)
 	cfgs.GET("/:name", m.h.Get)
 	cfgs.GET("/:name/versions", m.h.List)
@@ -0,0 +1,178 @@
+package render
+
+import (
+	"bytes"
+	"configuration-management-service/pkg/jsonx"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+	"text/template/parse"
+)
+
+// ErrTemplate marks config data that does not render: bad template syntax or
+// a reference to an unknown variable.
+var ErrTemplate = errors.New("template error")
+
+var openDelim = []byte("{{")
+
+// IRenderer renders {{ .vars.<name> }} templates inside string values of
+// config data and reports the variable versions it used.
+type IRenderer interface {
+	Render(ctx context.Context, data json.RawMessage) (json.RawMessage, map[string]int, error)
+	// Pin returns a renderer bound to the variables as they are now, so a
+	// batch of configs renders against one snapshot.
+	Pin(ctx context.Context) (IRenderer, error)
+}
+
+type renderer struct {
+	src VariableSource
+}
+
+// NewRenderer reads variables from src on every render; a nil src knows no
+// variables, so any reference fails to render.
+func NewRenderer(src VariableSource) IRenderer {
+	return renderer{src: src}
+}
+
+func (r renderer) Pin(ctx context.Context) (IRenderer, error) {
+	if r.src == nil {
+		return r, nil
+	}
+	vars, err := r.src.Variables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load variables: %w", err)
+	}
+	return renderer{src: SourceFunc(func(context.Context) (map[string]Value, error) { return vars, nil })}, nil
+}
+
+func (r renderer) Render(ctx context.Context, data json.RawMessage) (json.RawMessage, map[string]int, error) {
+	if !bytes.Contains(data, openDelim) {
+		return data, nil, nil
+	}
+	doc, err := jsonx.Decode(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vars := map[string]Value{}
+	if r.src != nil {
+		if vars, err = r.src.Variables(ctx); err != nil {
+			return nil, nil, fmt.Errorf("load variables: %w", err)
+		}
+	}
+	values := make(map[string]string, len(vars))
+	for name, v := range vars {
+		values[name] = v.Value
+	}
+	scope := map[string]any{"vars": values}
+
+	used := map[string]int{}
+	doc, err = walk(doc, func(s string) (string, error) {
+		if !bytes.Contains([]byte(s), openDelim) {
+			return s, nil
+		}
+		tmpl, err := template.New("value").Option("missingkey=error").Parse(s)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrTemplate, err.Error())
+		}
+		for _, name := range referenced(tmpl.Tree.Root) {
+			v, ok := vars[name]
+			if !ok {
+				return "", fmt.Errorf("%w: unknown variable %q", ErrTemplate, name)
+			}
+			used[name] = v.Version
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, scope); err != nil {
+			return "", fmt.Errorf("%w: %s", ErrTemplate, err.Error())
+		}
+		return buf.String(), nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out, err := jsonx.Encode(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(used) == 0 {
+		used = nil
+	}
+	return out, used, nil
+}
+
+// walk applies fn to every string value; object keys are left alone.
+func walk(v any, fn func(string) (string, error)) (any, error) {
+	var err error
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if t[k], err = walk(child, fn); err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+		}
+		return t, nil
+	case []any:
+		for i, child := range t {
+			if t[i], err = walk(child, fn); err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+		}
+		return t, nil
+	case string:
+		return fn(t)
+	default:
+		return v, nil
+	}
+}
+
+// referenced lists the variable names a template reads through .vars.<name>.
+func referenced(root parse.Node) []string {
+	var out []string
+	var visit func(n parse.Node)
+	visit = func(n parse.Node) {
+		switch t := n.(type) {
+		case *parse.ListNode:
+			if t == nil {
+				return
+			}
+			for _, c := range t.Nodes {
+				visit(c)
+			}
+		case *parse.ActionNode:
+			visit(t.Pipe)
+		case *parse.PipeNode:
+			if t == nil {
+				return
+			}
+			for _, c := range t.Cmds {
+				visit(c)
+			}
+		case *parse.CommandNode:
+			for _, a := range t.Args {
+				visit(a)
+			}
+		case *parse.FieldNode:
+			if len(t.Ident) >= 2 && t.Ident[0] == "vars" {
+				out = append(out, t.Ident[1])
+			}
+		case *parse.IfNode:
+			visit(t.Pipe)
+			visit(t.List)
+			visit(t.ElseList)
+		case *parse.RangeNode:
+			visit(t.Pipe)
+			visit(t.List)
+			visit(t.ElseList)
+		case *parse.WithNode:
+			visit(t.Pipe)
+			visit(t.List)
+			visit(t.ElseList)
+		}
+	}
+	visit(root)
+	return out
+}
@@ -0,0 +1,107 @@
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func staticSource(vars map[string]Value) VariableSource {
+	return SourceFunc(func(context.Context) (map[string]Value, error) { return vars, nil })
+}
+
+func TestRenderer_Render(t *testing.T) {
+	r := NewRenderer(staticSource(map[string]Value{
+		"domain": {Version: 3, Value: "example.com"},
+		"region": {Version: 1, Value: "eu"},
+		"unused": {Version: 7, Value: "x"},
+	}))
+
+	cases := []struct {
+		name string
+		in   string
+		out  string
+		used map[string]int
+		err  error
+	}{
+		{
+			name: "when no templates should return data untouched",
+			in:   `{"b":1,"a":"x"}`,
+			out:  `{"b":1,"a":"x"}`,
+		},
+		{
+			name: "when templates present should render strings and report versions",
+			in:   `{"base_url":"https://{{ .vars.region }}.{{ .vars.domain }}","n":1.50,"tags":["{{ .vars.region }}"]}`,
+			out:  `{"base_url":"https://eu.example.com","n":1.50,"tags":["eu"]}`,
+			used: map[string]int{"domain": 3, "region": 1},
+		},
+		{
+			name: "when variable used inside a conditional should report it",
+			in:   `{"a":"{{ if .vars.region }}{{ .vars.region }}{{ end }}"}`,
+			out:  `{"a":"eu"}`,
+			used: map[string]int{"region": 1},
+		},
+		{
+			name: "when variable unknown should return ErrTemplate",
+			in:   `{"a":"{{ .vars.missing }}"}`,
+			err:  ErrTemplate,
+		},
+		{
+			name: "when template malformed should return ErrTemplate",
+			in:   `{"a":"{{ .vars.domain "}`,
+			err:  ErrTemplate,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, used, err := r.Render(context.Background(), json.RawMessage(tc.in))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.out, string(got))
+			assert.Equal(t, tc.used, used)
+		})
+	}
+}
+
+func TestRenderer_Render_SourceError(t *testing.T) {
+	r := NewRenderer(SourceFunc(func(context.Context) (map[string]Value, error) {
+		return nil, errors.New("db down")
+	}))
+	_, _, err := r.Render(context.Background(), json.RawMessage(`{"a":"{{ .vars.x }}"}`))
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTemplate)
+}
+
+func TestRenderer_Render_NilSource(t *testing.T) {
+	_, _, err := NewRenderer(nil).Render(context.Background(), json.RawMessage(`{"a":"{{ .vars.x }}"}`))
+	assert.ErrorIs(t, err, ErrTemplate)
+}
+
+func TestRenderer_Pin(t *testing.T) {
+	version := 1
+	calls := 0
+	r := NewRenderer(SourceFunc(func(context.Context) (map[string]Value, error) {
+		calls++
+		return map[string]Value{"domain": {Version: version, Value: "v" + strconv.Itoa(version) + ".example.com"}}, nil
+	}))
+
+	pinned, err := r.Pin(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	version = 2
+	for i := 0; i < 2; i++ {
+		out, used, err := pinned.Render(context.Background(), json.RawMessage(`{"host":"{{ .vars.domain }}"}`))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"host":"v1.example.com"}`, string(out))
+		assert.Equal(t, map[string]int{"domain": 1}, used)
+	}
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,21 @@
+package render
+
+import "context"
+
+// Value is the latest version of one variable.
+type Value struct {
+	Version int
+	Value   string
+}
+
+// VariableSource supplies the variables a config may reference as {{ .vars.<name> }}.
+type VariableSource interface {
+	Variables(ctx context.Context) (map[string]Value, error)
+}
+
+// SourceFunc adapts a plain function to VariableSource.
+type SourceFunc func(ctx context.Context) (map[string]Value, error)
+
+func (f SourceFunc) Variables(ctx context.Context) (map[string]Value, error) {
+	return f(ctx)
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// AppendBatch appends one version per item in a single transaction, so a
+// config and the dependents re-pinned to it are written together or not at
+// all. Items come bases first, as for AppendMigration; it fails with
+// ErrConflict when a config moved past FromVersion.
+func (r *repo) AppendBatch(ctx context.Context, items []model.MigrationItem) ([]model.RemoteConfig, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, fmt.Errorf("append.begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	out, err := appendItems(ctx, tx, "", items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("append.commit: %w", err)
+	}
+	return out, nil
+}
+
+// appendItems writes items in order within tx, tagged with migrationID
+// ("" for none). An item extending a config written earlier in the batch is
+// pinned to that new version, and an item taking a layer range is checked
+// against the ranges held once the items before it are written.
+func appendItems(ctx context.Context, tx *sql.Tx, migrationID string, items []model.MigrationItem) ([]model.RemoteConfig, error) {
+	const qSel = `
+		SELECT version, type
+		FROM configs
+		WHERE name = ?
+		ORDER BY version DESC
+		LIMIT 1
+	`
+	const qIns = `
+		INSERT INTO configs(name, type, version, data, extends, base_version, schema_version, migration_id, layer_name, layer_start, layer_end)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	written := make(map[string]int, len(items))
+	out := make([]model.RemoteConfig, 0, len(items))
+	for _, it := range items {
+		var latest int
+		var schemaType string
+		if err := tx.QueryRowContext(ctx, qSel, it.Name).Scan(&latest, &schemaType); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, ErrNotFound
+			}
+			return nil, fmt.Errorf("append.select: %w", err)
+		}
+		if latest != it.FromVersion {
+			return nil, fmt.Errorf("%w: %s is at version %d, expected %d", ErrConflict, it.Name, latest, it.FromVersion)
+		}
+
+		if err := checkLayer(ctx, tx, it.Name, it.Meta.Layer); err != nil {
+			return nil, err
+		}
+
+		meta := it.Meta
+		if v, ok := written[meta.Extends]; ok {
+			meta.BaseVersion = v
+		}
+		next := latest + 1
+		layerName, layerStart, layerEnd := layerColumns(meta)
+		if _, err := tx.ExecContext(ctx, qIns, it.Name, schemaType, next, string(it.Data), nullable(meta.Extends), nullable(meta.BaseVersion), nullable(meta.SchemaVersion), nullable(migrationID), layerName, layerStart, layerEnd); err != nil {
+			return nil, fmt.Errorf("append.insert: %w", err)
+		}
+		written[it.Name] = next
+
+		cfg, err := byVersionTx(ctx, tx, it.Name, next)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cfg)
+	}
+	return out, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AppendBatch(t *testing.T) {
+	const selectSQL = `SELECT version, type FROM configs WHERE name = ? ORDER BY version DESC LIMIT 1`
+	const insertSQL = `INSERT INTO configs(name, type, version, data, extends, base_version, schema_version, migration_id, layer_name, layer_start, layer_end) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	const readBackSQL = `SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id FROM configs WHERE name = ? AND version = ? LIMIT 1`
+	columns := []string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}
+
+	items := []model.MigrationItem{
+		{Name: "base", FromVersion: 2, Data: json.RawMessage(`{"on":true}`), Meta: model.VersionMeta{SchemaVersion: 1}},
+		{Name: "child", FromVersion: 1, Data: json.RawMessage(`{}`), Meta: model.VersionMeta{Extends: "base", SchemaVersion: 1}},
+	}
+	writeBase := func(m sqlmock.Sqlmock) {
+		m.ExpectQuery(selectSQL).WithArgs("base").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "type"}).AddRow(2, "feature_toggle"))
+		m.ExpectExec(insertSQL).
+			WithArgs("base", "feature_toggle", 3, `{"on":true}`, nil, nil, 1, nil, nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		m.ExpectQuery(readBackSQL).WithArgs("base", 3).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("base", "feature_toggle", 3, `{"on":true}`, "2025-10-01T00:00:01Z", nil, nil, 1, nil))
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		want     []model.RemoteConfig
+		wantErr  error
+	}{
+		{
+			name: "when dependent insert fails should roll back the base version too",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				writeBase(m)
+				m.ExpectQuery(selectSQL).WithArgs("child").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "type"}).AddRow(1, "feature_toggle"))
+				m.ExpectExec(insertSQL).
+					WithArgs("child", "feature_toggle", 2, `{}`, "base", 3, 1, nil, nil, nil, nil).
+					WillReturnError(errors.New("disk I/O error"))
+				m.ExpectRollback()
+			},
+			wantErr: errors.New("append.insert: disk I/O error"),
+		},
+		{
+			name: "when dependent moved on should roll back and return ErrConflict",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				writeBase(m)
+				m.ExpectQuery(selectSQL).WithArgs("child").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "type"}).AddRow(2, "feature_toggle"))
+				m.ExpectRollback()
+			},
+			wantErr: ErrConflict,
+		},
+		{
+			name: "when success should pin the dependent to the new base version",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				writeBase(m)
+				m.ExpectQuery(selectSQL).WithArgs("child").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "type"}).AddRow(1, "feature_toggle"))
+				m.ExpectExec(insertSQL).
+					WithArgs("child", "feature_toggle", 2, `{}`, "base", 3, 1, nil, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				m.ExpectQuery(readBackSQL).WithArgs("child", 2).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("child", "feature_toggle", 2, `{}`, "2025-10-01T00:00:01Z", "base", 3, 1, nil))
+				m.ExpectCommit()
+			},
+			want: []model.RemoteConfig{
+				{Name: "base", Type: "feature_toggle", Version: 3, Data: json.RawMessage(`{"on":true}`), CreatedAt: "2025-10-01T00:00:01Z", SchemaVersion: 1},
+				{Name: "child", Type: "feature_toggle", Version: 2, Data: json.RawMessage(`{}`), CreatedAt: "2025-10-01T00:00:01Z", Extends: "base", BaseVersion: 3, SchemaVersion: 1},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.AppendBatch(context.Background(), items)
+
+			switch {
+			case errors.Is(tc.wantErr, ErrConflict):
+				assert.ErrorIs(t, err, ErrConflict)
+			case tc.wantErr != nil:
+				assert.EqualError(t, err, tc.wantErr.Error())
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// AppendMigration appends one version per item in a single transaction,
+// tagged with migrationID, and records the run in applied_migrations even
+// when items is empty. Items must come bases first: an item extending a
+// config written earlier in the batch is pinned to that new version. It
+// fails with ErrAlreadyExists when the migration was applied before and
+// with ErrConflict when a config moved past FromVersion.
+func (r *repo) AppendMigration(ctx context.Context, migrationID string, items []model.MigrationItem) ([]model.RemoteConfig, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, fmt.Errorf("migrate.begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var applied int
+	const qApplied = `SELECT COUNT(*) FROM applied_migrations WHERE id = ?`
+	if err := tx.QueryRowContext(ctx, qApplied, migrationID).Scan(&applied); err != nil {
+		return nil, fmt.Errorf("migrate.applied: %w", err)
+	}
+	if applied > 0 {
+		return nil, ErrAlreadyExists
+	}
+
+	out, err := appendItems(ctx, tx, migrationID, items)
+	if err != nil {
+		return nil, err
+	}
+
+	const qRecord = `INSERT INTO applied_migrations(id) VALUES(?)`
+	if _, err := tx.ExecContext(ctx, qRecord, migrationID); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("migrate.record: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("migrate.commit: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AppendMigration(t *testing.T) {
+	const appliedSQL = `SELECT COUNT(*) FROM applied_migrations WHERE id = ?`
+	const recordSQL = `INSERT INTO applied_migrations(id) VALUES(?)`
+	const selectSQL = `SELECT version, type FROM configs WHERE name = ? ORDER BY version DESC LIMIT 1`
+	const insertSQL = `INSERT INTO configs(name, type, version, data, extends, base_version, schema_version, migration_id, layer_name, layer_start, layer_end) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	const readBackSQL = `SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id FROM configs WHERE name = ? AND version = ? LIMIT 1`
+	columns := []string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}
+
+	items := []model.MigrationItem{
+		{Name: "base", FromVersion: 2, Data: json.RawMessage(`{"on":true}`), Meta: model.VersionMeta{SchemaVersion: 1}},
+		{Name: "child", FromVersion: 1, Data: json.RawMessage(`{}`), Meta: model.VersionMeta{Extends: "base", BaseVersion: 2, SchemaVersion: 1}},
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		want     []model.RemoteConfig
+		wantErr  error
+	}{
+		{
+			name: "when migration already applied should return ErrAlreadyExists",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(appliedSQL).WithArgs("m1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				m.ExpectRollback()
+			},
+			wantErr: ErrAlreadyExists,
+		},
+		{
+			name: "when config moved on should return ErrConflict",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(appliedSQL).WithArgs("m1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				m.ExpectQuery(selectSQL).WithArgs("base").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "type"}).AddRow(3, "feature_toggle"))
+				m.ExpectRollback()
+			},
+			wantErr: ErrConflict,
+		},
+		{
+			name: "when config missing should return ErrNotFound",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(appliedSQL).WithArgs("m1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				m.ExpectQuery(selectSQL).WithArgs("base").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "type"}))
+				m.ExpectRollback()
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "when success should pin children to the new base version",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(appliedSQL).WithArgs("m1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				m.ExpectQuery(selectSQL).WithArgs("base").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "type"}).AddRow(2, "feature_toggle"))
+				m.ExpectExec(insertSQL).
+					WithArgs("base", "feature_toggle", 3, `{"on":true}`, nil, nil, 1, "m1", nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery(readBackSQL).WithArgs("base", 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("base", "feature_toggle", 3, `{"on":true}`, "2025-10-01T00:00:01Z", nil, nil, 1, "m1"))
+
+				m.ExpectQuery(selectSQL).WithArgs("child").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "type"}).AddRow(1, "feature_toggle"))
+				m.ExpectExec(insertSQL).
+					WithArgs("child", "feature_toggle", 2, `{}`, "base", 3, 1, "m1", nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				m.ExpectQuery(readBackSQL).WithArgs("child", 2).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("child", "feature_toggle", 2, `{}`, "2025-10-01T00:00:01Z", "base", 3, 1, "m1"))
+
+				m.ExpectExec(recordSQL).WithArgs("m1").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+			want: []model.RemoteConfig{
+				{Name: "base", Type: "feature_toggle", Version: 3, Data: json.RawMessage(`{"on":true}`), CreatedAt: "2025-10-01T00:00:01Z", SchemaVersion: 1, MigrationID: "m1"},
+				{Name: "child", Type: "feature_toggle", Version: 2, Data: json.RawMessage(`{}`), CreatedAt: "2025-10-01T00:00:01Z", Extends: "base", BaseVersion: 3, SchemaVersion: 1, MigrationID: "m1"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.AppendMigration(context.Background(), "m1", items)
+
+			if tc.wantErr != nil {
+				assert.True(t, errors.Is(err, tc.wantErr), "got %v", err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_AppendMigration_EmptyTwice(t *testing.T) {
+	const appliedSQL = `SELECT COUNT(*) FROM applied_migrations WHERE id = ?`
+	const recordSQL = `INSERT INTO applied_migrations(id) VALUES(?)`
+
+	r, mock, db := newMockRepoEq(t)
+	defer db.Close()
+
+	// The first run changes no config but is still recorded...
+	mock.ExpectBegin()
+	mock.ExpectQuery(appliedSQL).WithArgs("m1").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(recordSQL).WithArgs("m1").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	// ...so the second one is refused.
+	mock.ExpectBegin()
+	mock.ExpectQuery(appliedSQL).WithArgs("m1").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	got, err := r.AppendMigration(context.Background(), "m1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = r.AppendMigration(context.Background(), "m1", nil)
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -7,7 +7,7 @@ import (
 
 func (r *repo) ByVersion(ctx context.Context, name string, version int) (model.RemoteConfig, error) {
 	const q = `
-		SELECT name, type, version, data, created_at
+		SELECT ` + configColumns + `
 		FROM configs
 		WHERE name = ? AND version = ?
 		LIMIT 1
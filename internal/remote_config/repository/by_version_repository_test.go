@@ -32,7 +32,7 @@ func Test_ByVersion(t *testing.T) {
 			cfgName: "missing",
 			version: 9,
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(`SELECT name, type, version, data, created_at
+				m.ExpectQuery(`SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id
 		FROM configs
 		WHERE name = ? AND version = ?
 		LIMIT 1`).WithArgs("missing", 9).
@@ -45,9 +45,9 @@ func Test_ByVersion(t *testing.T) {
 			cfgName: "key",
 			version: 2,
 			mockFunc: func(m sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at"}).
-					AddRow("key", "feature_toggle", 2, `{"on":true}`, "2025-10-01T00:00:00Z")
-				m.ExpectQuery(`SELECT name, type, version, data, created_at
+				rows := sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}).
+					AddRow("key", "feature_toggle", 2, `{"on":true}`, "2025-10-01T00:00:00Z", nil, nil, 1, nil)
+				m.ExpectQuery(`SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id
 		FROM configs
 		WHERE name = ? AND version = ?
 		LIMIT 1`).WithArgs("key", 2).
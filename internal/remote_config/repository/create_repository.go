@@ -3,21 +3,42 @@ package repository
 import (
 	"configuration-management-service/internal/remote_config/model"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 )
 
-func (r *repo) Create(ctx context.Context, schemaType, name string, data json.RawMessage) (model.RemoteConfig, error) {
+// Create writes version 1 of name. An active experiment's layer range is
+// checked in the same transaction, so two creates cannot both take it.
+func (r *repo) Create(ctx context.Context, schemaType, name string, data json.RawMessage, meta model.VersionMeta) (model.RemoteConfig, error) {
 	const q = `
-		INSERT INTO configs(name, type, version, data)
-		VALUES(?, ?, 1, ?)
+		INSERT INTO configs(name, type, version, data, extends, base_version, schema_version, layer_name, layer_start, layer_end)
+		VALUES(?, ?, 1, ?, ?, ?, ?, ?, ?, ?)
 	`
-	_, err := r.db.ExecContext(ctx, q, name, schemaType, string(data))
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return model.RemoteConfig{}, fmt.Errorf("create.begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := checkLayer(ctx, tx, name, meta.Layer); err != nil {
+		return model.RemoteConfig{}, err
+	}
+	layerName, layerStart, layerEnd := layerColumns(meta)
+	_, err = tx.ExecContext(ctx, q, name, schemaType, string(data), nullable(meta.Extends), nullable(meta.BaseVersion), nullable(meta.SchemaVersion), layerName, layerStart, layerEnd)
 	if err != nil {
 		if isUniqueViolation(err) {
 			return model.RemoteConfig{}, ErrAlreadyExists
 		}
 		return model.RemoteConfig{}, fmt.Errorf("create: %w", err)
 	}
-	return r.ByVersion(ctx, name, 1)
+	cfg, err := byVersionTx(ctx, tx, name, 1)
+	if err != nil {
+		return model.RemoteConfig{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.RemoteConfig{}, fmt.Errorf("create.commit: %w", err)
+	}
+	return cfg, nil
 }
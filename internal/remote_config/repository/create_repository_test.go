@@ -6,11 +6,18 @@ import (
 	"errors"
 	"testing"
 
+	"configuration-management-service/internal/remote_config/model"
+
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_Create(t *testing.T) {
+	const insertSQL = `INSERT INTO configs(name, type, version, data, extends, base_version, schema_version, layer_name, layer_start, layer_end) VALUES(?, ?, 1, ?, ?, ?, ?, ?, ?, ?)`
+	const layerSQL = `SELECT c.name, c.layer_start, c.layer_end FROM configs c JOIN (SELECT name, MAX(version) AS version FROM configs GROUP BY name) l ON l.name = c.name AND l.version = c.version WHERE c.layer_name = ? AND c.name <> ? AND c.layer_start < ? AND ? < c.layer_end ORDER BY c.name ASC`
+	const readBackSQL = `SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id FROM configs WHERE name = ? AND version = ? LIMIT 1`
+	layer := &model.Layer{Name: "checkout-page", Start: 40, End: 70}
+
 	type exRes struct {
 		err error
 	}
@@ -20,6 +27,7 @@ func Test_Create(t *testing.T) {
 		schemaType string
 		cfgName    string
 		data       json.RawMessage
+		meta       model.VersionMeta
 		mockFunc   func(m sqlmock.Sqlmock)
 		ex         exRes
 	}{
@@ -29,10 +37,11 @@ func Test_Create(t *testing.T) {
 			cfgName:    "dup",
 			data:       json.RawMessage(`{}`),
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectExec(`INSERT INTO configs(name, type, version, data)
-		VALUES(?, ?, 1, ?)`).
-					WithArgs("dup", "feature_toggle", "{}").
+				m.ExpectBegin()
+				m.ExpectExec(insertSQL).
+					WithArgs("dup", "feature_toggle", "{}", nil, nil, nil, nil, nil, nil).
 					WillReturnError(errors.New("UNIQUE constraint failed: configs.name"))
+				m.ExpectRollback()
 			},
 			ex: exRes{err: ErrAlreadyExists},
 		},
@@ -42,31 +51,63 @@ func Test_Create(t *testing.T) {
 			cfgName:    "x",
 			data:       json.RawMessage(`{}`),
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectExec(`INSERT INTO configs(name, type, version, data)
-		VALUES(?, ?, 1, ?)`).
-					WithArgs("x", "feature_toggle", "{}").
+				m.ExpectBegin()
+				m.ExpectExec(insertSQL).
+					WithArgs("x", "feature_toggle", "{}", nil, nil, nil, nil, nil, nil).
 					WillReturnError(errors.New("boom"))
+				m.ExpectRollback()
 			},
 			ex: exRes{err: errors.New("boom")},
 		},
+		{
+			name:       "when layer range is held should return LayerOverlapError",
+			schemaType: "experiment_config",
+			cfgName:    "checkout-copy",
+			data:       json.RawMessage(`{"active":true}`),
+			meta:       model.VersionMeta{Layer: layer},
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(layerSQL).WithArgs("checkout-page", "checkout-copy", 70.0, 40.0).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "layer_start", "layer_end"}).AddRow("checkout-button", 0.0, 50.0))
+				m.ExpectRollback()
+			},
+			ex: exRes{err: &LayerOverlapError{Name: "checkout-copy", Layer: *layer, Overlaps: []LayerOverlap{
+				{Name: "checkout-button", Layer: model.Layer{Name: "checkout-page", Start: 0, End: 50}},
+			}}},
+		},
+		{
+			name:       "when layer range is free should store it with the version",
+			schemaType: "experiment_config",
+			cfgName:    "checkout-copy",
+			data:       json.RawMessage(`{"active":true}`),
+			meta:       model.VersionMeta{SchemaVersion: 1, Layer: layer},
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(layerSQL).WithArgs("checkout-page", "checkout-copy", 70.0, 40.0).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "layer_start", "layer_end"}))
+				m.ExpectExec(insertSQL).
+					WithArgs("checkout-copy", "experiment_config", `{"active":true}`, nil, nil, 1, "checkout-page", 40.0, 70.0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery(readBackSQL).WithArgs("checkout-copy", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}).
+						AddRow("checkout-copy", "experiment_config", 1, `{"active":true}`, "2025-10-01T00:00:00Z", nil, nil, 1, nil))
+				m.ExpectCommit()
+			},
+		},
 		{
 			name:       "when success",
 			schemaType: "feature_toggle",
 			cfgName:    "qris",
 			data:       json.RawMessage(`{"enabled":true}`),
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectExec(`INSERT INTO configs(name, type, version, data)
-		VALUES(?, ?, 1, ?)`).
-					WithArgs("qris", "feature_toggle", `{"enabled":true}`).
+				m.ExpectBegin()
+				m.ExpectExec(insertSQL).
+					WithArgs("qris", "feature_toggle", `{"enabled":true}`, nil, nil, nil, nil, nil, nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
-
-				rows := sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at"}).
-					AddRow("qris", "feature_toggle", 1, `{"enabled":true}`, "2025-10-01T00:00:00Z")
-				m.ExpectQuery(`SELECT name, type, version, data, created_at
-		FROM configs
-		WHERE name = ? AND version = ?
-		LIMIT 1`).WithArgs("qris", 1).
-					WillReturnRows(rows)
+				m.ExpectQuery(readBackSQL).WithArgs("qris", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}).
+						AddRow("qris", "feature_toggle", 1, `{"enabled":true}`, "2025-10-01T00:00:00Z", nil, nil, 1, nil))
+				m.ExpectCommit()
 			},
 			ex: exRes{err: nil},
 		},
@@ -78,11 +119,15 @@ func Test_Create(t *testing.T) {
 			defer db.Close()
 
 			tc.mockFunc(mock)
-			_, err := r.Create(context.Background(), tc.schemaType, tc.cfgName, tc.data)
+			_, err := r.Create(context.Background(), tc.schemaType, tc.cfgName, tc.data, tc.meta)
 
-			if tc.ex.err == nil {
+			var lerr *LayerOverlapError
+			switch {
+			case tc.ex.err == nil:
 				assert.NoError(t, err)
-			} else {
+			case errors.As(tc.ex.err, &lerr):
+				assert.Equal(t, tc.ex.err, err)
+			default:
 				assert.Error(t, err)
 				if errors.Is(tc.ex.err, ErrAlreadyExists) {
 					assert.ErrorIs(t, err, ErrAlreadyExists)
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Dependents(t *testing.T) {
+	type exRes struct {
+		count int
+		err   error
+	}
+
+	const q = `SELECT c.name, c.type, c.version, c.data, c.created_at, c.extends, c.base_version, c.schema_version, c.migration_id FROM configs c JOIN (SELECT name, MAX(version) AS version FROM configs GROUP BY name) l ON l.name = c.name AND l.version = c.version WHERE c.extends = ? ORDER BY c.name ASC`
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		ex       exRes
+	}{
+		{
+			name: "when query error should return error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("base").WillReturnError(errors.New("query err"))
+			},
+			ex: exRes{err: errors.New("query err")},
+		},
+		{
+			name: "when success should return dependents",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("base").WillReturnRows(
+					sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}).
+						AddRow("eu", "service_client", 4, `{}`, "2025-10-01T00:00:00Z", "base", 2, 1, nil))
+			},
+			ex: exRes{count: 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.Dependents(context.Background(), "base")
+			if tc.ex.err != nil {
+				assert.EqualError(t, err, tc.ex.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, got, tc.ex.count)
+				assert.Equal(t, "base", got[0].Extends)
+				assert.Equal(t, 2, got[0].BaseVersion)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"context"
+)
+
+// LatestByType returns the latest version of every config of schemaType.
+func (r *repo) LatestByType(ctx context.Context, schemaType string) ([]model.RemoteConfig, error) {
+	const q = `
+		SELECT c.name, c.type, c.version, c.data, c.created_at, c.extends, c.base_version, c.schema_version, c.migration_id
+		FROM configs c
+		JOIN (SELECT name, MAX(version) AS version FROM configs GROUP BY name) l
+		  ON l.name = c.name AND l.version = c.version
+		WHERE c.type = ?
+		ORDER BY c.name ASC
+	`
+	rows, err := r.db.QueryContext(ctx, q, schemaType)
+	if err != nil {
+		return nil, err
+	}
+	return scanConfigs(rows)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"context"
+	"strings"
+)
+
+// LatestByTypes returns the latest version of every config of the given
+// types in one statement, so the set is consistent even while configs are
+// being written.
+func (r *repo) LatestByTypes(ctx context.Context, types []string) ([]model.RemoteConfig, error) {
+	if len(types) == 0 {
+		return nil, nil
+	}
+	q := `
+		SELECT c.name, c.type, c.version, c.data, c.created_at, c.extends, c.base_version, c.schema_version, c.migration_id
+		FROM configs c
+		JOIN (SELECT name, MAX(version) AS version FROM configs GROUP BY name) l
+		  ON l.name = c.name AND l.version = c.version
+		WHERE c.type IN (` + strings.TrimSuffix(strings.Repeat("?, ", len(types)), ", ") + `)
+		ORDER BY c.name ASC
+	`
+	args := make([]any, len(types))
+	for i, t := range types {
+		args[i] = t
+	}
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanConfigs(rows)
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LatestByTypes(t *testing.T) {
+	const q = `SELECT c.name, c.type, c.version, c.data, c.created_at, c.extends, c.base_version, c.schema_version, c.migration_id FROM configs c JOIN (SELECT name, MAX(version) AS version FROM configs GROUP BY name) l ON l.name = c.name AND l.version = c.version WHERE c.type IN (?, ?) ORDER BY c.name ASC`
+
+	cases := []struct {
+		name     string
+		types    []string
+		mockFunc func(m sqlmock.Sqlmock)
+		count    int
+		err      bool
+	}{
+		{
+			name:     "when no type is asked should not query",
+			mockFunc: func(m sqlmock.Sqlmock) {},
+		},
+		{
+			name:  "when query error should return error",
+			types: []string{"feature_toggle", "experiment_config"},
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("feature_toggle", "experiment_config").WillReturnError(errors.New("query err"))
+			},
+			err: true,
+		},
+		{
+			name:  "when success should return latest version of each config of every type",
+			types: []string{"feature_toggle", "experiment_config"},
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("feature_toggle", "experiment_config").WillReturnRows(
+					sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}).
+						AddRow("a", "feature_toggle", 3, `{"enabled":true}`, "2025-10-01T00:00:00Z", nil, nil, 1, nil).
+						AddRow("b", "experiment_config", 1, `{"active":false}`, "2025-10-01T00:00:00Z", nil, nil, 1, nil))
+			},
+			count: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.LatestByTypes(context.Background(), tc.types)
+
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, got, tc.count)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 
 func (r *repo) Latest(ctx context.Context, name string) (model.RemoteConfig, error) {
 	const q = `
-		SELECT name, type, version, data, created_at
+		SELECT ` + configColumns + `
 		FROM configs
 		WHERE name = ?
 		ORDER BY version DESC
@@ -22,7 +22,7 @@ func Test_Latest(t *testing.T) {
 			name:    "when not found should return ErrNotFound",
 			cfgName: "none",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(`SELECT name, type, version, data, created_at
+				m.ExpectQuery(`SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id
 		FROM configs
 		WHERE name = ?
 		ORDER BY version DESC
@@ -35,9 +35,9 @@ func Test_Latest(t *testing.T) {
 			name:    "when success",
 			cfgName: "key",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at"}).
-					AddRow("key", "feature_toggle", 7, `{"on":false}`, "2025-10-01T00:00:00Z")
-				m.ExpectQuery(`SELECT name, type, version, data, created_at
+				rows := sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}).
+					AddRow("key", "feature_toggle", 7, `{"on":false}`, "2025-10-01T00:00:00Z", nil, nil, 1, nil)
+				m.ExpectQuery(`SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id
 		FROM configs
 		WHERE name = ?
 		ORDER BY version DESC
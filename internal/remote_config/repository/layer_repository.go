@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// LayerOverlapError rejects a version whose layer range overlaps the range
+// the latest version of another config holds in the same layer.
+type LayerOverlapError struct {
+	Name     string
+	Layer    model.Layer
+	Overlaps []LayerOverlap
+}
+
+// LayerOverlap is a config holding part of a range.
+type LayerOverlap struct {
+	Name  string
+	Layer model.Layer
+}
+
+func (e *LayerOverlapError) Error() string {
+	names := make([]string, 0, len(e.Overlaps))
+	for _, o := range e.Overlaps {
+		names = append(names, o.Name)
+	}
+	return fmt.Sprintf("%s: layer %q overlaps %s", e.Name, e.Layer.Name, strings.Join(names, ", "))
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// LayerOverlaps returns the configs other than name whose latest version
+// holds part of layer, by name.
+func (r *repo) LayerOverlaps(ctx context.Context, name string, layer model.Layer) ([]LayerOverlap, error) {
+	return layerOverlaps(ctx, r.db, name, layer)
+}
+
+func layerOverlaps(ctx context.Context, q querier, name string, layer model.Layer) ([]LayerOverlap, error) {
+	const qSel = `
+		SELECT c.name, c.layer_start, c.layer_end
+		FROM configs c
+		JOIN (SELECT name, MAX(version) AS version FROM configs GROUP BY name) l
+		  ON l.name = c.name AND l.version = c.version
+		WHERE c.layer_name = ? AND c.name <> ? AND c.layer_start < ? AND ? < c.layer_end
+		ORDER BY c.name ASC
+	`
+	rows, err := q.QueryContext(ctx, qSel, layer.Name, name, layer.End, layer.Start)
+	if err != nil {
+		return nil, fmt.Errorf("layer.select: %w", err)
+	}
+	defer rows.Close()
+	var out []LayerOverlap
+	for rows.Next() {
+		o := LayerOverlap{Layer: model.Layer{Name: layer.Name}}
+		if err := rows.Scan(&o.Name, &o.Layer.Start, &o.Layer.End); err != nil {
+			return nil, fmt.Errorf("layer.scan: %w", err)
+		}
+		out = append(out, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("layer.select: %w", err)
+	}
+	return out, nil
+}
+
+// checkLayer fails with a *LayerOverlapError when layer, the range the new
+// version of name takes, is held by another config. Run in the transaction
+// that writes the version, so concurrent writes cannot both take a range.
+func checkLayer(ctx context.Context, tx *sql.Tx, name string, layer *model.Layer) error {
+	if layer == nil {
+		return nil
+	}
+	overlaps, err := layerOverlaps(ctx, tx, name, *layer)
+	if err != nil {
+		return err
+	}
+	if len(overlaps) > 0 {
+		return &LayerOverlapError{Name: name, Layer: *layer, Overlaps: overlaps}
+	}
+	return nil
+}
+
+// layerColumns maps the layer of meta to the layer_name, layer_start and
+// layer_end columns.
+func layerColumns(meta model.VersionMeta) (any, any, any) {
+	if meta.Layer == nil {
+		return nil, nil, nil
+	}
+	return meta.Layer.Name, meta.Layer.Start, meta.Layer.End
+}
@@ -3,12 +3,11 @@ package repository
 import (
 	"configuration-management-service/internal/remote_config/model"
 	"context"
-	"encoding/json"
 )
 
 func (r *repo) List(ctx context.Context, name string) ([]model.RemoteConfig, error) {
 	const q = `
-		SELECT name, type, version, data, created_at
+		SELECT ` + configColumns + `
 		FROM configs
 		WHERE name = ?
 		ORDER BY version ASC
@@ -21,12 +20,10 @@ func (r *repo) List(ctx context.Context, name string) ([]model.RemoteConfig, err
 
 	var out []model.RemoteConfig
 	for rows.Next() {
-		var cfg model.RemoteConfig
-		var dataStr string
-		if err := rows.Scan(&cfg.Name, &cfg.Type, &cfg.Version, &dataStr, &cfg.CreatedAt); err != nil {
+		cfg, err := scanConfig(rows)
+		if err != nil {
 			return nil, err
 		}
-		cfg.Data = json.RawMessage(dataStr)
 		out = append(out, cfg)
 	}
 	if err := rows.Err(); err != nil {
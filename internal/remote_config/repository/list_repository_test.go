@@ -25,7 +25,7 @@ func Test_List(t *testing.T) {
 			name:    "when query error should return error",
 			cfgName: "key",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(`SELECT name, type, version, data, created_at
+				m.ExpectQuery(`SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id
 		FROM configs
 		WHERE name = ?
 		ORDER BY version ASC`).WithArgs("key").
@@ -37,8 +37,8 @@ func Test_List(t *testing.T) {
 			name:    "when success empty should return empty",
 			cfgName: "key",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at"})
-				m.ExpectQuery(`SELECT name, type, version, data, created_at
+				rows := sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"})
+				m.ExpectQuery(`SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id
 		FROM configs
 		WHERE name = ?
 		ORDER BY version ASC`).WithArgs("key").
@@ -50,10 +50,10 @@ func Test_List(t *testing.T) {
 			name:    "when success with rows should return rows",
 			cfgName: "key",
 			mockFunc: func(m sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at"}).
-					AddRow("key", "feature_toggle", 1, `{"on":true}`, "2025-10-01T00:00:00Z").
-					AddRow("key", "feature_toggle", 2, `{"on":false}`, "2025-10-01T00:01:00Z")
-				m.ExpectQuery(`SELECT name, type, version, data, created_at
+				rows := sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}).
+					AddRow("key", "feature_toggle", 1, `{"on":true}`, "2025-10-01T00:00:00Z", nil, nil, 1, nil).
+					AddRow("key", "feature_toggle", 2, `{"on":false}`, "2025-10-01T00:01:00Z", nil, nil, 1, nil)
+				m.ExpectQuery(`SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id
 		FROM configs
 		WHERE name = ?
 		ORDER BY version ASC`).WithArgs("key").
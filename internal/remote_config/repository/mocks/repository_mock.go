@@ -6,6 +6,7 @@ package mocks
 
 import (
 	model "configuration-management-service/internal/remote_config/model"
+	repository "configuration-management-service/internal/remote_config/repository"
 	context "context"
 	json "encoding/json"
 	reflect "reflect"
@@ -36,19 +37,34 @@ func (m *MockIRepo) EXPECT() *MockIRepoMockRecorder {
 	return m.recorder
 }
 
-// Append mocks base method.
-func (m *MockIRepo) Append(ctx context.Context, name string, data json.RawMessage) (model.RemoteConfig, error) {
+// AppendBatch mocks base method.
+func (m *MockIRepo) AppendBatch(ctx context.Context, items []model.MigrationItem) ([]model.RemoteConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Append", ctx, name, data)
-	ret0, _ := ret[0].(model.RemoteConfig)
+	ret := m.ctrl.Call(m, "AppendBatch", ctx, items)
+	ret0, _ := ret[0].([]model.RemoteConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendBatch indicates an expected call of AppendBatch.
+func (mr *MockIRepoMockRecorder) AppendBatch(ctx, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendBatch", reflect.TypeOf((*MockIRepo)(nil).AppendBatch), ctx, items)
+}
+
+// AppendMigration mocks base method.
+func (m *MockIRepo) AppendMigration(ctx context.Context, migrationID string, items []model.MigrationItem) ([]model.RemoteConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendMigration", ctx, migrationID, items)
+	ret0, _ := ret[0].([]model.RemoteConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Append indicates an expected call of Append.
-func (mr *MockIRepoMockRecorder) Append(ctx, name, data interface{}) *gomock.Call {
+// AppendMigration indicates an expected call of AppendMigration.
+func (mr *MockIRepoMockRecorder) AppendMigration(ctx, migrationID, items interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockIRepo)(nil).Append), ctx, name, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendMigration", reflect.TypeOf((*MockIRepo)(nil).AppendMigration), ctx, migrationID, items)
 }
 
 // ByVersion mocks base method.
@@ -67,18 +83,33 @@ func (mr *MockIRepoMockRecorder) ByVersion(ctx, name, version interface{}) *gomo
 }
 
 // Create mocks base method.
-func (m *MockIRepo) Create(ctx context.Context, schemaType, name string, data json.RawMessage) (model.RemoteConfig, error) {
+func (m *MockIRepo) Create(ctx context.Context, schemaType, name string, data json.RawMessage, meta model.VersionMeta) (model.RemoteConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, schemaType, name, data)
+	ret := m.ctrl.Call(m, "Create", ctx, schemaType, name, data, meta)
 	ret0, _ := ret[0].(model.RemoteConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIRepoMockRecorder) Create(ctx, schemaType, name, data interface{}) *gomock.Call {
+func (mr *MockIRepoMockRecorder) Create(ctx, schemaType, name, data, meta interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRepo)(nil).Create), ctx, schemaType, name, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRepo)(nil).Create), ctx, schemaType, name, data, meta)
+}
+
+// Dependents mocks base method.
+func (m *MockIRepo) Dependents(ctx context.Context, base string) ([]model.RemoteConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dependents", ctx, base)
+	ret0, _ := ret[0].([]model.RemoteConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dependents indicates an expected call of Dependents.
+func (mr *MockIRepoMockRecorder) Dependents(ctx, base interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dependents", reflect.TypeOf((*MockIRepo)(nil).Dependents), ctx, base)
 }
 
 // Latest mocks base method.
@@ -96,6 +127,51 @@ func (mr *MockIRepoMockRecorder) Latest(ctx, name interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockIRepo)(nil).Latest), ctx, name)
 }
 
+// LatestByType mocks base method.
+func (m *MockIRepo) LatestByType(ctx context.Context, schemaType string) ([]model.RemoteConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestByType", ctx, schemaType)
+	ret0, _ := ret[0].([]model.RemoteConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestByType indicates an expected call of LatestByType.
+func (mr *MockIRepoMockRecorder) LatestByType(ctx, schemaType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestByType", reflect.TypeOf((*MockIRepo)(nil).LatestByType), ctx, schemaType)
+}
+
+// LatestByTypes mocks base method.
+func (m *MockIRepo) LatestByTypes(ctx context.Context, types []string) ([]model.RemoteConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestByTypes", ctx, types)
+	ret0, _ := ret[0].([]model.RemoteConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestByTypes indicates an expected call of LatestByTypes.
+func (mr *MockIRepoMockRecorder) LatestByTypes(ctx, types interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestByTypes", reflect.TypeOf((*MockIRepo)(nil).LatestByTypes), ctx, types)
+}
+
+// LayerOverlaps mocks base method.
+func (m *MockIRepo) LayerOverlaps(ctx context.Context, name string, layer model.Layer) ([]repository.LayerOverlap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LayerOverlaps", ctx, name, layer)
+	ret0, _ := ret[0].([]repository.LayerOverlap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LayerOverlaps indicates an expected call of LayerOverlaps.
+func (mr *MockIRepoMockRecorder) LayerOverlaps(ctx, name, layer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LayerOverlaps", reflect.TypeOf((*MockIRepo)(nil).LayerOverlaps), ctx, name, layer)
+}
+
 // List mocks base method.
 func (m *MockIRepo) List(ctx context.Context, name string) ([]model.RemoteConfig, error) {
 	m.ctrl.T.Helper()
@@ -111,6 +187,21 @@ func (mr *MockIRepoMockRecorder) List(ctx, name interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIRepo)(nil).List), ctx, name)
 }
 
+// Rewrite mocks base method.
+func (m *MockIRepo) Rewrite(ctx context.Context, fn repository.RewriteFunc) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rewrite", ctx, fn)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rewrite indicates an expected call of Rewrite.
+func (mr *MockIRepoMockRecorder) Rewrite(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rewrite", reflect.TypeOf((*MockIRepo)(nil).Rewrite), ctx, fn)
+}
+
 // MockrowScanner is a mock of rowScanner interface.
 type MockrowScanner struct {
 	ctrl     *gomock.Controller
@@ -12,16 +12,26 @@ import (
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
+	ErrConflict      = errors.New("conflict")
 )
 
 type IRepo interface {
-	Create(ctx context.Context, schemaType, name string, data json.RawMessage) (model.RemoteConfig, error)
-	Append(ctx context.Context, name string, data json.RawMessage) (model.RemoteConfig, error)
+	Create(ctx context.Context, schemaType, name string, data json.RawMessage, meta model.VersionMeta) (model.RemoteConfig, error)
 	Latest(ctx context.Context, name string) (model.RemoteConfig, error)
 	ByVersion(ctx context.Context, name string, version int) (model.RemoteConfig, error)
 	List(ctx context.Context, name string) ([]model.RemoteConfig, error)
+	Dependents(ctx context.Context, base string) ([]model.RemoteConfig, error)
+	LatestByType(ctx context.Context, schemaType string) ([]model.RemoteConfig, error)
+	LatestByTypes(ctx context.Context, types []string) ([]model.RemoteConfig, error)
+	Rewrite(ctx context.Context, fn RewriteFunc) (int, error)
+	AppendMigration(ctx context.Context, migrationID string, items []model.MigrationItem) ([]model.RemoteConfig, error)
+	AppendBatch(ctx context.Context, items []model.MigrationItem) ([]model.RemoteConfig, error)
+	LayerOverlaps(ctx context.Context, name string, layer model.Layer) ([]LayerOverlap, error)
 }
 
+// RewriteFunc returns the new stored data for a row and whether it changed.
+type RewriteFunc func(cfg model.RemoteConfig) (json.RawMessage, bool, error)
+
 type repo struct {
 	db *sql.DB
 }
@@ -34,22 +44,56 @@ type rowScanner interface {
 	Scan(dest ...any) error
 }
 
+// configColumns is the column list every scanConfig query selects.
+const configColumns = `name, type, version, data, created_at, extends, base_version, schema_version, migration_id`
+
 func scanConfig(row rowScanner) (model.RemoteConfig, error) {
 	var cfg model.RemoteConfig
 	var dataStr string
-	if err := row.Scan(&cfg.Name, &cfg.Type, &cfg.Version, &dataStr, &cfg.CreatedAt); err != nil {
+	var extends, migrationID sql.NullString
+	var baseVersion, schemaVersion sql.NullInt64
+	if err := row.Scan(&cfg.Name, &cfg.Type, &cfg.Version, &dataStr, &cfg.CreatedAt, &extends, &baseVersion, &schemaVersion, &migrationID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.RemoteConfig{}, ErrNotFound
 		}
 		return model.RemoteConfig{}, err
 	}
 	cfg.Data = json.RawMessage(dataStr)
+	cfg.Extends = extends.String
+	cfg.BaseVersion = int(baseVersion.Int64)
+	cfg.SchemaVersion = int(schemaVersion.Int64)
+	cfg.MigrationID = migrationID.String
 	return cfg, nil
 }
 
+func scanConfigs(rows *sql.Rows) ([]model.RemoteConfig, error) {
+	defer rows.Close()
+	var out []model.RemoteConfig
+	for rows.Next() {
+		cfg, err := scanConfig(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cfg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// nullable maps the zero value of meta fields to SQL NULL.
+func nullable[T comparable](v T) any {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return v
+}
+
 func byVersionTx(ctx context.Context, tx *sql.Tx, name string, version int) (model.RemoteConfig, error) {
 	const q = `
-		SELECT name, type, version, data, created_at
+		SELECT ` + configColumns + `
 		FROM configs
 		WHERE name = ? AND version = ?
 		LIMIT 1
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Rewrite replaces the stored data of every version in place, in one
+// transaction. It is meant for storage-level maintenance such as key
+// rotation, where the logical value of a version does not change.
+func (r *repo) Rewrite(ctx context.Context, fn RewriteFunc) (int, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return 0, fmt.Errorf("rewrite.begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const qSel = `
+		SELECT ` + configColumns + `
+		FROM configs
+		ORDER BY name ASC, version ASC
+	`
+	rows, err := tx.QueryContext(ctx, qSel)
+	if err != nil {
+		return 0, fmt.Errorf("rewrite.select: %w", err)
+	}
+	var all []model.RemoteConfig
+	for rows.Next() {
+		cfg, err := scanConfig(rows)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("rewrite.scan: %w", err)
+		}
+		all = append(all, cfg)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("rewrite.select: %w", err)
+	}
+	rows.Close()
+
+	const qUpd = `
+		UPDATE configs SET data = ?
+		WHERE name = ? AND version = ?
+	`
+	n := 0
+	for _, cfg := range all {
+		data, changed, err := fn(cfg)
+		if err != nil {
+			return 0, fmt.Errorf("rewrite %s@%d: %w", cfg.Name, cfg.Version, err)
+		}
+		if !changed {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, qUpd, string(data), cfg.Name, cfg.Version); err != nil {
+			return 0, fmt.Errorf("rewrite.update: %w", err)
+		}
+		n++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("rewrite.commit: %w", err)
+	}
+	return n, nil
+}
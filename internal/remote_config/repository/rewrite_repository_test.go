@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Rewrite(t *testing.T) {
+	type exRes struct {
+		n   int
+		err error
+	}
+
+	const selectAllSQL = `SELECT name, type, version, data, created_at, extends, base_version, schema_version, migration_id FROM configs ORDER BY name ASC, version ASC`
+	const updateSQL = `UPDATE configs SET data = ? WHERE name = ? AND version = ?`
+
+	// rewrites only version 2 of "a"
+	fn := func(cfg model.RemoteConfig) (json.RawMessage, bool, error) {
+		if cfg.Name == "a" && cfg.Version == 2 {
+			return json.RawMessage(`{"v":"new"}`), true, nil
+		}
+		return cfg.Data, false, nil
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		ex       exRes
+	}{
+		{
+			name: "when select error should return error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(selectAllSQL).WillReturnError(errors.New("boom"))
+				m.ExpectRollback()
+			},
+			ex: exRes{err: errors.New("rewrite.select: boom")},
+		},
+		{
+			name: "when success should update only changed rows",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(selectAllSQL).WillReturnRows(
+					sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}).
+						AddRow("a", "service_client", 1, `{"v":"old"}`, "t1", nil, nil, 1, nil).
+						AddRow("a", "service_client", 2, `{"v":"old"}`, "t2", nil, nil, 1, nil))
+				m.ExpectExec(updateSQL).WithArgs(`{"v":"new"}`, "a", 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			ex: exRes{n: 1},
+		},
+		{
+			name: "when update error should rollback",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(selectAllSQL).WillReturnRows(
+					sqlmock.NewRows([]string{"name", "type", "version", "data", "created_at", "extends", "base_version", "schema_version", "migration_id"}).
+						AddRow("a", "service_client", 2, `{"v":"old"}`, "t2", nil, nil, 1, nil))
+				m.ExpectExec(updateSQL).WithArgs(`{"v":"new"}`, "a", 2).WillReturnError(errors.New("locked"))
+				m.ExpectRollback()
+			},
+			ex: exRes{err: errors.New("rewrite.update: locked")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			n, err := r.Rewrite(context.Background(), fn)
+
+			if tc.ex.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.ex.err.Error())
+			}
+			assert.Equal(t, tc.ex.n, n)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package secretref
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SecretProvider looks up the value behind a secret reference path such as
+// "payments/api_token". ok is false when the provider does not know the path.
+type SecretProvider interface {
+	Name() string
+	Lookup(ctx context.Context, path string) (value string, ok bool, err error)
+}
+
+var errOutsideRoot = errors.New("secret path escapes provider root")
+
+// FileProvider reads each secret from a file below Dir, e.g. a mounted
+// Kubernetes or Docker secrets directory. One trailing newline is trimmed.
+type FileProvider struct {
+	Dir string
+}
+
+func NewFileProvider(dir string) SecretProvider {
+	return FileProvider{Dir: dir}
+}
+
+func (p FileProvider) Name() string { return "file" }
+
+func (p FileProvider) Lookup(_ context.Context, path string) (string, bool, error) {
+	clean := filepath.Clean("/" + path)
+	full := filepath.Join(p.Dir, clean)
+	if !strings.HasPrefix(full, filepath.Clean(p.Dir)+string(filepath.Separator)) {
+		return "", false, errOutsideRoot
+	}
+	b, err := os.ReadFile(full)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(b), "\n"), "\r"), true, nil
+}
+
+// EnvProvider maps a path to an environment variable: "payments/api_token"
+// with prefix "SECRET_" becomes SECRET_PAYMENTS_API_TOKEN.
+type EnvProvider struct {
+	Prefix string
+}
+
+func NewEnvProvider(prefix string) SecretProvider {
+	return EnvProvider{Prefix: prefix}
+}
+
+func (p EnvProvider) Name() string { return "env" }
+
+func (p EnvProvider) Lookup(_ context.Context, path string) (string, bool, error) {
+	v, ok := os.LookupEnv(p.envName(path))
+	return v, ok, nil
+}
+
+func (p EnvProvider) envName(path string) string {
+	r := strings.NewReplacer("/", "_", "-", "_", ".", "_")
+	return p.Prefix + strings.ToUpper(r.Replace(path))
+}
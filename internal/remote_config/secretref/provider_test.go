@@ -0,0 +1,55 @@
+package secretref
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileProvider_Lookup(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "payments"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "payments", "api_token"), []byte("tok-123\n"), 0o600))
+	p := NewFileProvider(dir)
+
+	cases := []struct {
+		name    string
+		path    string
+		want    string
+		ok      bool
+		wantErr bool
+	}{
+		{name: "when file exists should return value without trailing newline", path: "payments/api_token", want: "tok-123", ok: true},
+		{name: "when file missing should return not ok", path: "payments/missing", ok: false},
+		{name: "when path escapes dir should stay inside root", path: "../../etc/passwd", ok: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok, err := p.Lookup(context.Background(), tc.path)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEnvProvider_Lookup(t *testing.T) {
+	t.Setenv("SECRET_PAYMENTS_API_TOKEN", "env-tok")
+	p := NewEnvProvider("SECRET_")
+
+	got, ok, err := p.Lookup(context.Background(), "payments/api-token")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "env-tok", got)
+
+	_, ok, err = p.Lookup(context.Background(), "payments/other")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,133 @@
+package secretref
+
+import (
+	"configuration-management-service/pkg/jsonx"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+)
+
+// refPattern matches ${secret:<path>} anywhere inside a string value.
+var refPattern = regexp.MustCompile(`\$\{secret:([A-Za-z0-9_./-]+)\}`)
+
+// Resolution records where one reference was found and which provider served it.
+type Resolution struct {
+	Ref      string `json:"ref"`
+	Provider string `json:"provider,omitempty"`
+	Resolved bool   `json:"resolved"`
+}
+
+// IResolver replaces secret references in config data with provider values.
+type IResolver interface {
+	Resolve(ctx context.Context, data json.RawMessage) (json.RawMessage, []Resolution, error)
+	Unresolved(ctx context.Context, data json.RawMessage) ([]string, error)
+}
+
+type resolver struct {
+	providers []SecretProvider
+}
+
+// NewResolver asks providers in order; the first one that knows a path wins.
+func NewResolver(providers ...SecretProvider) IResolver {
+	return resolver{providers: providers}
+}
+
+func (r resolver) Resolve(ctx context.Context, data json.RawMessage) (json.RawMessage, []Resolution, error) {
+	if !refPattern.Match(data) {
+		return data, nil, nil
+	}
+	doc, err := jsonx.Decode(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var res []Resolution
+	var lookupErr error
+	doc = rewrite(doc, func(s string) string {
+		return refPattern.ReplaceAllStringFunc(s, func(m string) string {
+			path := refPattern.FindStringSubmatch(m)[1]
+			val, provider, ok, err := r.lookup(ctx, path)
+			if err != nil && lookupErr == nil {
+				lookupErr = fmt.Errorf("secret %s: %w", path, err)
+			}
+			res = append(res, Resolution{Ref: path, Provider: provider, Resolved: ok})
+			if !ok {
+				return m
+			}
+			return val
+		})
+	})
+	if lookupErr != nil {
+		return nil, nil, lookupErr
+	}
+	out, err := jsonx.Encode(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, res, nil
+}
+
+// Unresolved lists every reference in data that no provider can serve.
+func (r resolver) Unresolved(ctx context.Context, data json.RawMessage) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	for _, m := range refPattern.FindAllSubmatch(data, -1) {
+		path := string(m[1])
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		_, _, ok, err := r.lookup(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("secret %s: %w", path, err)
+		}
+		if !ok {
+			out = append(out, path)
+		}
+	}
+	return out, nil
+}
+
+func (r resolver) lookup(ctx context.Context, path string) (string, string, bool, error) {
+	for _, p := range r.providers {
+		v, ok, err := p.Lookup(ctx, path)
+		if err != nil {
+			return "", p.Name(), false, err
+		}
+		if ok {
+			return v, p.Name(), true, nil
+		}
+	}
+	return "", "", false, nil
+}
+
+// Audit writes one log line per resolved reference. Values are never logged.
+func Audit(logger *log.Logger, name string, version int, res []Resolution) {
+	if logger == nil {
+		logger = log.Default()
+	}
+	for _, r := range res {
+		logger.Printf("audit: secret_resolve config=%s version=%d ref=%s provider=%s resolved=%t",
+			name, version, r.Ref, r.Provider, r.Resolved)
+	}
+}
+
+func rewrite(v any, fn func(string) string) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			t[k] = rewrite(child, fn)
+		}
+		return t
+	case []any:
+		for i, child := range t {
+			t[i] = rewrite(child, fn)
+		}
+		return t
+	case string:
+		return fn(t)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,79 @@
+package secretref
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mapProvider struct {
+	name string
+	vals map[string]string
+	err  error
+}
+
+func (m mapProvider) Name() string { return m.name }
+
+func (m mapProvider) Lookup(_ context.Context, path string) (string, bool, error) {
+	if m.err != nil {
+		return "", false, m.err
+	}
+	v, ok := m.vals[path]
+	return v, ok, nil
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	r := NewResolver(
+		mapProvider{name: "file", vals: map[string]string{"payments/api_token": "tok"}},
+		mapProvider{name: "env", vals: map[string]string{"payments/api_token": "shadowed", "db/pass": "pw"}},
+	)
+
+	cases := []struct {
+		name string
+		in   string
+		out  string
+		res  []Resolution
+	}{
+		{
+			name: "when no references should return data untouched",
+			in:   `{"b":1,"a":"x"}`,
+			out:  `{"b":1,"a":"x"}`,
+		},
+		{
+			name: "when references present should replace them, first provider wins",
+			in:   `{"headers":{"Authorization":"Bearer ${secret:payments/api_token}"},"list":["${secret:db/pass}"]}`,
+			out:  `{"headers":{"Authorization":"Bearer tok"},"list":["pw"]}`,
+		},
+		{
+			name: "when reference unknown should leave it in place",
+			in:   `{"a":"${secret:nope}"}`,
+			out:  `{"a":"${secret:nope}"}`,
+			res:  []Resolution{{Ref: "nope", Resolved: false}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, res, err := r.Resolve(context.Background(), json.RawMessage(tc.in))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.out, string(got))
+			if tc.res != nil {
+				assert.Equal(t, tc.res, res)
+			}
+		})
+	}
+}
+
+func TestResolver_Unresolved(t *testing.T) {
+	r := NewResolver(mapProvider{name: "env", vals: map[string]string{"known": "v"}})
+
+	got, err := r.Unresolved(context.Background(), json.RawMessage(`{"a":"${secret:known}","b":"${secret:missing}","c":"${secret:missing}"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"missing"}, got)
+
+	failing := NewResolver(mapProvider{name: "env", err: errors.New("boom")})
+	_, err = failing.Unresolved(context.Background(), json.RawMessage(`{"a":"${secret:x}"}`))
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"configuration-management-service/internal/remote_config/model"
 	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/pkg/jsonx"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,21 +11,19 @@ import (
 	"strings"
 )
 
-func (s service) Create(ctx context.Context, schemaType, name string, data json.RawMessage) (model.RemoteConfig, error) {
-	schemaType = strings.TrimSpace(schemaType)
+func (s service) Create(ctx context.Context, schemaType, name string, data json.RawMessage, extends string) (model.RemoteConfig, error) {
 	name = strings.TrimSpace(name)
-	if schemaType == "" || name == "" {
-		return model.RemoteConfig{}, ErrInvalidInput
-	}
-	if len(data) == 0 {
-		return model.RemoteConfig{}, fmt.Errorf("%w: empty data", ErrInvalidInput)
+	p, err := s.planCreate(ctx, schemaType, name, data, extends)
+	if err != nil {
+		return model.RemoteConfig{}, err
 	}
 
-	if err := s.validator.Validate(schemaType, data); err != nil {
-		return model.RemoteConfig{}, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	sealed, err := s.seal(ctx, p.schemaType, p.meta.SchemaVersion, name, p.data, nil)
+	if err != nil {
+		return model.RemoteConfig{}, err
 	}
 
-	cfg, err := s.repo.Create(ctx, schemaType, name, data)
+	cfg, err := s.repo.Create(ctx, p.schemaType, name, sealed, p.meta)
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrAlreadyExists):
@@ -32,8 +31,56 @@ func (s service) Create(ctx context.Context, schemaType, name string, data json.
 		case errors.Is(err, repository.ErrNotFound):
 			return model.RemoteConfig{}, ErrNotFound
 		default:
-			return model.RemoteConfig{}, err
+			return model.RemoteConfig{}, layerError(name, err)
 		}
 	}
-	return cfg, nil
+	cfg.Warnings = p.warnings
+	return s.open(ctx, cfg, false)
+}
+
+// planCreate validates the first version of name without storing it.
+func (s service) planCreate(ctx context.Context, schemaType, name string, data json.RawMessage, extends string) (plan, error) {
+	schemaType = strings.TrimSpace(schemaType)
+	extends = strings.TrimSpace(extends)
+	if schemaType == "" || name == "" {
+		return plan{}, ErrInvalidInput
+	}
+	if len(data) == 0 {
+		return plan{}, fmt.Errorf("%w: empty data", ErrInvalidInput)
+	}
+
+	var meta model.VersionMeta
+	effective := data
+	if extends != "" {
+		base, err := s.loadBase(ctx, name, schemaType, extends)
+		if err != nil {
+			return plan{}, err
+		}
+		baseData, err := s.effective(ctx, base, s.revealedLayer(ctx))
+		if err != nil {
+			return plan{}, err
+		}
+		if effective, err = jsonx.MergeRaw(baseData, data); err != nil {
+			return plan{}, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+		}
+		meta = model.VersionMeta{Extends: extends, BaseVersion: base.Version}
+	} else {
+		var err error
+		if data, err = s.writeDefaults(ctx, schemaType, data); err != nil {
+			return plan{}, err
+		}
+		effective = data
+	}
+
+	v, err := s.validateRendered(ctx, schemaType, effective)
+	if err != nil {
+		return plan{}, err
+	}
+	meta.SchemaVersion, meta.Layer = v.schemaVersion, v.layer
+
+	warnings, err := s.secretWarnings(ctx, data)
+	if err != nil {
+		return plan{}, err
+	}
+	return plan{schemaType: schemaType, data: data, meta: meta, warnings: warnings}, nil
 }
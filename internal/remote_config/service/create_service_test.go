@@ -1,6 +1,9 @@
 package service
 
 import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/render"
+	"configuration-management-service/internal/remote_config/secretref"
 	"context"
 	"encoding/json"
 	"testing"
@@ -54,7 +57,7 @@ func Test_service_Create(t *testing.T) {
 			valErr:  nil,
 			mockFunc: func(m *repoMock.MockIRepo) {
 				m.EXPECT().
-					Create(gomock.Any(), "feature_toggle", "x", json.RawMessage(`{"enabled":true}`)).
+					Create(gomock.Any(), "feature_toggle", "x", json.RawMessage(`{"enabled":true}`), model.VersionMeta{}).
 					Return(model.RemoteConfig{}, repository.ErrAlreadyExists)
 			},
 			ex: exRes{res: model.RemoteConfig{}, err: ErrAlreadyExists},
@@ -67,11 +70,32 @@ func Test_service_Create(t *testing.T) {
 			valErr:  nil,
 			mockFunc: func(m *repoMock.MockIRepo) {
 				m.EXPECT().
-					Create(gomock.Any(), "feature_toggle", "y", json.RawMessage(`{}`)).
+					Create(gomock.Any(), "feature_toggle", "y", json.RawMessage(`{}`), model.VersionMeta{}).
 					Return(model.RemoteConfig{}, repository.ErrNotFound)
 			},
 			ex: exRes{res: model.RemoteConfig{}, err: ErrNotFound},
 		},
+		{
+			name:    "when data has unresolved secret reference should return warnings",
+			schema:  "service_client",
+			cfgName: "pay",
+			data:    json.RawMessage(`{"headers":{"Authorization":"${secret:payments/api_token}"}}`),
+			valErr:  nil,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().
+					Create(gomock.Any(), "service_client", "pay", gomock.Any(), model.VersionMeta{}).
+					Return(model.RemoteConfig{Name: "pay", Type: "service_client", Version: 1}, nil)
+			},
+			ex: exRes{
+				res: model.RemoteConfig{
+					Name:     "pay",
+					Type:     "service_client",
+					Version:  1,
+					Warnings: []string{"unresolved secret reference: ${secret:payments/api_token}"},
+				},
+				err: nil,
+			},
+		},
 		{
 			name:    "when success",
 			schema:  "feature_toggle",
@@ -80,7 +104,7 @@ func Test_service_Create(t *testing.T) {
 			valErr:  nil,
 			mockFunc: func(m *repoMock.MockIRepo) {
 				m.EXPECT().
-					Create(gomock.Any(), "feature_toggle", "qris", json.RawMessage(`{"enabled":true}`)).
+					Create(gomock.Any(), "feature_toggle", "qris", json.RawMessage(`{"enabled":true}`), model.VersionMeta{}).
 					Return(model.RemoteConfig{
 						Name:    "qris",
 						Type:    "feature_toggle",
@@ -111,9 +135,12 @@ func Test_service_Create(t *testing.T) {
 			svc := service{
 				repo:      repo,
 				validator: stubValidator{err: tc.valErr},
+				crypter:   fieldcrypt.New(nil),
+				resolver:  secretref.NewResolver(),
+				renderer:  render.NewRenderer(nil),
 			}
 
-			got, err := svc.Create(context.Background(), tc.schema, tc.cfgName, tc.data)
+			got, err := svc.Create(context.Background(), tc.schema, tc.cfgName, tc.data, "")
 
 			if tc.valErr != nil {
 				assert.Error(t, err)
@@ -125,3 +152,163 @@ func Test_service_Create(t *testing.T) {
 		})
 	}
 }
+
+func Test_service_Create_UnknownVariable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repoMock.NewMockIRepo(ctrl)
+
+	svc := service{
+		repo:      repo,
+		validator: stubValidator{},
+		crypter:   fieldcrypt.New(nil),
+		resolver:  secretref.NewResolver(),
+		renderer:  render.NewRenderer(nil),
+	}
+
+	_, err := svc.Create(context.Background(), "service_client", "pay", json.RawMessage(`{"base_url":"https://{{ .vars.domain }}"}`), "")
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func Test_service_Create_RecordsSchemaVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repoMock.NewMockIRepo(ctrl)
+	repo.EXPECT().
+		Create(gomock.Any(), "feature_toggle", "qris", json.RawMessage(`{"enabled":true}`), model.VersionMeta{SchemaVersion: 3}).
+		Return(model.RemoteConfig{Name: "qris", Type: "feature_toggle", Version: 1, SchemaVersion: 3, Data: json.RawMessage(`{"enabled":true}`)}, nil)
+
+	svc := service{
+		repo:      repo,
+		validator: stubValidator{version: 3},
+		crypter:   fieldcrypt.New(nil),
+		resolver:  secretref.NewResolver(),
+		renderer:  render.NewRenderer(nil),
+	}
+
+	got, err := svc.Create(context.Background(), "feature_toggle", "qris", json.RawMessage(`{"enabled":true}`), "")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, got.SchemaVersion)
+}
+
+func Test_service_Create_SegmentReferences(t *testing.T) {
+	data := json.RawMessage(`{"enabled":true,"rules":[{"segments":["employees","beta"],"serve":true},{"segments":["qris"],"serve":false}]}`)
+
+	cases := []struct {
+		name       string
+		mockFunc   func(m *repoMock.MockIRepo)
+		violations []model.Violation
+	}{
+		{
+			name: "when referenced segments are missing or not segments should report each",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "employees").Return(model.RemoteConfig{Name: "employees", Type: "segment", Version: 2}, nil)
+				m.EXPECT().Latest(gomock.Any(), "beta").Return(model.RemoteConfig{}, repository.ErrNotFound)
+				m.EXPECT().Latest(gomock.Any(), "qris").Return(model.RemoteConfig{Name: "qris", Type: "feature_toggle", Version: 1}, nil)
+			},
+			violations: []model.Violation{
+				{Pointer: "/rules/0/segments/1", Keyword: "segmentExists", Actual: "beta", Message: `rules.0.segments.1: segment "beta" does not exist`},
+				{Pointer: "/rules/1/segments/0", Keyword: "segmentType", Expected: "segment", Actual: "qris", Message: `rules.1.segments.0: "qris" is of type feature_toggle, not segment`},
+			},
+		},
+		{
+			name: "when referenced segments exist should create the config",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), gomock.Any()).Return(model.RemoteConfig{Type: "segment", Version: 1}, nil).Times(3)
+				m.EXPECT().Create(gomock.Any(), "feature_toggle", "checkout", data, model.VersionMeta{}).
+					Return(model.RemoteConfig{Name: "checkout", Type: "feature_toggle", Version: 1, Data: data}, nil)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+
+			svc := service{
+				repo:      repo,
+				validator: stubValidator{},
+				crypter:   fieldcrypt.New(nil),
+				resolver:  secretref.NewResolver(),
+				renderer:  render.NewRenderer(nil),
+			}
+
+			_, err := svc.Create(context.Background(), "feature_toggle", "checkout", data, "")
+			if tc.violations == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var verr *ValidationError
+			if assert.ErrorAs(t, err, &verr) {
+				assert.Equal(t, tc.violations, verr.Report.Violations)
+			}
+		})
+	}
+}
+
+func Test_service_Create_LayerOverlap(t *testing.T) {
+	const variants = `"variants":[{"name":"control","weight":50},{"name":"green","weight":50}]`
+	exp := func(rest string) json.RawMessage {
+		return json.RawMessage(`{"experiment_key":"checkout-copy",` + variants + `,` + rest + `}`)
+	}
+	layer := &model.Layer{Name: "checkout-page", Start: 40, End: 70}
+
+	cases := []struct {
+		name       string
+		data       json.RawMessage
+		meta       model.VersionMeta
+		repoErr    error
+		violations []model.Violation
+	}{
+		{
+			name:    "when an active experiment of the layer holds part of the range should report it",
+			data:    exp(`"active":true,"layer":{"name":"checkout-page","start":40,"end":70}`),
+			meta:    model.VersionMeta{Layer: layer},
+			repoErr: &repository.LayerOverlapError{Name: "checkout-copy", Layer: *layer, Overlaps: []repository.LayerOverlap{{Name: "checkout-button", Layer: model.Layer{Name: "checkout-page", Start: 0, End: 50}}}},
+			violations: []model.Violation{
+				{Pointer: "/layer", Keyword: "layerOverlap", Actual: "checkout-button", Message: `layer: [40, 70) of layer "checkout-page" overlaps [0, 50) of experiment "checkout-button"`},
+			},
+		},
+		{
+			name: "when the range is free should write it with the version",
+			data: exp(`"active":true,"layer":{"name":"checkout-page","start":40,"end":70}`),
+			meta: model.VersionMeta{Layer: layer},
+		},
+		{
+			name: "when the experiment is inactive should take no range",
+			data: exp(`"active":false,"layer":{"name":"checkout-page","start":40,"end":70}`),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repoMock.NewMockIRepo(ctrl)
+			repo.EXPECT().Create(gomock.Any(), "experiment_config", "checkout-copy", tc.data, tc.meta).
+				Return(model.RemoteConfig{Name: "checkout-copy", Type: "experiment_config", Version: 1, Data: tc.data}, tc.repoErr)
+
+			svc := service{
+				repo:      repo,
+				validator: stubValidator{},
+				crypter:   fieldcrypt.New(nil),
+				resolver:  secretref.NewResolver(),
+				renderer:  render.NewRenderer(nil),
+			}
+
+			_, err := svc.Create(context.Background(), "experiment_config", "checkout-copy", tc.data, "")
+			if tc.violations == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var verr *ValidationError
+			if assert.ErrorAs(t, err, &verr) {
+				assert.Equal(t, tc.violations, verr.Report.Violations)
+				assert.Empty(t, verr.Report.Config)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/pkg/jsonx"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DryRun runs the validation of a create, or of an update when name exists,
+// and reports the version it would write without storing anything.
+// schemaType is required for a new config and must match an existing one.
+func (s service) DryRun(ctx context.Context, schemaType, name string, data json.RawMessage, extends *string) (model.DryRun, error) {
+	schemaType = strings.TrimSpace(schemaType)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.DryRun{}, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+
+	latest, err := s.repo.Latest(ctx, name)
+	if errors.Is(err, repository.ErrNotFound) {
+		if schemaType == "" {
+			return model.DryRun{}, ErrNotFound
+		}
+		base := ""
+		if extends != nil {
+			base = *extends
+		}
+		p, err := s.planCreate(ctx, schemaType, name, data, base)
+		if err != nil {
+			return model.DryRun{}, err
+		}
+		if err := s.checkLayers(ctx, name, p); err != nil {
+			return model.DryRun{}, err
+		}
+		return s.dryRun(ctx, name, nil, p)
+	}
+	if err != nil {
+		return model.DryRun{}, err
+	}
+
+	if schemaType != "" && schemaType != latest.Type {
+		return model.DryRun{}, fmt.Errorf("%w: %s is a %s config", ErrAlreadyExists, name, latest.Type)
+	}
+	p, err := s.planUpdate(ctx, latest, data, extends)
+	if err != nil {
+		return model.DryRun{}, err
+	}
+	if err := s.checkLayers(ctx, name, p); err != nil {
+		return model.DryRun{}, err
+	}
+	return s.dryRun(ctx, name, &latest, p)
+}
+
+// dryRun describes the write p would make after latest (nil for a create).
+// The diff compares the plaintext own data so changed secrets show up, but
+// the values reported come from the redacted documents.
+func (s service) dryRun(ctx context.Context, name string, latest *model.RemoteConfig, p plan) (model.DryRun, error) {
+	res := model.DryRun{
+		Name:          name,
+		Type:          p.schemaType,
+		Operation:     model.OperationCreate,
+		Version:       1,
+		SchemaVersion: p.meta.SchemaVersion,
+		Extends:       p.meta.Extends,
+		BaseVersion:   p.meta.BaseVersion,
+		Warnings:      p.warnings,
+	}
+	for dep := range p.checked {
+		res.Dependents = append(res.Dependents, dep)
+	}
+	sort.Strings(res.Dependents)
+
+	var prev json.RawMessage
+	before, shownBefore := json.RawMessage(`{}`), json.RawMessage(`{}`)
+	if latest != nil {
+		prev = latest.Data
+		res.Operation = model.OperationUpdate
+		res.CurrentVersion = latest.Version
+		res.Version = latest.Version + 1
+
+		plain, err := s.open(ctx, *latest, true)
+		if err != nil {
+			return model.DryRun{}, err
+		}
+		shown, err := s.open(ctx, *latest, false)
+		if err != nil {
+			return model.DryRun{}, err
+		}
+		before, shownBefore = plain.Data, shown.Data
+	}
+
+	sealed, err := s.seal(ctx, p.schemaType, p.meta.SchemaVersion, name, p.data, prev)
+	if err != nil {
+		return model.DryRun{}, err
+	}
+	next := model.RemoteConfig{Name: name, Type: p.schemaType, SchemaVersion: p.meta.SchemaVersion, Data: sealed}
+	plain, err := s.open(ctx, next, true)
+	if err != nil {
+		return model.DryRun{}, err
+	}
+	shown, err := s.open(ctx, next, false)
+	if err != nil {
+		return model.DryRun{}, err
+	}
+	res.Data = shown.Data
+
+	changes, err := jsonx.Diff(before, plain.Data)
+	if err != nil {
+		return model.DryRun{}, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+	oldDoc, err := jsonx.Decode(shownBefore)
+	if err != nil {
+		return model.DryRun{}, err
+	}
+	newDoc, err := jsonx.Decode(shown.Data)
+	if err != nil {
+		return model.DryRun{}, err
+	}
+	for i, c := range changes {
+		if c.Old != nil {
+			if changes[i].Old, err = shownValue(oldDoc, c.Path); err != nil {
+				return model.DryRun{}, err
+			}
+		}
+		if c.New != nil {
+			if changes[i].New, err = shownValue(newDoc, c.Path); err != nil {
+				return model.DryRun{}, err
+			}
+		}
+	}
+	res.Changes = changes
+	return res, nil
+}
+
+func shownValue(doc any, pointer string) (json.RawMessage, error) {
+	v, _ := jsonx.Lookup(doc, pointer)
+	return jsonx.Encode(v)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/render"
+	"configuration-management-service/internal/remote_config/repository"
+	repoMock "configuration-management-service/internal/remote_config/repository/mocks"
+	"configuration-management-service/internal/remote_config/secretref"
+	"configuration-management-service/pkg/jsonx"
+	"configuration-management-service/pkg/keyring"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_DryRun(t *testing.T) {
+	kr, _ := keyring.New("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	paths := []string{"/headers"}
+	sealed, err := fieldcrypt.New(kr).Seal("pay", paths, json.RawMessage(`{"timeout_ms":200,"headers":{"auth":"old","trace":"on"}}`), nil)
+	assert.NoError(t, err)
+	pay := model.RemoteConfig{Name: "pay", Type: "service_client", Version: 4, SchemaVersion: 1, Data: sealed}
+
+	cases := []struct {
+		name       string
+		schemaType string
+		cfgName    string
+		data       string
+		extends    *string
+		valErr     error
+		mockFunc   func(m *repoMock.MockIRepo)
+		res        model.DryRun
+		err        error
+	}{
+		{
+			name:     "when name is empty should return ErrInvalidInput",
+			cfgName:  "  ",
+			data:     `{}`,
+			mockFunc: func(m *repoMock.MockIRepo) {},
+			err:      ErrInvalidInput,
+		},
+		{
+			name:    "when config is new and type is missing should return ErrNotFound",
+			cfgName: "pay",
+			data:    `{}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "pay").Return(model.RemoteConfig{}, repository.ErrNotFound)
+			},
+			err: ErrNotFound,
+		},
+		{
+			name:       "when config is new should report version 1 with every field added",
+			schemaType: "service_client",
+			cfgName:    "pay",
+			data:       `{"timeout_ms":200,"headers":{"auth":"new"}}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "pay").Return(model.RemoteConfig{}, repository.ErrNotFound)
+			},
+			res: model.DryRun{
+				Name: "pay", Type: "service_client", Operation: model.OperationCreate, Version: 1, SchemaVersion: 1,
+				Data: json.RawMessage(`{"headers":{"auth":"[REDACTED]"},"timeout_ms":200}`),
+				Changes: []jsonx.Change{
+					{Op: "add", Path: "/headers", New: json.RawMessage(`{"auth":"[REDACTED]"}`)},
+					{Op: "add", Path: "/timeout_ms", New: json.RawMessage(`200`)},
+				},
+			},
+		},
+		{
+			name:       "when type differs from the existing config should return ErrAlreadyExists",
+			schemaType: "feature_toggle",
+			cfgName:    "pay",
+			data:       `{}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "pay").Return(pay, nil)
+			},
+			err: ErrAlreadyExists,
+		},
+		{
+			name:    "when data breaks the schema should return the validation error",
+			cfgName: "pay",
+			data:    `{"timeout_ms":1}`,
+			valErr:  errors.New("timeout_ms too low"),
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "pay").Return(pay, nil)
+			},
+			err: ErrInvalidInput,
+		},
+		{
+			name:    "when config exists should report the next version and a redacted diff",
+			cfgName: "pay",
+			data:    `{"timeout_ms":500,"headers":{"auth":"new","trace":"on"}}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "pay").Return(pay, nil)
+				m.EXPECT().Dependents(gomock.Any(), "pay").Return([]model.RemoteConfig{{Name: "pay-eu", Type: "service_client", Data: json.RawMessage(`{}`)}}, nil)
+				m.EXPECT().Dependents(gomock.Any(), "pay-eu").Return(nil, nil)
+			},
+			res: model.DryRun{
+				Name: "pay", Type: "service_client", Operation: model.OperationUpdate, Version: 5, CurrentVersion: 4, SchemaVersion: 1,
+				Data: json.RawMessage(`{"headers":{"auth":"[REDACTED]","trace":"[REDACTED]"},"timeout_ms":500}`),
+				Changes: []jsonx.Change{
+					{Op: "replace", Path: "/headers/auth", Old: json.RawMessage(`"[REDACTED]"`), New: json.RawMessage(`"[REDACTED]"`)},
+					{Op: "replace", Path: "/timeout_ms", Old: json.RawMessage(`200`), New: json.RawMessage(`500`)},
+				},
+				Dependents: []string{"pay-eu"},
+			},
+		},
+		{
+			name:    "when secrets are sent back redacted should keep them unchanged",
+			cfgName: "pay",
+			data:    `{"timeout_ms":500,"headers":{"auth":"[REDACTED]","trace":"[REDACTED]"}}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "pay").Return(pay, nil)
+				m.EXPECT().Dependents(gomock.Any(), "pay").Return(nil, nil)
+			},
+			res: model.DryRun{
+				Name: "pay", Type: "service_client", Operation: model.OperationUpdate, Version: 5, CurrentVersion: 4, SchemaVersion: 1,
+				Data: json.RawMessage(`{"headers":{"auth":"[REDACTED]","trace":"[REDACTED]"},"timeout_ms":500}`),
+				Changes: []jsonx.Change{
+					{Op: "replace", Path: "/timeout_ms", Old: json.RawMessage(`200`), New: json.RawMessage(`500`)},
+				},
+			},
+		},
+		{
+			name:    "when a new secret is sent redacted should return ErrInvalidInput",
+			cfgName: "pay",
+			data:    `{"timeout_ms":500,"headers":{"api_key":"[REDACTED]"}}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "pay").Return(pay, nil)
+				m.EXPECT().Dependents(gomock.Any(), "pay").Return(nil, nil)
+			},
+			err: ErrInvalidInput,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// No Create or Append is expected: a dry run never writes.
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo, validator: stubValidator{err: tc.valErr, secrets: paths, version: 1}, crypter: fieldcrypt.New(kr), resolver: secretref.NewResolver(), renderer: render.NewRenderer(nil)}
+
+			res, err := svc.DryRun(context.Background(), tc.schemaType, tc.cfgName, json.RawMessage(tc.data), tc.extends)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.res, res)
+		})
+	}
+}
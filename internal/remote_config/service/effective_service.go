@@ -0,0 +1,36 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/render"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// EffectiveByType returns the latest version of every config of schemaType
+// with the data a schema has to accept: base chain merged, secret fields
+// decrypted and variables rendered. Secrets are in plain text, so the result
+// is for internal checks only and must never be served.
+func (s service) EffectiveByType(ctx context.Context, schemaType string) ([]model.RemoteConfig, error) {
+	latest, err := s.repo.LatestByType(ctx, schemaType)
+	if err != nil {
+		return nil, err
+	}
+	for i, cfg := range latest {
+		data, err := s.effective(ctx, cfg, s.revealedLayer(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		rendered, used, err := s.renderer.Render(ctx, data)
+		switch {
+		case errors.Is(err, render.ErrTemplate):
+			rendered = data
+		case err != nil:
+			return nil, fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		latest[i].Data = rendered
+		latest[i].Variables = used
+	}
+	return latest, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/render"
+	"configuration-management-service/internal/remote_config/secretref"
+	"context"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/model"
+	repoMock "configuration-management-service/internal/remote_config/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_EffectiveByType(t *testing.T) {
+	vars := render.SourceFunc(func(context.Context) (map[string]render.Value, error) {
+		return map[string]render.Value{"region": {Version: 3, Value: "eu"}}, nil
+	})
+
+	cases := []struct {
+		name     string
+		mockFunc func(m *repoMock.MockIRepo)
+		res      []model.RemoteConfig
+		err      error
+	}{
+		{
+			name: "when repo fails should return error",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return(nil, errors.New("db down"))
+			},
+			err: errors.New("db down"),
+		},
+		{
+			name: "when configs extend a base should merge it and render variables",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return([]model.RemoteConfig{
+					{Name: "base", Type: "geo_rule", Version: 2, Data: []byte(`{"pct":10}`)},
+					{Name: "child", Type: "geo_rule", Version: 1, Extends: "base", BaseVersion: 1, Data: []byte(`{"region":"{{ .vars.region }}"}`)},
+				}, nil)
+				m.EXPECT().ByVersion(gomock.Any(), "base", 1).Return(model.RemoteConfig{Name: "base", Type: "geo_rule", Version: 1, Data: []byte(`{"pct":5}`)}, nil)
+			},
+			res: []model.RemoteConfig{
+				{Name: "base", Type: "geo_rule", Version: 2, Data: []byte(`{"pct":10}`)},
+				{Name: "child", Type: "geo_rule", Version: 1, Extends: "base", BaseVersion: 1, Data: []byte(`{"pct":5,"region":"eu"}`), Variables: map[string]int{"region": 3}},
+			},
+		},
+		{
+			name: "when template invalid should keep data unrendered",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return([]model.RemoteConfig{
+					{Name: "broken", Type: "geo_rule", Version: 1, Data: []byte(`{"region":"{{ .vars.nope }}"}`)},
+				}, nil)
+			},
+			res: []model.RemoteConfig{
+				{Name: "broken", Type: "geo_rule", Version: 1, Data: []byte(`{"region":"{{ .vars.nope }}"}`)},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo, validator: stubValidator{}, crypter: fieldcrypt.New(nil), resolver: secretref.NewResolver(), renderer: render.NewRenderer(vars)}
+
+			got, err := svc.EffectiveByType(context.Background(), "geo_rule")
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, len(tc.res))
+			for i := range tc.res {
+				assert.JSONEq(t, string(tc.res[i].Data), string(got[i].Data))
+				got[i].Data = tc.res[i].Data
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
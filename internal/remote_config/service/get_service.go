@@ -2,13 +2,18 @@ package service
 
 import (
 	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/render"
 	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/internal/remote_config/secretref"
+	"configuration-management-service/internal/remote_config/validator"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 )
 
-func (s service) Get(ctx context.Context, name string, version *int) (model.RemoteConfig, error) {
+func (s service) Get(ctx context.Context, name string, version *int, opts model.ReadOptions) (model.RemoteConfig, error) {
 	name = strings.TrimSpace(name)
 	if name == "" {
 		return model.RemoteConfig{}, ErrInvalidInput
@@ -22,7 +27,7 @@ func (s service) Get(ctx context.Context, name string, version *int) (model.Remo
 			}
 			return model.RemoteConfig{}, err
 		}
-		return cfg, nil
+		return s.present(ctx, cfg, opts)
 	}
 
 	cfg, err := s.repo.ByVersion(ctx, name, *version)
@@ -32,5 +37,73 @@ func (s service) Get(ctx context.Context, name string, version *int) (model.Remo
 		}
 		return model.RemoteConfig{}, err
 	}
+	return s.present(ctx, cfg, opts)
+}
+
+// present turns a stored version into what the caller asked to see.
+func (s service) present(ctx context.Context, cfg model.RemoteConfig, opts model.ReadOptions) (model.RemoteConfig, error) {
+	reveal := opts.Reveal || opts.ResolveSecrets
+	switch opts.View {
+	case "", model.ViewResolved:
+		data, err := s.effective(ctx, cfg, func(layer model.RemoteConfig) (json.RawMessage, error) {
+			opened, err := s.open(ctx, layer, reveal)
+			return opened.Data, err
+		})
+		if err != nil {
+			return model.RemoteConfig{}, err
+		}
+		cfg.Data = data
+		if cfg, err = s.renderVariables(ctx, cfg); err != nil {
+			return model.RemoteConfig{}, err
+		}
+		if cfg.Data, cfg.Defaulted, err = s.validator.ApplyDefaults(ctx, cfg.Type, cfg.SchemaVersion, validator.DefaultsRead, cfg.Data); err != nil {
+			return model.RemoteConfig{}, err
+		}
+	case model.ViewRaw:
+		var err error
+		if cfg, err = s.open(ctx, cfg, reveal); err != nil {
+			return model.RemoteConfig{}, err
+		}
+	default:
+		return model.RemoteConfig{}, fmt.Errorf("%w: unknown view %q", ErrInvalidInput, opts.View)
+	}
+	if !opts.ResolveSecrets {
+		return cfg, nil
+	}
+	data, res, err := s.resolver.Resolve(ctx, cfg.Data)
+	if err != nil {
+		return model.RemoteConfig{}, err
+	}
+	secretref.Audit(nil, cfg.Name, cfg.Version, res)
+	for _, r := range res {
+		if !r.Resolved {
+			cfg.Warnings = append(cfg.Warnings, "unresolved secret reference: ${secret:"+r.Ref+"}")
+		}
+	}
+	cfg.Data = data
+	return cfg, nil
+}
+
+// renderVariables renders {{ .vars.<name> }} templates with the current
+// variables and validates the rendered value. Data that no longer renders or
+// validates (e.g. a variable changed since the write) is served with warnings
+// rather than failing the read.
+func (s service) renderVariables(ctx context.Context, cfg model.RemoteConfig) (model.RemoteConfig, error) {
+	data, used, err := s.renderer.Render(ctx, cfg.Data)
+	if err != nil {
+		if errors.Is(err, render.ErrTemplate) {
+			cfg.Warnings = append(cfg.Warnings, "variables not rendered: "+err.Error())
+			return cfg, nil
+		}
+		return model.RemoteConfig{}, err
+	}
+	cfg.Data = data
+	cfg.Variables = used
+	if len(used) == 0 {
+		return cfg, nil
+	}
+	if _, err := s.validator.Validate(ctx, cfg.Type, data); err != nil {
+		cfg.Warnings = append(cfg.Warnings, "rendered config fails validation: "+err.Error())
+	}
 	return cfg, nil
 }
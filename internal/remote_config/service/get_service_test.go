@@ -1,8 +1,13 @@
 package service
 
 import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/render"
 	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/internal/remote_config/secretref"
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"configuration-management-service/internal/remote_config/model"
@@ -78,11 +83,102 @@ func Test_service_Get(t *testing.T) {
 
 			repo := repoMock.NewMockIRepo(ctrl)
 			tc.mockFunc(repo)
-			svc := service{repo: repo}
+			svc := service{repo: repo, validator: stubValidator{}, crypter: fieldcrypt.New(nil), resolver: secretref.NewResolver(), renderer: render.NewRenderer(nil)}
 
-			got, err := svc.Get(context.Background(), tc.cfgName, tc.version)
+			got, err := svc.Get(context.Background(), tc.cfgName, tc.version, model.ReadOptions{})
 			assert.Equal(t, tc.ex.err, err)
 			assert.Equal(t, tc.ex.res, got)
 		})
 	}
 }
+
+func Test_service_Get_ResolveSecrets(t *testing.T) {
+	t.Setenv("TEST_SECRET_PAYMENTS_API_TOKEN", "tok-123")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repoMock.NewMockIRepo(ctrl)
+	stored := model.RemoteConfig{
+		Name:    "pay",
+		Type:    "service_client",
+		Version: 3,
+		Data:    json.RawMessage(`{"base_url":"https://pay","headers":{"Authorization":"Bearer ${secret:payments/api_token}","X-Key":"${secret:missing}"}}`),
+	}
+	repo.EXPECT().Latest(gomock.Any(), "pay").Return(stored, nil).Times(2)
+
+	svc := service{
+		repo:      repo,
+		validator: stubValidator{},
+		crypter:   fieldcrypt.New(nil),
+		resolver:  secretref.NewResolver(secretref.NewEnvProvider("TEST_SECRET_")),
+		renderer:  render.NewRenderer(nil),
+	}
+
+	got, err := svc.Get(context.Background(), "pay", nil, model.ReadOptions{ResolveSecrets: true})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"base_url":"https://pay","headers":{"Authorization":"Bearer tok-123","X-Key":"${secret:missing}"}}`, string(got.Data))
+	assert.Equal(t, []string{"unresolved secret reference: ${secret:missing}"}, got.Warnings)
+
+	got, err = svc.Get(context.Background(), "pay", nil, model.ReadOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, stored.Data, got.Data, "references stay untouched unless resolution is requested")
+}
+
+func Test_service_Get_RendersVariables(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repoMock.NewMockIRepo(ctrl)
+	stored := model.RemoteConfig{
+		Name:    "pay",
+		Type:    "service_client",
+		Version: 2,
+		Data:    json.RawMessage(`{"base_url":"https://pay.{{ .vars.domain }}"}`),
+	}
+	repo.EXPECT().Latest(gomock.Any(), "pay").Return(stored, nil).Times(2)
+
+	vars := render.SourceFunc(func(context.Context) (map[string]render.Value, error) {
+		return map[string]render.Value{"domain": {Version: 4, Value: "example.com"}}, nil
+	})
+	svc := service{
+		repo:      repo,
+		validator: stubValidator{},
+		crypter:   fieldcrypt.New(nil),
+		resolver:  secretref.NewResolver(),
+		renderer:  render.NewRenderer(vars),
+	}
+
+	got, err := svc.Get(context.Background(), "pay", nil, model.ReadOptions{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"base_url":"https://pay.example.com"}`, string(got.Data))
+	assert.Equal(t, map[string]int{"domain": 4}, got.Variables)
+
+	got, err = svc.Get(context.Background(), "pay", nil, model.ReadOptions{View: model.ViewRaw})
+	assert.NoError(t, err)
+	assert.Equal(t, stored.Data, got.Data, "raw view keeps templates unrendered")
+	assert.Nil(t, got.Variables)
+}
+
+func Test_service_Get_RenderedInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repoMock.NewMockIRepo(ctrl)
+	repo.EXPECT().Latest(gomock.Any(), "pay").Return(model.RemoteConfig{
+		Name: "pay", Type: "service_client", Version: 2,
+		Data: json.RawMessage(`{"base_url":"{{ .vars.domain }}"}`),
+	}, nil)
+
+	vars := render.SourceFunc(func(context.Context) (map[string]render.Value, error) {
+		return map[string]render.Value{"domain": {Version: 5, Value: "not a url"}}, nil
+	})
+	svc := service{
+		repo:      repo,
+		validator: stubValidator{err: errors.New("base_url: does not match format 'uri'")},
+		crypter:   fieldcrypt.New(nil),
+		resolver:  secretref.NewResolver(),
+		renderer:  render.NewRenderer(vars),
+	}
+
+	got, err := svc.Get(context.Background(), "pay", nil, model.ReadOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rendered config fails validation: base_url: does not match format 'uri'"}, got.Warnings)
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/pkg/jsonx"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// maxInheritanceDepth bounds base chains so a corrupted chain cannot recurse forever.
+const maxInheritanceDepth = 8
+
+// layerFunc returns the data one layer contributes to the effective value.
+type layerFunc func(cfg model.RemoteConfig) (json.RawMessage, error)
+
+// revealedLayer returns the data of each layer with its secret fields
+// decrypted, which is what the schema validates: sealed values are strings
+// whatever the type of the field.
+func (s service) revealedLayer(ctx context.Context) layerFunc {
+	return func(cfg model.RemoteConfig) (json.RawMessage, error) {
+		opened, err := s.open(ctx, cfg, true)
+		return opened.Data, err
+	}
+}
+
+// loadBase returns the latest version of base after checking that name may
+// extend it: same type and no cycle back to name.
+func (s service) loadBase(ctx context.Context, name, schemaType, base string) (model.RemoteConfig, error) {
+	if base == name {
+		return model.RemoteConfig{}, fmt.Errorf("%w: config cannot extend itself", ErrInvalidInput)
+	}
+	latest, err := s.repo.Latest(ctx, base)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return model.RemoteConfig{}, fmt.Errorf("%w: base config %q not found", ErrInvalidInput, base)
+		}
+		return model.RemoteConfig{}, err
+	}
+	if latest.Type != schemaType {
+		return model.RemoteConfig{}, fmt.Errorf("%w: base config %q is %s, not %s", ErrInvalidInput, base, latest.Type, schemaType)
+	}
+
+	cur := latest
+	for depth := 1; cur.Extends != ""; depth++ {
+		if cur.Extends == name {
+			return model.RemoteConfig{}, fmt.Errorf("%w: inheritance cycle: %s extends %s", ErrInvalidInput, cur.Name, name)
+		}
+		if depth >= maxInheritanceDepth {
+			return model.RemoteConfig{}, fmt.Errorf("%w: inheritance chain deeper than %d", ErrInvalidInput, maxInheritanceDepth)
+		}
+		if cur, err = s.repo.Latest(ctx, cur.Extends); err != nil {
+			return model.RemoteConfig{}, err
+		}
+	}
+	return latest, nil
+}
+
+// effective merges cfg onto its pinned base chain, base first.
+func (s service) effective(ctx context.Context, cfg model.RemoteConfig, layer layerFunc) (json.RawMessage, error) {
+	return s.effectiveDepth(ctx, cfg, layer, 0)
+}
+
+func (s service) effectiveDepth(ctx context.Context, cfg model.RemoteConfig, layer layerFunc, depth int) (json.RawMessage, error) {
+	own, err := layer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Extends == "" {
+		return own, nil
+	}
+	if depth >= maxInheritanceDepth {
+		return nil, fmt.Errorf("%s: inheritance chain deeper than %d", cfg.Name, maxInheritanceDepth)
+	}
+	base, err := s.repo.ByVersion(ctx, cfg.Extends, cfg.BaseVersion)
+	if err != nil {
+		return nil, fmt.Errorf("%s: base %s@%d: %w", cfg.Name, cfg.Extends, cfg.BaseVersion, err)
+	}
+	baseData, err := s.effectiveDepth(ctx, base, layer, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	return jsonx.MergeRaw(baseData, own)
+}
+
+// checkDependents validates every direct and indirect dependent of name
+// against the effective value name is about to get, before anything is
+// written. It returns what the validation found for each dependent.
+func (s service) checkDependents(ctx context.Context, name string, effective json.RawMessage) (map[string]validated, error) {
+	checked := map[string]validated{}
+	if err := s.checkDependentsDepth(ctx, name, effective, 0, checked); err != nil {
+		return nil, err
+	}
+	return checked, nil
+}
+
+func (s service) checkDependentsDepth(ctx context.Context, name string, effective json.RawMessage, depth int, checked map[string]validated) error {
+	if depth >= maxInheritanceDepth {
+		return nil
+	}
+	deps, err := s.repo.Dependents(ctx, name)
+	if err != nil {
+		return err
+	}
+	for _, d := range deps {
+		own, err := s.revealedLayer(ctx)(d)
+		if err != nil {
+			return fmt.Errorf("dependent %s: %w", d.Name, err)
+		}
+		merged, err := jsonx.MergeRaw(effective, own)
+		if err != nil {
+			return err
+		}
+		v, err := s.validateRendered(ctx, d.Type, merged)
+		if err != nil {
+			var verr *ValidationError
+			if errors.As(err, &verr) {
+				verr.Report.Config = d.Name
+				return verr
+			}
+			return fmt.Errorf("dependent %s: %w", d.Name, err)
+		}
+		checked[d.Name] = v
+		if err := s.checkDependentsDepth(ctx, d.Name, merged, depth+1, checked); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendVersion appends data as the next version of name, which must still
+// be at fromVersion, and re-pins every direct and indirect dependent to the
+// new version in the same transaction, so the change of their effective
+// value is recorded in their own history. checked holds what
+// checkDependents found for them.
+func (s service) appendVersion(ctx context.Context, name string, fromVersion int, data json.RawMessage, meta model.VersionMeta, checked map[string]validated) (model.RemoteConfig, error) {
+	items := []model.MigrationItem{{Name: name, FromVersion: fromVersion, Data: data, Meta: meta}}
+	items, err := s.dependentItems(ctx, name, 0, checked, items)
+	if err != nil {
+		return model.RemoteConfig{}, err
+	}
+
+	written, err := s.repo.AppendBatch(ctx, items)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return model.RemoteConfig{}, ErrNotFound
+		case errors.Is(err, repository.ErrConflict):
+			return model.RemoteConfig{}, fmt.Errorf("%w: %s", ErrConflict, err.Error())
+		default:
+			return model.RemoteConfig{}, layerError(name, err)
+		}
+	}
+	return written[0], nil
+}
+
+// dependentItems appends a new version of every dependent of name to items,
+// dependents of dependents after their base. The repository pins each one
+// to the version its base gets in the same batch.
+func (s service) dependentItems(ctx context.Context, name string, depth int, checked map[string]validated, items []model.MigrationItem) ([]model.MigrationItem, error) {
+	if depth >= maxInheritanceDepth {
+		return items, nil
+	}
+	deps, err := s.repo.Dependents(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deps {
+		meta := model.VersionMeta{Extends: name, SchemaVersion: checked[d.Name].schemaVersion, Layer: checked[d.Name].layer}
+		items = append(items, model.MigrationItem{Name: d.Name, FromVersion: d.Version, Data: d.Data, Meta: meta})
+		if items, err = s.dependentItems(ctx, d.Name, depth+1, checked, items); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
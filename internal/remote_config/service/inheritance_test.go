@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/render"
+	"configuration-management-service/internal/remote_config/repository"
+	repoMock "configuration-management-service/internal/remote_config/repository/mocks"
+	"configuration-management-service/internal/remote_config/secretref"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingValidator remembers the last document it validated.
+type recordingValidator struct {
+	stubValidator
+	last *json.RawMessage
+}
+
+func (r recordingValidator) Validate(ctx context.Context, schemaType string, data json.RawMessage) (int, error) {
+	*r.last = data
+	return r.version, r.err
+}
+
+func newInheritanceService(repo *repoMock.MockIRepo, last *json.RawMessage) service {
+	return service{
+		repo:      repo,
+		validator: recordingValidator{last: last},
+		crypter:   fieldcrypt.New(nil),
+		resolver:  secretref.NewResolver(),
+		renderer:  render.NewRenderer(nil),
+	}
+}
+
+var (
+	baseV2 = model.RemoteConfig{
+		Name: "svc-base", Type: "service_client", Version: 2,
+		Data: json.RawMessage(`{"name":"svc","base_url":"https://a","timeout_ms":500,"retry":{"max_retries":3}}`),
+	}
+	overlayV1 = model.RemoteConfig{
+		Name: "svc-eu", Type: "service_client", Version: 1,
+		Data:    json.RawMessage(`{"base_url":"https://eu","retry":{"jitter":true}}`),
+		Extends: "svc-base", BaseVersion: 2,
+	}
+)
+
+func Test_service_Create_Extends(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repoMock.NewMockIRepo(ctrl)
+	var validated json.RawMessage
+
+	repo.EXPECT().Latest(gomock.Any(), "svc-base").Return(baseV2, nil)
+	repo.EXPECT().Create(gomock.Any(), "service_client", "svc-eu", overlayV1.Data, model.VersionMeta{Extends: "svc-base", BaseVersion: 2}).
+		Return(overlayV1, nil)
+
+	svc := newInheritanceService(repo, &validated)
+	got, err := svc.Create(context.Background(), "service_client", "svc-eu", overlayV1.Data, "svc-base")
+	assert.NoError(t, err)
+	assert.Equal(t, "svc-base", got.Extends)
+	assert.JSONEq(t, `{"name":"svc","base_url":"https://eu","timeout_ms":500,"retry":{"max_retries":3,"jitter":true}}`, string(validated),
+		"the merged document is what gets validated")
+}
+
+func Test_service_Create_ExtendsErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		extends  string
+		mockFunc func(m *repoMock.MockIRepo)
+	}{
+		{
+			name:     "when extends itself should return ErrInvalidInput",
+			extends:  "svc-eu",
+			mockFunc: func(m *repoMock.MockIRepo) {},
+		},
+		{
+			name:    "when base has different type should return ErrInvalidInput",
+			extends: "flag",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "flag").Return(model.RemoteConfig{Name: "flag", Type: "feature_toggle", Version: 1}, nil)
+			},
+		},
+		{
+			name:    "when base chain leads back should return ErrInvalidInput",
+			extends: "a",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "a").Return(model.RemoteConfig{Name: "a", Type: "service_client", Version: 1, Extends: "b"}, nil)
+				m.EXPECT().Latest(gomock.Any(), "b").Return(model.RemoteConfig{Name: "b", Type: "service_client", Version: 1, Extends: "svc-eu"}, nil)
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			var validated json.RawMessage
+
+			svc := newInheritanceService(repo, &validated)
+			_, err := svc.Create(context.Background(), "service_client", "svc-eu", json.RawMessage(`{}`), tc.extends)
+			assert.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
+func Test_service_Update_PropagatesToDependents(t *testing.T) {
+	newBase := json.RawMessage(`{"name":"svc","base_url":"https://a","timeout_ms":900}`)
+	items := []model.MigrationItem{
+		{Name: "svc-base", FromVersion: 2, Data: newBase},
+		{Name: "svc-eu", FromVersion: 1, Data: overlayV1.Data, Meta: model.VersionMeta{Extends: "svc-base"}},
+	}
+
+	cases := []struct {
+		name    string
+		written []model.RemoteConfig
+		repoErr error
+		version int
+		err     error
+	}{
+		{
+			name: "when batch is written should return the new base version",
+			written: []model.RemoteConfig{
+				{Name: "svc-base", Type: "service_client", Version: 3, Data: newBase},
+				{Name: "svc-eu", Type: "service_client", Version: 2, Extends: "svc-base", BaseVersion: 3},
+			},
+			version: 3,
+		},
+		{
+			name:    "when a dependent moved on should return ErrConflict",
+			repoErr: fmt.Errorf("%w: svc-eu is at version 2, expected 1", repository.ErrConflict),
+			err:     ErrConflict,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repoMock.NewMockIRepo(ctrl)
+			var validated json.RawMessage
+
+			repo.EXPECT().Latest(gomock.Any(), "svc-base").Return(baseV2, nil)
+			// once to validate dependents, once to list the versions to write
+			repo.EXPECT().Dependents(gomock.Any(), "svc-base").Return([]model.RemoteConfig{overlayV1}, nil).Times(2)
+			repo.EXPECT().Dependents(gomock.Any(), "svc-eu").Return(nil, nil).Times(2)
+			// base and dependents go in one batch: no version is written alone
+			repo.EXPECT().AppendBatch(gomock.Any(), items).Return(tc.written, tc.repoErr)
+
+			svc := newInheritanceService(repo, &validated)
+			got, err := svc.Update(context.Background(), "svc-base", newBase, nil)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.version, got.Version)
+		})
+	}
+}
+
+func Test_service_Get_Views(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repoMock.NewMockIRepo(ctrl)
+	var validated json.RawMessage
+
+	repo.EXPECT().Latest(gomock.Any(), "svc-eu").Return(overlayV1, nil).Times(2)
+	repo.EXPECT().ByVersion(gomock.Any(), "svc-base", 2).Return(baseV2, nil)
+
+	svc := newInheritanceService(repo, &validated)
+
+	resolved, err := svc.Get(context.Background(), "svc-eu", nil, model.ReadOptions{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"svc","base_url":"https://eu","timeout_ms":500,"retry":{"max_retries":3,"jitter":true}}`, string(resolved.Data))
+
+	raw, err := svc.Get(context.Background(), "svc-eu", nil, model.ReadOptions{View: model.ViewRaw})
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(overlayV1.Data), string(raw.Data))
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const typeExperimentConfig = "experiment_config"
+
+// activeLayer returns the layer range of an active experiment_config, or
+// nil for any other config: only running experiments hold their range. The
+// repository checks the range against the ones held when it writes the
+// version, so that a context falls in at most one running experiment per
+// layer.
+func activeLayer(schemaType string, data json.RawMessage) (*model.Layer, error) {
+	if schemaType != typeExperimentConfig {
+		return nil, nil
+	}
+	var doc struct {
+		Active bool `json:"active"`
+		Layer  *struct {
+			Name  string  `json:"name"`
+			Start float64 `json:"start"`
+			End   float64 `json:"end"`
+		} `json:"layer"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+	if !doc.Active || doc.Layer == nil {
+		return nil, nil
+	}
+	return &model.Layer{Name: doc.Layer.Name, Start: doc.Layer.Start, End: doc.Layer.End}, nil
+}
+
+// layerViolations reports the experiments holding part of layer.
+func layerViolations(layer model.Layer, overlaps []repository.LayerOverlap) []model.Violation {
+	out := make([]model.Violation, 0, len(overlaps))
+	for _, o := range overlaps {
+		out = append(out, model.Violation{
+			Pointer: "/layer", Keyword: "layerOverlap", Actual: o.Name,
+			Message: fmt.Sprintf("layer: [%v, %v) of layer %q overlaps [%v, %v) of experiment %q", layer.Start, layer.End, layer.Name, o.Layer.Start, o.Layer.End, o.Name),
+		})
+	}
+	return out
+}
+
+// checkLayers reports the overlaps writing p as name would be rejected for,
+// without writing. Writes are checked in their own transaction; this is for
+// dry runs.
+func (s service) checkLayers(ctx context.Context, name string, p plan) error {
+	takes := map[string]*model.Layer{name: p.meta.Layer}
+	names := []string{name}
+	for dep, v := range p.checked {
+		takes[dep] = v.layer
+		names = append(names, dep)
+	}
+	sort.Strings(names[1:])
+	for _, n := range names {
+		if takes[n] == nil {
+			continue
+		}
+		overlaps, err := s.repo.LayerOverlaps(ctx, n, *takes[n])
+		if err != nil {
+			return err
+		}
+		if len(overlaps) > 0 {
+			return layerError(name, &repository.LayerOverlapError{Name: n, Layer: *takes[n], Overlaps: overlaps})
+		}
+	}
+	return nil
+}
+
+// layerError turns the overlap the repository found while writing into a
+// ValidationError, reported against the dependent it was found on when
+// that is not name. Other errors are returned as they are.
+func layerError(name string, err error) error {
+	var lerr *repository.LayerOverlapError
+	if !errors.As(err, &lerr) {
+		return err
+	}
+	report := model.ValidationReport{Violations: layerViolations(lerr.Layer, lerr.Overlaps)}
+	if lerr.Name != name {
+		report.Config = lerr.Name
+	}
+	return &ValidationError{Report: report}
+}
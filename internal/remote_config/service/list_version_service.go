@@ -20,5 +20,10 @@ func (s service) ListVersions(ctx context.Context, name string) ([]model.RemoteC
 		}
 		return nil, err
 	}
+	for i := range res {
+		if res[i], err = s.open(ctx, res[i], false); err != nil {
+			return nil, err
+		}
+	}
 	return res, nil
 }
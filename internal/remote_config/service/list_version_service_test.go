@@ -1,7 +1,10 @@
 package service
 
 import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/render"
 	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/internal/remote_config/secretref"
 	"context"
 	"testing"
 
@@ -55,7 +58,7 @@ func Test_service_ListVersions(t *testing.T) {
 
 			repo := repoMock.NewMockIRepo(ctrl)
 			tc.mockFunc(repo)
-			svc := service{repo: repo}
+			svc := service{repo: repo, validator: stubValidator{}, crypter: fieldcrypt.New(nil), resolver: secretref.NewResolver(), renderer: render.NewRenderer(nil)}
 
 			got, err := svc.ListVersions(context.Background(), tc.cfgName)
 			assert.Equal(t, tc.ex.err, err)
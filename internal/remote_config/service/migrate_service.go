@@ -0,0 +1,232 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/pkg/jsonx"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Migrate runs fn over the own data of the latest version of every config
+// of schemaType, bases before the configs extending them, and validates the
+// new effective values. Unless dryRun is set, it appends one version tagged
+// with migrationID to every changed config in a single transaction; a
+// config whose base changed is re-pinned to the new base version. Nothing
+// is written when any config fails; the results say which one and why.
+func (s service) Migrate(ctx context.Context, schemaType, migrationID string, fn model.MigrateFunc, dryRun bool) ([]model.MigrationResult, error) {
+	schemaType = strings.TrimSpace(schemaType)
+	if schemaType == "" || fn == nil {
+		return nil, ErrInvalidInput
+	}
+	if !dryRun && strings.TrimSpace(migrationID) == "" {
+		return nil, fmt.Errorf("%w: migration id is required", ErrInvalidInput)
+	}
+
+	latest, err := s.repo.LatestByType(ctx, schemaType)
+	if err != nil {
+		return nil, err
+	}
+	latest = basesFirst(latest)
+
+	opened := s.revealedLayer(ctx)
+
+	results := make([]model.MigrationResult, 0, len(latest))
+	items := make([]model.MigrationItem, 0, len(latest))
+	effective := make(map[string]json.RawMessage, len(latest))
+	changed := make(map[string]bool, len(latest))
+	failed := 0
+	for _, cfg := range latest {
+		res := model.MigrationResult{Name: cfg.Name, Version: cfg.Version}
+		masked, err := s.open(ctx, cfg, false)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		res.Before, res.After = masked.Data, masked.Data
+
+		item, after, err := s.migrateOne(ctx, cfg, fn, opened, effective, changed)
+		switch {
+		case err != nil:
+			var verr *ValidationError
+			if !errors.As(err, &verr) && !errors.Is(err, ErrInvalidInput) {
+				return nil, fmt.Errorf("%s: %w", cfg.Name, err)
+			}
+			res.Error = err.Error()
+			failed++
+		case item != nil:
+			res.Changed = true
+			res.After = after
+			items = append(items, *item)
+		}
+		results = append(results, res)
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%w: migration fails for %d of %d configs", ErrInvalidInput, failed, len(results))
+	}
+	if dryRun {
+		return results, nil
+	}
+	// Write even an empty batch: the repository refuses a migration that
+	// was applied before.
+	written, err := s.repo.AppendMigration(ctx, migrationID, items)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAlreadyExists):
+			return nil, fmt.Errorf("%w: migration %s was applied before", ErrAlreadyExists, migrationID)
+		case errors.Is(err, repository.ErrConflict):
+			return nil, fmt.Errorf("%w: configs changed while the migration ran", ErrConflict)
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrNotFound
+		}
+		var lerr *repository.LayerOverlapError
+		if errors.As(err, &lerr) {
+			return nil, fmt.Errorf("%s: %w", lerr.Name, layerError(lerr.Name, err))
+		}
+		return nil, err
+	}
+	versions := make(map[string]int, len(written))
+	for _, cfg := range written {
+		versions[cfg.Name] = cfg.Version
+	}
+	for i := range results {
+		results[i].NewVersion = versions[results[i].Name]
+	}
+	return results, nil
+}
+
+// migrateOne computes the new version of one config. It returns a nil item
+// when neither the own data nor the base of the config changed. effective
+// and changed collect the outcome for the configs extending this one.
+func (s service) migrateOne(
+	ctx context.Context,
+	cfg model.RemoteConfig,
+	fn model.MigrateFunc,
+	opened layerFunc,
+	effective map[string]json.RawMessage,
+	changed map[string]bool,
+) (*model.MigrationItem, json.RawMessage, error) {
+	own, err := opened(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var inherited json.RawMessage
+	baseChanged := changed[cfg.Extends]
+	if cfg.Extends != "" {
+		if baseChanged {
+			inherited = effective[cfg.Extends]
+		} else {
+			base, err := s.repo.ByVersion(ctx, cfg.Extends, cfg.BaseVersion)
+			if err != nil {
+				return nil, nil, fmt.Errorf("base %s@%d: %w", cfg.Extends, cfg.BaseVersion, err)
+			}
+			if inherited, err = s.effective(ctx, base, opened); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	next, err := fn(own, inherited)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+	merged := next
+	if inherited != nil {
+		if merged, err = jsonx.MergeRaw(inherited, next); err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+		}
+	}
+	effective[cfg.Name] = merged
+
+	same, err := jsonEqual(own, next)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+	if same && !baseChanged {
+		return nil, nil, nil
+	}
+	changed[cfg.Name] = true
+
+	v, err := s.validateRendered(ctx, cfg.Type, merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	paths, err := s.validator.SecretFields(ctx, cfg.Type, v.schemaVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealed, err := s.crypter.Seal(cfg.Name, paths, next, cfg.Data)
+	if err != nil {
+		if errors.Is(err, fieldcrypt.ErrInvalidSecret) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+		}
+		return nil, nil, err
+	}
+	after, err := s.crypter.Open(cfg.Name, paths, sealed, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &model.MigrationItem{
+		Name:        cfg.Name,
+		FromVersion: cfg.Version,
+		Data:        sealed,
+		Meta: model.VersionMeta{
+			Extends:       cfg.Extends,
+			BaseVersion:   cfg.BaseVersion,
+			SchemaVersion: v.schemaVersion,
+			Layer:         v.layer,
+		},
+	}, after, nil
+}
+
+// basesFirst orders configs so that every base comes before the configs
+// extending it, by name otherwise.
+func basesFirst(cfgs []model.RemoteConfig) []model.RemoteConfig {
+	byName := make(map[string]model.RemoteConfig, len(cfgs))
+	names := make([]string, 0, len(cfgs))
+	for _, c := range cfgs {
+		byName[c.Name] = c
+		names = append(names, c.Name)
+	}
+	sort.Strings(names)
+
+	out := make([]model.RemoteConfig, 0, len(cfgs))
+	placed := make(map[string]bool, len(cfgs))
+	var place func(name string, depth int)
+	place = func(name string, depth int) {
+		c, ok := byName[name]
+		if !ok || placed[name] {
+			return
+		}
+		if depth < maxInheritanceDepth {
+			place(c.Extends, depth+1)
+		}
+		if !placed[name] {
+			placed[name] = true
+			out = append(out, c)
+		}
+	}
+	for _, n := range names {
+		place(n, 0)
+	}
+	return out
+}
+
+func jsonEqual(a, b json.RawMessage) (bool, error) {
+	x, err := jsonx.Decode(a)
+	if err != nil {
+		return false, err
+	}
+	y, err := jsonx.Decode(b)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(x, y), nil
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/render"
+	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/internal/remote_config/secretref"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/model"
+	repoMock "configuration-management-service/internal/remote_config/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// renamePct moves "pct" to "percent" in the own data of a config.
+func renamePct(own, inherited json.RawMessage) (json.RawMessage, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(own, &doc); err != nil {
+		return nil, err
+	}
+	v, ok := doc["pct"]
+	if !ok {
+		return own, nil
+	}
+	if v == "bad" {
+		return nil, errors.New("pct is not a number")
+	}
+	delete(doc, "pct")
+	doc["percent"] = v
+	return json.Marshal(doc)
+}
+
+func Test_service_Migrate(t *testing.T) {
+	stored := []model.RemoteConfig{
+		{Name: "child", Type: "geo_rule", Version: 4, Extends: "base", BaseVersion: 2, SchemaVersion: 1, Data: []byte(`{"region":"eu"}`)},
+		{Name: "base", Type: "geo_rule", Version: 2, SchemaVersion: 1, Data: []byte(`{"pct":10}`)},
+		{Name: "other", Type: "geo_rule", Version: 1, SchemaVersion: 1, Data: []byte(`{"region":"us"}`)},
+	}
+	items := []model.MigrationItem{
+		{Name: "base", FromVersion: 2, Data: json.RawMessage(`{"percent":10}`), Meta: model.VersionMeta{SchemaVersion: 1}},
+		{Name: "child", FromVersion: 4, Data: json.RawMessage(`{"region":"eu"}`), Meta: model.VersionMeta{Extends: "base", BaseVersion: 2, SchemaVersion: 1}},
+	}
+
+	type input struct {
+		dryRun bool
+		stored []model.RemoteConfig
+	}
+
+	cases := []struct {
+		name     string
+		in       input
+		valErr   error
+		mockFunc func(m *repoMock.MockIRepo)
+		res      []model.MigrationResult
+		err      error
+	}{
+		{
+			name: "when repo fails should return error",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return(nil, errors.New("db down"))
+			},
+			err: errors.New("db down"),
+		},
+		{
+			name: "when dry run should report changed configs without writing",
+			in:   input{dryRun: true, stored: stored},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return(stored, nil)
+			},
+			res: []model.MigrationResult{
+				{Name: "base", Version: 2, Changed: true, Before: json.RawMessage(`{"pct":10}`), After: json.RawMessage(`{"percent":10}`)},
+				{Name: "child", Version: 4, Changed: true, Before: json.RawMessage(`{"region":"eu"}`), After: json.RawMessage(`{"region":"eu"}`)},
+				{Name: "other", Version: 1, Before: json.RawMessage(`{"region":"us"}`), After: json.RawMessage(`{"region":"us"}`)},
+			},
+		},
+		{
+			name: "when transform fails should report it and not write",
+			in: input{stored: []model.RemoteConfig{
+				{Name: "base", Type: "geo_rule", Version: 2, Data: []byte(`{"pct":"bad"}`)},
+			}},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return([]model.RemoteConfig{
+					{Name: "base", Type: "geo_rule", Version: 2, Data: []byte(`{"pct":"bad"}`)},
+				}, nil)
+			},
+			res: []model.MigrationResult{
+				{Name: "base", Version: 2, Before: json.RawMessage(`{"pct":"bad"}`), After: json.RawMessage(`{"pct":"bad"}`), Error: "invalid input: pct is not a number"},
+			},
+			err: errors.New("invalid input: migration fails for 1 of 1 configs"),
+		},
+		{
+			name:   "when result breaks the schema should report it and not write",
+			in:     input{stored: stored},
+			valErr: errors.New("percent is not allowed"),
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return(stored, nil)
+			},
+			res: []model.MigrationResult{
+				{Name: "base", Version: 2, Before: json.RawMessage(`{"pct":10}`), After: json.RawMessage(`{"pct":10}`), Error: "invalid input: percent is not allowed"},
+				{Name: "child", Version: 4, Before: json.RawMessage(`{"region":"eu"}`), After: json.RawMessage(`{"region":"eu"}`), Error: "invalid input: percent is not allowed"},
+				{Name: "other", Version: 1, Before: json.RawMessage(`{"region":"us"}`), After: json.RawMessage(`{"region":"us"}`)},
+			},
+			err: errors.New("invalid input: migration fails for 2 of 3 configs"),
+		},
+		{
+			name: "when applied should append changed configs in one batch",
+			in:   input{stored: stored},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return(stored, nil)
+				m.EXPECT().AppendMigration(gomock.Any(), "m1", items).Return([]model.RemoteConfig{
+					{Name: "base", Version: 3}, {Name: "child", Version: 5},
+				}, nil)
+			},
+			res: []model.MigrationResult{
+				{Name: "base", Version: 2, NewVersion: 3, Changed: true, Before: json.RawMessage(`{"pct":10}`), After: json.RawMessage(`{"percent":10}`)},
+				{Name: "child", Version: 4, NewVersion: 5, Changed: true, Before: json.RawMessage(`{"region":"eu"}`), After: json.RawMessage(`{"region":"eu"}`)},
+				{Name: "other", Version: 1, Before: json.RawMessage(`{"region":"us"}`), After: json.RawMessage(`{"region":"us"}`)},
+			},
+		},
+		{
+			name: "when applied before should return ErrAlreadyExists",
+			in:   input{stored: stored},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return(stored, nil)
+				m.EXPECT().AppendMigration(gomock.Any(), "m1", items).Return(nil, repository.ErrAlreadyExists)
+			},
+			err: errors.New("already exists: migration m1 was applied before"),
+		},
+		{
+			name: "when nothing changes and applied before should return ErrAlreadyExists",
+			in:   input{stored: stored[2:]},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return(stored[2:], nil)
+				m.EXPECT().AppendMigration(gomock.Any(), "m1", []model.MigrationItem{}).Return(nil, repository.ErrAlreadyExists)
+			},
+			err: errors.New("already exists: migration m1 was applied before"),
+		},
+		{
+			name: "when a config moved on should return ErrConflict",
+			in:   input{stored: stored},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByType(gomock.Any(), "geo_rule").Return(stored, nil)
+				m.EXPECT().AppendMigration(gomock.Any(), "m1", items).Return(nil, repository.ErrConflict)
+			},
+			err: errors.New("conflict: configs changed while the migration ran"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo, validator: stubValidator{err: tc.valErr, version: 1}, crypter: fieldcrypt.New(nil), resolver: secretref.NewResolver(), renderer: render.NewRenderer(nil)}
+
+			got, err := svc.Migrate(context.Background(), "geo_rule", "m1", renamePct, tc.in.dryRun)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
+
+func Test_basesFirst(t *testing.T) {
+	got := basesFirst([]model.RemoteConfig{
+		{Name: "a", Extends: "c"},
+		{Name: "b"},
+		{Name: "c", Extends: "b"},
+		{Name: "d", Extends: "missing"},
+	})
+	names := make([]string, 0, len(got))
+	for _, c := range got {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"b", "c", "a", "d"}, names)
+}
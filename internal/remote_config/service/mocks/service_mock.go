@@ -37,33 +37,63 @@ func (m *MockIService) EXPECT() *MockIServiceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockIService) Create(ctx context.Context, schemaType, name string, data json.RawMessage) (model.RemoteConfig, error) {
+func (m *MockIService) Create(ctx context.Context, schemaType, name string, data json.RawMessage, extends string) (model.RemoteConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, schemaType, name, data)
+	ret := m.ctrl.Call(m, "Create", ctx, schemaType, name, data, extends)
 	ret0, _ := ret[0].(model.RemoteConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIServiceMockRecorder) Create(ctx, schemaType, name, data interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) Create(ctx, schemaType, name, data, extends interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIService)(nil).Create), ctx, schemaType, name, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIService)(nil).Create), ctx, schemaType, name, data, extends)
+}
+
+// DryRun mocks base method.
+func (m *MockIService) DryRun(ctx context.Context, schemaType, name string, data json.RawMessage, extends *string) (model.DryRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRun", ctx, schemaType, name, data, extends)
+	ret0, _ := ret[0].(model.DryRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DryRun indicates an expected call of DryRun.
+func (mr *MockIServiceMockRecorder) DryRun(ctx, schemaType, name, data, extends interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRun", reflect.TypeOf((*MockIService)(nil).DryRun), ctx, schemaType, name, data, extends)
+}
+
+// EffectiveByType mocks base method.
+func (m *MockIService) EffectiveByType(ctx context.Context, schemaType string) ([]model.RemoteConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EffectiveByType", ctx, schemaType)
+	ret0, _ := ret[0].([]model.RemoteConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EffectiveByType indicates an expected call of EffectiveByType.
+func (mr *MockIServiceMockRecorder) EffectiveByType(ctx, schemaType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EffectiveByType", reflect.TypeOf((*MockIService)(nil).EffectiveByType), ctx, schemaType)
 }
 
 // Get mocks base method.
-func (m *MockIService) Get(ctx context.Context, name string, version *int) (model.RemoteConfig, error) {
+func (m *MockIService) Get(ctx context.Context, name string, version *int, opts model.ReadOptions) (model.RemoteConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, name, version)
+	ret := m.ctrl.Call(m, "Get", ctx, name, version, opts)
 	ret0, _ := ret[0].(model.RemoteConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockIServiceMockRecorder) Get(ctx, name, version interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) Get(ctx, name, version, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIService)(nil).Get), ctx, name, version)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIService)(nil).Get), ctx, name, version, opts)
 }
 
 // ListVersions mocks base method.
@@ -81,6 +111,36 @@ func (mr *MockIServiceMockRecorder) ListVersions(ctx, name interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersions", reflect.TypeOf((*MockIService)(nil).ListVersions), ctx, name)
 }
 
+// Migrate mocks base method.
+func (m *MockIService) Migrate(ctx context.Context, schemaType, migrationID string, fn model.MigrateFunc, dryRun bool) ([]model.MigrationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrate", ctx, schemaType, migrationID, fn, dryRun)
+	ret0, _ := ret[0].([]model.MigrationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Migrate indicates an expected call of Migrate.
+func (mr *MockIServiceMockRecorder) Migrate(ctx, schemaType, migrationID, fn, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockIService)(nil).Migrate), ctx, schemaType, migrationID, fn, dryRun)
+}
+
+// Reencrypt mocks base method.
+func (m *MockIService) Reencrypt(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reencrypt", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reencrypt indicates an expected call of Reencrypt.
+func (mr *MockIServiceMockRecorder) Reencrypt(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reencrypt", reflect.TypeOf((*MockIService)(nil).Reencrypt), ctx)
+}
+
 // Rollback mocks base method.
 func (m *MockIService) Rollback(ctx context.Context, name string, version int) (model.RemoteConfig, error) {
 	m.ctrl.T.Helper()
@@ -96,17 +156,32 @@ func (mr *MockIServiceMockRecorder) Rollback(ctx, name, version interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockIService)(nil).Rollback), ctx, name, version)
 }
 
+// Snapshot mocks base method.
+func (m *MockIService) Snapshot(ctx context.Context, types []string) ([]model.RemoteConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, types)
+	ret0, _ := ret[0].([]model.RemoteConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockIServiceMockRecorder) Snapshot(ctx, types interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockIService)(nil).Snapshot), ctx, types)
+}
+
 // Update mocks base method.
-func (m *MockIService) Update(ctx context.Context, name string, data json.RawMessage) (model.RemoteConfig, error) {
+func (m *MockIService) Update(ctx context.Context, name string, data json.RawMessage, extends *string) (model.RemoteConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, name, data)
+	ret := m.ctrl.Call(m, "Update", ctx, name, data, extends)
 	ret0, _ := ret[0].(model.RemoteConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockIServiceMockRecorder) Update(ctx, name, data interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) Update(ctx, name, data, extends interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIService)(nil).Update), ctx, name, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIService)(nil).Update), ctx, name, data, extends)
 }
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"context"
+	"encoding/json"
+)
+
+// Reencrypt re-seals every secret field of every stored version with the
+// active key so retired keys can be dropped from the keyring.
+func (s service) Reencrypt(ctx context.Context) (int, error) {
+	return s.repo.Rewrite(ctx, func(cfg model.RemoteConfig) (json.RawMessage, bool, error) {
+		paths, err := s.validator.SecretFields(ctx, cfg.Type, cfg.SchemaVersion)
+		if err != nil {
+			return nil, false, err
+		}
+		return s.crypter.Rotate(cfg.Name, paths, cfg.Data)
+	})
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/repository"
+	repoMock "configuration-management-service/internal/remote_config/repository/mocks"
+	"configuration-management-service/pkg/keyring"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Reencrypt(t *testing.T) {
+	keys := map[string][]byte{"old": bytes.Repeat([]byte{1}, 32), "new": bytes.Repeat([]byte{2}, 32)}
+	oldKR, _ := keyring.New("old", keys)
+	newKR, _ := keyring.New("new", keys)
+	paths := []string{"/headers"}
+
+	sealed, err := fieldcrypt.New(oldKR).Seal("pay", paths, json.RawMessage(`{"headers":{"a":"b"}}`), nil)
+	assert.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repoMock.NewMockIRepo(ctrl)
+	repo.EXPECT().Rewrite(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn repository.RewriteFunc) (int, error) {
+			out, changed, err := fn(model.RemoteConfig{Name: "pay", Type: "service_client", Version: 1, Data: sealed})
+			assert.NoError(t, err)
+			assert.True(t, changed)
+			assert.Contains(t, string(out), "enc:v1:new:")
+			return 1, nil
+		})
+
+	svc := service{repo: repo, validator: stubValidator{secrets: paths}, crypter: fieldcrypt.New(newKR)}
+	n, err := svc.Reencrypt(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
@@ -23,12 +23,31 @@ func (s service) Rollback(ctx context.Context, name string, version int) (model.
 		return model.RemoteConfig{}, err
 	}
 
-	cfg, err := s.repo.Append(ctx, name, target.Data)
+	// The restored version keeps its pinned base, so its effective value is
+	// exactly the one it had back then.
+	effective, err := s.effective(ctx, target, s.revealedLayer(ctx))
+	if err != nil {
+		return model.RemoteConfig{}, err
+	}
+	// The schema may have evolved since the target was written.
+	v, err := s.validateRendered(ctx, target.Type, effective)
+	if err != nil {
+		return model.RemoteConfig{}, err
+	}
+	checked, err := s.checkDependents(ctx, name, effective)
+	if err != nil {
+		return model.RemoteConfig{}, err
+	}
+
+	latest, err := s.repo.Latest(ctx, name)
+	if err != nil {
+		return model.RemoteConfig{}, err
+	}
+
+	meta := model.VersionMeta{Extends: target.Extends, BaseVersion: target.BaseVersion, SchemaVersion: v.schemaVersion, Layer: v.layer}
+	cfg, err := s.appendVersion(ctx, name, latest.Version, target.Data, meta, checked)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return model.RemoteConfig{}, ErrNotFound
-		}
 		return model.RemoteConfig{}, err
 	}
-	return cfg, nil
+	return s.open(ctx, cfg, false)
 }
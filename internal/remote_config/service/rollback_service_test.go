@@ -1,8 +1,15 @@
 package service
 
 import (
+	"bytes"
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/render"
 	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/internal/remote_config/secretref"
+	"configuration-management-service/pkg/keyring"
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"configuration-management-service/internal/remote_config/model"
@@ -23,6 +30,7 @@ func Test_service_Rollback(t *testing.T) {
 		cfgName  string
 		version  int
 		mockFunc func(m *repoMock.MockIRepo)
+		valErr   error
 		ex       exRes
 	}{
 		{
@@ -41,13 +49,25 @@ func Test_service_Rollback(t *testing.T) {
 			},
 			ex: exRes{res: model.RemoteConfig{}, err: ErrNotFound},
 		},
+		{
+			name:    "when target no longer valid against latest schema should return ErrInvalidInput",
+			cfgName: "key",
+			version: 2,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().ByVersion(gomock.Any(), "key", 2).Return(model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 2, Data: []byte(`{"a":1}`)}, nil)
+			},
+			valErr: errors.New("(root): enabled is required"),
+			ex:     exRes{res: model.RemoteConfig{}, err: ErrInvalidInput},
+		},
 		{
 			name:    "when append not found ErrNotFound should return",
 			cfgName: "key",
 			version: 2,
 			mockFunc: func(m *repoMock.MockIRepo) {
 				m.EXPECT().ByVersion(gomock.Any(), "key", 2).Return(model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 2, Data: []byte(`{"a":1}`)}, nil)
-				m.EXPECT().Append(gomock.Any(), "key", []byte(`{"a":1}`)).Return(model.RemoteConfig{}, repository.ErrNotFound)
+				m.EXPECT().Dependents(gomock.Any(), "key").Return(nil, nil).Times(2)
+				m.EXPECT().Latest(gomock.Any(), "key").Return(model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 4}, nil)
+				m.EXPECT().AppendBatch(gomock.Any(), []model.MigrationItem{{Name: "key", FromVersion: 4, Data: []byte(`{"a":1}`)}}).Return(nil, repository.ErrNotFound)
 			},
 			ex: exRes{res: model.RemoteConfig{}, err: ErrNotFound},
 		},
@@ -57,7 +77,10 @@ func Test_service_Rollback(t *testing.T) {
 			version: 2,
 			mockFunc: func(m *repoMock.MockIRepo) {
 				m.EXPECT().ByVersion(gomock.Any(), "key", 2).Return(model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 2, Data: []byte(`{"a":1}`)}, nil)
-				m.EXPECT().Append(gomock.Any(), "key", []byte(`{"a":1}`)).Return(model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 3, Data: []byte(`{"a":1}`)}, nil)
+				m.EXPECT().Dependents(gomock.Any(), "key").Return(nil, nil).Times(2)
+				m.EXPECT().Latest(gomock.Any(), "key").Return(model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 2}, nil)
+				m.EXPECT().AppendBatch(gomock.Any(), []model.MigrationItem{{Name: "key", FromVersion: 2, Data: []byte(`{"a":1}`)}}).
+					Return([]model.RemoteConfig{{Name: "key", Type: "feature_toggle", Version: 3, Data: []byte(`{"a":1}`)}}, nil)
 			},
 			ex: exRes{res: model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 3, Data: []byte(`{"a":1}`)}, err: nil},
 		},
@@ -70,11 +93,57 @@ func Test_service_Rollback(t *testing.T) {
 
 			repo := repoMock.NewMockIRepo(ctrl)
 			tc.mockFunc(repo)
-			svc := service{repo: repo}
+			svc := service{repo: repo, validator: stubValidator{err: tc.valErr}, crypter: fieldcrypt.New(nil), resolver: secretref.NewResolver(), renderer: render.NewRenderer(nil)}
 
 			got, err := svc.Rollback(context.Background(), tc.cfgName, tc.version)
-			assert.Equal(t, tc.ex.err, err)
+			assert.ErrorIs(t, err, tc.ex.err)
 			assert.Equal(t, tc.ex.res, got)
 		})
 	}
 }
+
+// numericPIN rejects data whose pin is not a number, as a schema with an
+// integer x-secret field would.
+type numericPIN struct {
+	stubValidator
+}
+
+func (v numericPIN) Validate(ctx context.Context, schemaType string, data json.RawMessage) (int, error) {
+	var doc struct {
+		PIN any `json:"pin"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return 0, err
+	}
+	if _, ok := doc.PIN.(float64); !ok {
+		return 0, errors.New("pin: Invalid type. Expected: integer, given: string")
+	}
+	return 1, nil
+}
+
+func Test_service_Rollback_NumericSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	kr, _ := keyring.New("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	crypter := fieldcrypt.New(kr)
+	paths := []string{"/pin"}
+	target, err := crypter.Seal("atm", paths, json.RawMessage(`{"pin":1234}`), nil)
+	assert.NoError(t, err)
+	child, err := crypter.Seal("atm-eu", paths, json.RawMessage(`{"pin":4321}`), nil)
+	assert.NoError(t, err)
+
+	repo := repoMock.NewMockIRepo(ctrl)
+	repo.EXPECT().ByVersion(gomock.Any(), "atm", 1).Return(model.RemoteConfig{Name: "atm", Type: "service_client", Version: 1, Data: target}, nil)
+	repo.EXPECT().Dependents(gomock.Any(), "atm").
+		Return([]model.RemoteConfig{{Name: "atm-eu", Type: "service_client", Version: 3, Extends: "atm", BaseVersion: 2, Data: child}}, nil).Times(2)
+	repo.EXPECT().Dependents(gomock.Any(), "atm-eu").Return(nil, nil).Times(2)
+	repo.EXPECT().Latest(gomock.Any(), "atm").Return(model.RemoteConfig{Name: "atm", Type: "service_client", Version: 2}, nil)
+	repo.EXPECT().AppendBatch(gomock.Any(), gomock.Any()).
+		Return([]model.RemoteConfig{{Name: "atm", Type: "service_client", Version: 3, Data: target}}, nil)
+	svc := service{repo: repo, validator: numericPIN{stubValidator{secrets: paths}}, crypter: crypter, resolver: secretref.NewResolver(), renderer: render.NewRenderer(nil)}
+
+	got, err := svc.Rollback(context.Background(), "atm", 1)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"pin":"[REDACTED]"}`, string(got.Data))
+}
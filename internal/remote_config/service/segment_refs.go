@@ -0,0 +1,88 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const typeSegment = "segment"
+
+// segmentRef is a segment name found in config data, with its pointer.
+type segmentRef struct {
+	pointer string
+	name    string
+}
+
+// segmentRefs returns the segments data of schemaType references:
+// feature_toggle rules and the experiment_config audience name them.
+func segmentRefs(schemaType string, data json.RawMessage) ([]segmentRef, error) {
+	var refs []segmentRef
+	switch schemaType {
+	case "feature_toggle":
+		var doc struct {
+			Rules []struct {
+				Segments []string `json:"segments"`
+			} `json:"rules"`
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		for i, r := range doc.Rules {
+			for j, name := range r.Segments {
+				refs = append(refs, segmentRef{pointer: "/rules/" + strconv.Itoa(i) + "/segments/" + strconv.Itoa(j), name: name})
+			}
+		}
+	case "experiment_config":
+		var doc struct {
+			Audience struct {
+				Segments []string `json:"segments"`
+			} `json:"audience"`
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		for j, name := range doc.Audience.Segments {
+			refs = append(refs, segmentRef{pointer: "/audience/segments/" + strconv.Itoa(j), name: name})
+		}
+	}
+	return refs, nil
+}
+
+// checkSegments rejects data that references a segment which does not
+// exist, or a config that is not a segment.
+func (s service) checkSegments(ctx context.Context, schemaType string, data json.RawMessage) error {
+	refs, err := segmentRefs(schemaType, data)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+
+	var violations []model.Violation
+	for _, ref := range refs {
+		at := strings.ReplaceAll(strings.TrimPrefix(ref.pointer, "/"), "/", ".")
+		cfg, err := s.repo.Latest(ctx, ref.name)
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			violations = append(violations, model.Violation{
+				Pointer: ref.pointer, Keyword: "segmentExists", Actual: ref.name,
+				Message: fmt.Sprintf("%s: segment %q does not exist", at, ref.name),
+			})
+		case err != nil:
+			return err
+		case cfg.Type != typeSegment:
+			violations = append(violations, model.Violation{
+				Pointer: ref.pointer, Keyword: "segmentType", Expected: typeSegment, Actual: ref.name,
+				Message: fmt.Sprintf("%s: %q is of type %s, not segment", at, ref.name, cfg.Type),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return &ValidationError{Report: model.ValidationReport{Violations: violations}}
+	}
+	return nil
+}
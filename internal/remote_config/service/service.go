@@ -1,36 +1,185 @@
 package service
 
 import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
 	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/render"
 	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/internal/remote_config/secretref"
 	"configuration-management-service/internal/remote_config/validator"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
 	ErrInvalidInput  = errors.New("invalid input")
+	ErrConflict      = errors.New("conflict")
 )
 
+// ValidationError rejects data that breaks its schema. It matches
+// ErrInvalidInput and carries every violation for the caller to fix at once.
+type ValidationError struct {
+	Report model.ValidationReport
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Report.Violations))
+	for _, v := range e.Report.Violations {
+		msgs = append(msgs, v.Message)
+	}
+	if e.Report.Config != "" {
+		return fmt.Sprintf("%s: dependent %s: %s", ErrInvalidInput, e.Report.Config, strings.Join(msgs, "; "))
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidInput, strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Unwrap() error { return ErrInvalidInput }
+
 type IService interface {
-	Create(ctx context.Context, schemaType, name string, data json.RawMessage) (model.RemoteConfig, error)
-	Update(ctx context.Context, name string, data json.RawMessage) (model.RemoteConfig, error)
-	Get(ctx context.Context, name string, version *int) (model.RemoteConfig, error)
+	Create(ctx context.Context, schemaType, name string, data json.RawMessage, extends string) (model.RemoteConfig, error)
+	Update(ctx context.Context, name string, data json.RawMessage, extends *string) (model.RemoteConfig, error)
+	Get(ctx context.Context, name string, version *int, opts model.ReadOptions) (model.RemoteConfig, error)
 	ListVersions(ctx context.Context, name string) ([]model.RemoteConfig, error)
 	Rollback(ctx context.Context, name string, version int) (model.RemoteConfig, error)
+	Reencrypt(ctx context.Context) (int, error)
+	EffectiveByType(ctx context.Context, schemaType string) ([]model.RemoteConfig, error)
+	Migrate(ctx context.Context, schemaType, migrationID string, fn model.MigrateFunc, dryRun bool) ([]model.MigrationResult, error)
+	DryRun(ctx context.Context, schemaType, name string, data json.RawMessage, extends *string) (model.DryRun, error)
+	Snapshot(ctx context.Context, types []string) ([]model.RemoteConfig, error)
 }
 
 type service struct {
 	repo      repository.IRepo
 	validator validator.ISchemaValidator
+	crypter   fieldcrypt.ICrypter
+	resolver  secretref.IResolver
+	renderer  render.IRenderer
+	presented *presentCache
 }
 
-func NewService(repo repository.IRepo, schemaValidator validator.ISchemaValidator) IService {
+func NewService(
+	repo repository.IRepo,
+	schemaValidator validator.ISchemaValidator,
+	crypter fieldcrypt.ICrypter,
+	resolver secretref.IResolver,
+	renderer render.IRenderer,
+) IService {
 	return service{
 		repo:      repo,
 		validator: schemaValidator,
+		crypter:   crypter,
+		resolver:  resolver,
+		renderer:  renderer,
+		presented: newPresentCache(),
+	}
+}
+
+// plan is a validated write: the own data Create or Update stores, before
+// its secret fields are sealed.
+type plan struct {
+	schemaType string
+	// data has the write-time schema defaults applied.
+	data     json.RawMessage
+	meta     model.VersionMeta
+	warnings []string
+	// checked holds the dependents an update validated, with what the
+	// validation found for each.
+	checked map[string]validated
+}
+
+// seal encrypts the secret fields of data, as declared by the schema version
+// that validated it, before it is written. prev is the stored data of the
+// version being replaced (nil on create); redacted leaves keep its values.
+func (s service) seal(ctx context.Context, schemaType string, schemaVersion int, name string, data, prev json.RawMessage) (json.RawMessage, error) {
+	paths, err := s.validator.SecretFields(ctx, schemaType, schemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.crypter.Seal(name, paths, data, prev)
+	if errors.Is(err, fieldcrypt.ErrInvalidSecret) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+	return sealed, err
+}
+
+// validated is what validateRendered learned about the data it accepted.
+type validated struct {
+	schemaVersion int
+	// layer is the range the data takes as an active experiment, checked
+	// when the version is written.
+	layer *model.Layer
+}
+
+// validateRendered renders variable templates in the effective data with the
+// current variables and validates the result against the latest schema of
+// the type, and checks that the segments it references exist. It returns
+// the schema version that accepted the data and the layer range it takes.
+func (s service) validateRendered(ctx context.Context, schemaType string, data json.RawMessage) (validated, error) {
+	rendered, _, err := s.renderer.Render(ctx, data)
+	if err != nil {
+		if errors.Is(err, render.ErrTemplate) {
+			return validated{}, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+		}
+		return validated{}, err
+	}
+	version, err := s.validator.Validate(ctx, schemaType, rendered)
+	if err != nil {
+		var verr *validator.ValidationError
+		if errors.As(err, &verr) {
+			return validated{}, &ValidationError{Report: model.ValidationReport{Violations: verr.Violations}}
+		}
+		return validated{}, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+	if err := s.checkSegments(ctx, schemaType, rendered); err != nil {
+		return validated{}, err
+	}
+	layer, err := activeLayer(schemaType, rendered)
+	if err != nil {
+		return validated{}, err
+	}
+	return validated{schemaVersion: version, layer: layer}, nil
+}
+
+// writeDefaults stores the schema defaults with data when the type's latest
+// schema asks for it at write time. Configs with a base get them through
+// the base instead, since their own defaults would override its values.
+func (s service) writeDefaults(ctx context.Context, schemaType string, data json.RawMessage) (json.RawMessage, error) {
+	filled, _, err := s.validator.ApplyDefaults(ctx, schemaType, 0, validator.DefaultsWrite, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+	return filled, nil
+}
+
+// secretWarnings reports references no secret provider can resolve. They do
+// not block the write since the secret may be provisioned later.
+func (s service) secretWarnings(ctx context.Context, data json.RawMessage) ([]string, error) {
+	missing, err := s.resolver.Unresolved(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, ref := range missing {
+		out = append(out, "unresolved secret reference: ${secret:"+ref+"}")
+	}
+	return out, nil
+}
+
+// open decrypts or redacts the secret fields of a stored config.
+func (s service) open(ctx context.Context, cfg model.RemoteConfig, reveal bool) (model.RemoteConfig, error) {
+	paths, err := s.validator.SecretFields(ctx, cfg.Type, cfg.SchemaVersion)
+	if err != nil {
+		return model.RemoteConfig{}, err
+	}
+	data, err := s.crypter.Open(cfg.Name, paths, cfg.Data, reveal)
+	if err != nil {
+		return model.RemoteConfig{}, err
 	}
+	cfg.Data = data
+	return cfg, nil
 }
@@ -1,12 +1,132 @@
 package service
 
 import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/render"
+	repoMock "configuration-management-service/internal/remote_config/repository/mocks"
+	"configuration-management-service/internal/remote_config/secretref"
 	"configuration-management-service/internal/remote_config/validator"
+	"context"
 	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
-type stubValidator struct{ err error }
+type stubValidator struct {
+	err     error
+	secrets []string
+	version int
+	// defaults is the x-defaults mode of the stubbed schema; when it
+	// matches, ApplyDefaults adds "inclusive": true.
+	defaults string
+}
+
+func (s stubValidator) Validate(ctx context.Context, schemaType string, data json.RawMessage) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	return s.version, nil
+}
+
+func (s stubValidator) Invalidate(schemaType string) {}
+
+func (s stubValidator) SecretFields(ctx context.Context, schemaType string, version int) ([]string, error) {
+	return s.secrets, nil
+}
 
-func (s stubValidator) Validate(schemaType string, data json.RawMessage) error { return s.err }
+func (s stubValidator) ApplyDefaults(ctx context.Context, schemaType string, version int, mode string, data json.RawMessage) (json.RawMessage, []string, error) {
+	if s.defaults == "" || s.defaults != mode {
+		return data, nil, nil
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if _, ok := doc["inclusive"]; ok {
+		return data, nil, nil
+	}
+	doc["inclusive"] = true
+	out, err := json.Marshal(doc)
+	return out, []string{"/inclusive"}, err
+}
 
 var _ validator.ISchemaValidator = (*stubValidator)(nil)
+
+func Test_service_validateRendered(t *testing.T) {
+	violations := []model.Violation{
+		{Pointer: "/enabled", Keyword: "required", Message: "(root): enabled is required"},
+		{Pointer: "/tags/1", Keyword: "type", Expected: "string", Actual: float64(1), Message: "tags.1: Invalid type. Expected: string, given: integer"},
+	}
+
+	cases := []struct {
+		name   string
+		valErr error
+		report *model.ValidationReport
+	}{
+		{
+			name:   "when validator reports violations should return ValidationError with all of them",
+			valErr: &validator.ValidationError{Violations: violations},
+			report: &model.ValidationReport{Violations: violations},
+		},
+		{
+			name:   "when validator fails otherwise should return plain ErrInvalidInput",
+			valErr: validator.ErrUnknownType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := service{validator: stubValidator{err: tc.valErr}, renderer: render.NewRenderer(nil)}
+
+			_, err := svc.validateRendered(context.Background(), "feature_toggle", json.RawMessage(`{"tags":["a",1]}`))
+			assert.ErrorIs(t, err, ErrInvalidInput)
+
+			var verr *ValidationError
+			if tc.report == nil {
+				assert.False(t, errors.As(err, &verr))
+				return
+			}
+			assert.True(t, errors.As(err, &verr))
+			assert.Equal(t, *tc.report, verr.Report)
+		})
+	}
+}
+
+func Test_service_Defaults(t *testing.T) {
+	stored := model.RemoteConfig{Name: "lat", Type: "threshold_policy", Version: 1, SchemaVersion: 1, Data: json.RawMessage(`{"metric":"p95"}`)}
+
+	t.Run("when write mode should store defaults with configs that have no base", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		repo := repoMock.NewMockIRepo(ctrl)
+		repo.EXPECT().Create(gomock.Any(), "threshold_policy", "lat", json.RawMessage(`{"inclusive":true,"metric":"p95"}`), model.VersionMeta{}).
+			Return(model.RemoteConfig{Name: "lat", Type: "threshold_policy", Version: 1, Data: json.RawMessage(`{"inclusive":true,"metric":"p95"}`)}, nil)
+		svc := service{repo: repo, validator: stubValidator{defaults: validator.DefaultsWrite}, crypter: fieldcrypt.New(nil), resolver: secretref.NewResolver(), renderer: render.NewRenderer(nil)}
+
+		got, err := svc.Create(context.Background(), "threshold_policy", "lat", json.RawMessage(`{"metric":"p95"}`), "")
+		assert.NoError(t, err)
+		assert.Nil(t, got.Defaulted)
+	})
+
+	t.Run("when read mode should fill resolved reads and report the fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		repo := repoMock.NewMockIRepo(ctrl)
+		repo.EXPECT().Latest(gomock.Any(), "lat").Return(stored, nil).Times(2)
+		svc := service{repo: repo, validator: stubValidator{defaults: validator.DefaultsRead}, crypter: fieldcrypt.New(nil), resolver: secretref.NewResolver(), renderer: render.NewRenderer(nil)}
+
+		resolved, err := svc.Get(context.Background(), "lat", nil, model.ReadOptions{})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"metric":"p95","inclusive":true}`, string(resolved.Data))
+		assert.Equal(t, []string{"/inclusive"}, resolved.Defaulted)
+
+		raw, err := svc.Get(context.Background(), "lat", nil, model.ReadOptions{View: model.ViewRaw})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"metric":"p95"}`, string(raw.Data))
+		assert.Nil(t, raw.Defaulted)
+	})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Snapshot returns the resolved view of the latest version of every config
+// of the given types, as Get serves it with secrets redacted. The versions
+// are read in one statement and every config renders against the same
+// variables, so the set is consistent even while configs or variables are
+// being written. Only the versions missing from the presented cache are
+// presented, so a read that finds every version cached costs the one
+// statement.
+func (s service) Snapshot(ctx context.Context, types []string) ([]model.RemoteConfig, error) {
+	latest, err := s.repo.LatestByTypes(ctx, types)
+	if err != nil {
+		return nil, err
+	}
+	var pinned *service
+	for i, cfg := range latest {
+		if hit, ok := s.presented.get(cfg); ok {
+			latest[i] = hit
+			continue
+		}
+		if pinned == nil {
+			p := s
+			if p.renderer, err = s.renderer.Pin(ctx); err != nil {
+				return nil, err
+			}
+			pinned = &p
+		}
+		if latest[i], err = pinned.present(ctx, cfg, model.ReadOptions{}); err != nil {
+			return nil, fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		s.presented.put(latest[i])
+	}
+	return latest, nil
+}
+
+// presentCache holds the resolved view of the latest version of each config
+// by name. A version is immutable, and so is the base version it extends,
+// so its view only changes with the variables it renders: views that use
+// variables or carry warnings are not cached.
+type presentCache struct {
+	byName sync.Map
+}
+
+func newPresentCache() *presentCache {
+	return &presentCache{}
+}
+
+// get returns the cached view of the version of cfg.
+func (c *presentCache) get(cfg model.RemoteConfig) (model.RemoteConfig, bool) {
+	if c == nil {
+		return model.RemoteConfig{}, false
+	}
+	hit, ok := c.byName.Load(cfg.Name)
+	if !ok || hit.(model.RemoteConfig).Version != cfg.Version {
+		return model.RemoteConfig{}, false
+	}
+	return hit.(model.RemoteConfig), true
+}
+
+func (c *presentCache) put(cfg model.RemoteConfig) {
+	if c == nil || len(cfg.Variables) > 0 || len(cfg.Warnings) > 0 {
+		return
+	}
+	c.byName.Store(cfg.Name, cfg)
+}
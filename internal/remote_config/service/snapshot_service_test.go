@@ -0,0 +1,130 @@
+package service
+
+import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/render"
+	"configuration-management-service/internal/remote_config/secretref"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/model"
+	repoMock "configuration-management-service/internal/remote_config/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Snapshot(t *testing.T) {
+	types := []string{"feature_toggle", "experiment_config"}
+
+	cases := []struct {
+		name     string
+		vars     render.VariableSource
+		mockFunc func(m *repoMock.MockIRepo)
+		res      []model.RemoteConfig
+		err      string
+	}{
+		{
+			name: "when repo fails should return error",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByTypes(gomock.Any(), types).Return(nil, errors.New("db down"))
+			},
+			err: "db down",
+		},
+		{
+			name: "when variables cannot be loaded should return error",
+			vars: render.SourceFunc(func(context.Context) (map[string]render.Value, error) { return nil, errors.New("vars down") }),
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByTypes(gomock.Any(), types).Return([]model.RemoteConfig{
+					{Name: "search", Type: "experiment_config", Version: 1, Data: []byte(`{"active":true}`)},
+				}, nil)
+			},
+			err: "load variables: vars down",
+		},
+		{
+			name: "when configs extend a base and use variables should resolve each against one variable read",
+			vars: onceSource(t, map[string]render.Value{"market": {Version: 4, Value: "ID"}}),
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().LatestByTypes(gomock.Any(), types).Return([]model.RemoteConfig{
+					{Name: "checkout", Type: "feature_toggle", Version: 2, Extends: "base", BaseVersion: 1, Data: []byte(`{"tags":["{{ .vars.market }}"]}`)},
+					{Name: "search", Type: "experiment_config", Version: 1, Data: []byte(`{"experiment_key":"{{ .vars.market }}-search"}`)},
+				}, nil)
+				m.EXPECT().ByVersion(gomock.Any(), "base", 1).Return(model.RemoteConfig{Name: "base", Type: "feature_toggle", Version: 1, Data: []byte(`{"enabled":true}`)}, nil)
+			},
+			res: []model.RemoteConfig{
+				{Name: "checkout", Type: "feature_toggle", Version: 2, Extends: "base", BaseVersion: 1, Data: []byte(`{"enabled":true,"tags":["ID"]}`), Variables: map[string]int{"market": 4}},
+				{Name: "search", Type: "experiment_config", Version: 1, Data: []byte(`{"experiment_key":"ID-search"}`), Variables: map[string]int{"market": 4}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo, validator: stubValidator{}, crypter: fieldcrypt.New(nil), resolver: secretref.NewResolver(), renderer: render.NewRenderer(tc.vars)}
+
+			got, err := svc.Snapshot(context.Background(), types)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			if !assert.Len(t, got, len(tc.res)) {
+				return
+			}
+			for i := range tc.res {
+				assert.JSONEq(t, string(tc.res[i].Data), string(got[i].Data))
+				got[i].Data = tc.res[i].Data
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
+
+func Test_service_Snapshot_Cached(t *testing.T) {
+	types := []string{"feature_toggle"}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repoMock.NewMockIRepo(ctrl)
+	checkout := model.RemoteConfig{Name: "checkout", Type: "feature_toggle", Version: 2, Extends: "base", BaseVersion: 1, Data: []byte(`{"rollout_percentage":10}`)}
+	market := model.RemoteConfig{Name: "market", Type: "feature_toggle", Version: 1, Data: []byte(`{"tags":["{{ .vars.market }}"]}`)}
+	gomock.InOrder(
+		repo.EXPECT().LatestByTypes(gomock.Any(), types).Return([]model.RemoteConfig{checkout, market}, nil),
+		repo.EXPECT().ByVersion(gomock.Any(), "base", 1).Return(model.RemoteConfig{Name: "base", Type: "feature_toggle", Version: 1, Data: []byte(`{"enabled":true}`)}, nil),
+		repo.EXPECT().LatestByTypes(gomock.Any(), types).Return([]model.RemoteConfig{checkout, market}, nil),
+	)
+	reads := 0
+	vars := render.SourceFunc(func(context.Context) (map[string]render.Value, error) {
+		reads++
+		return map[string]render.Value{"market": {Version: reads, Value: fmt.Sprintf("m%d", reads)}}, nil
+	})
+	svc := service{repo: repo, validator: stubValidator{}, crypter: fieldcrypt.New(nil), resolver: secretref.NewResolver(), renderer: render.NewRenderer(vars), presented: newPresentCache()}
+
+	_, err := svc.Snapshot(context.Background(), types)
+	assert.NoError(t, err)
+	got, err := svc.Snapshot(context.Background(), types)
+	assert.NoError(t, err)
+
+	// The second read presents only the config that renders variables.
+	if assert.Len(t, got, 2) {
+		assert.JSONEq(t, `{"enabled":true,"rollout_percentage":10}`, string(got[0].Data))
+		assert.JSONEq(t, `{"tags":["m2"]}`, string(got[1].Data))
+	}
+}
+
+// onceSource serves vars and fails the test when read more than once.
+func onceSource(t *testing.T, vars map[string]render.Value) render.VariableSource {
+	read := false
+	return render.SourceFunc(func(context.Context) (map[string]render.Value, error) {
+		assert.False(t, read, "variables read twice")
+		read = true
+		return vars, nil
+	})
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"configuration-management-service/internal/remote_config/model"
 	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/pkg/jsonx"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,7 +11,9 @@ import (
 	"strings"
 )
 
-func (s service) Update(ctx context.Context, name string, data json.RawMessage) (model.RemoteConfig, error) {
+// Update appends a new version. extends == nil keeps the base of the latest
+// version; a pointer to "" detaches the config from its base.
+func (s service) Update(ctx context.Context, name string, data json.RawMessage, extends *string) (model.RemoteConfig, error) {
 	name = strings.TrimSpace(name)
 	if name == "" {
 		return model.RemoteConfig{}, ErrInvalidInput
@@ -27,16 +30,72 @@ func (s service) Update(ctx context.Context, name string, data json.RawMessage)
 		return model.RemoteConfig{}, err
 	}
 
-	if err := s.validator.Validate(latest.Type, data); err != nil {
-		return model.RemoteConfig{}, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	p, err := s.planUpdate(ctx, latest, data, extends)
+	if err != nil {
+		return model.RemoteConfig{}, err
 	}
 
-	cfg, err := s.repo.Append(ctx, name, data)
+	sealed, err := s.seal(ctx, p.schemaType, p.meta.SchemaVersion, name, p.data, latest.Data)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return model.RemoteConfig{}, ErrNotFound
-		}
 		return model.RemoteConfig{}, err
 	}
-	return cfg, nil
+
+	cfg, err := s.appendVersion(ctx, name, latest.Version, sealed, p.meta, p.checked)
+	if err != nil {
+		return model.RemoteConfig{}, err
+	}
+	cfg.Warnings = p.warnings
+	return s.open(ctx, cfg, false)
+}
+
+// planUpdate validates the version that would follow latest, dependents
+// included, without storing it.
+func (s service) planUpdate(ctx context.Context, latest model.RemoteConfig, data json.RawMessage, extends *string) (plan, error) {
+	if len(data) == 0 {
+		return plan{}, fmt.Errorf("%w: empty data", ErrInvalidInput)
+	}
+
+	base := latest.Extends
+	if extends != nil {
+		base = strings.TrimSpace(*extends)
+	}
+
+	var meta model.VersionMeta
+	effective := data
+	if base != "" {
+		b, err := s.loadBase(ctx, latest.Name, latest.Type, base)
+		if err != nil {
+			return plan{}, err
+		}
+		baseData, err := s.effective(ctx, b, s.revealedLayer(ctx))
+		if err != nil {
+			return plan{}, err
+		}
+		if effective, err = jsonx.MergeRaw(baseData, data); err != nil {
+			return plan{}, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+		}
+		meta = model.VersionMeta{Extends: base, BaseVersion: b.Version}
+	} else {
+		var err error
+		if data, err = s.writeDefaults(ctx, latest.Type, data); err != nil {
+			return plan{}, err
+		}
+		effective = data
+	}
+
+	v, err := s.validateRendered(ctx, latest.Type, effective)
+	if err != nil {
+		return plan{}, err
+	}
+	meta.SchemaVersion, meta.Layer = v.schemaVersion, v.layer
+	checked, err := s.checkDependents(ctx, latest.Name, effective)
+	if err != nil {
+		return plan{}, err
+	}
+
+	warnings, err := s.secretWarnings(ctx, data)
+	if err != nil {
+		return plan{}, err
+	}
+	return plan{schemaType: latest.Type, data: data, meta: meta, warnings: warnings, checked: checked}, nil
 }
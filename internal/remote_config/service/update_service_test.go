@@ -1,7 +1,10 @@
 package service
 
 import (
+	"configuration-management-service/internal/remote_config/fieldcrypt"
+	"configuration-management-service/internal/remote_config/render"
 	"configuration-management-service/internal/remote_config/repository"
+	"configuration-management-service/internal/remote_config/secretref"
 	"context"
 	"encoding/json"
 	"errors"
@@ -63,7 +66,8 @@ func Test_service_Update(t *testing.T) {
 			valErr:  nil,
 			mockFunc: func(m *repoMock.MockIRepo) {
 				m.EXPECT().Latest(gomock.Any(), "key").Return(model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 2}, nil)
-				m.EXPECT().Append(gomock.Any(), "key", json.RawMessage(`{"ok":true}`)).Return(model.RemoteConfig{}, repository.ErrNotFound)
+				m.EXPECT().Dependents(gomock.Any(), "key").Return(nil, nil).Times(2)
+				m.EXPECT().AppendBatch(gomock.Any(), []model.MigrationItem{{Name: "key", FromVersion: 2, Data: json.RawMessage(`{"ok":true}`)}}).Return(nil, repository.ErrNotFound)
 			},
 			ex: exRes{res: model.RemoteConfig{}, err: ErrNotFound},
 		},
@@ -74,7 +78,9 @@ func Test_service_Update(t *testing.T) {
 			valErr:  nil,
 			mockFunc: func(m *repoMock.MockIRepo) {
 				m.EXPECT().Latest(gomock.Any(), "key").Return(model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 2}, nil)
-				m.EXPECT().Append(gomock.Any(), "key", json.RawMessage(`{"ok":true}`)).Return(model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 3, Data: json.RawMessage(`{"ok":true}`)}, nil)
+				m.EXPECT().Dependents(gomock.Any(), "key").Return(nil, nil).Times(2)
+				m.EXPECT().AppendBatch(gomock.Any(), []model.MigrationItem{{Name: "key", FromVersion: 2, Data: json.RawMessage(`{"ok":true}`)}}).
+					Return([]model.RemoteConfig{{Name: "key", Type: "feature_toggle", Version: 3, Data: json.RawMessage(`{"ok":true}`)}}, nil)
 			},
 			ex: exRes{res: model.RemoteConfig{Name: "key", Type: "feature_toggle", Version: 3, Data: json.RawMessage(`{"ok":true}`)}, err: nil},
 		},
@@ -88,9 +94,9 @@ func Test_service_Update(t *testing.T) {
 			repo := repoMock.NewMockIRepo(ctrl)
 			tc.mockFunc(repo)
 
-			svc := service{repo: repo, validator: stubValidator{err: tc.valErr}}
+			svc := service{repo: repo, validator: stubValidator{err: tc.valErr}, crypter: fieldcrypt.New(nil), resolver: secretref.NewResolver(), renderer: render.NewRenderer(nil)}
 
-			got, err := svc.Update(context.Background(), tc.cfgName, tc.data)
+			got, err := svc.Update(context.Background(), tc.cfgName, tc.data, nil)
 			if tc.valErr != nil && errors.Is(err, ErrInvalidInput) {
 				assert.Error(t, err)
 			} else {
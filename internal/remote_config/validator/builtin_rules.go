@@ -0,0 +1,224 @@
+package validator
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/pkg/schedule"
+	"configuration-management-service/pkg/semver"
+	"configuration-management-service/pkg/targeting"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // zone checks must not depend on the host's zoneinfo
+)
+
+// BuiltinRules returns the semantic rules of the built-in config types.
+func BuiltinRules() Rules {
+	return Rules{
+		"schedule_rule":     {RuleFunc(cronRule), RuleFunc(timezoneRule), RuleFunc(windowsRule)},
+		"threshold_policy":  {RuleFunc(thresholdRule)},
+		"experiment_config": {RuleFunc(variantsRule), RuleFunc(minAppVersionRule), RuleFunc(layerRule)},
+		"feature_toggle":    {RuleFunc(toggleRulesRule)},
+		"segment":           {RuleFunc(segmentClausesRule)},
+	}
+}
+
+func cronRule(doc any) []model.Violation {
+	expr, ok := field(doc, "cron").(string)
+	if !ok {
+		return nil
+	}
+	if _, err := schedule.Parse(expr); err != nil {
+		return []model.Violation{{
+			Pointer: "/cron", Keyword: "cron", Expected: "5-field cron expression", Actual: expr,
+			Message: "cron: " + err.Error(),
+		}}
+	}
+	return nil
+}
+
+func timezoneRule(doc any) []model.Violation {
+	tz, ok := field(doc, "timezone").(string)
+	if !ok {
+		return nil
+	}
+	// "Local" loads the host zone, which is not a stable IANA name.
+	if _, err := time.LoadLocation(tz); err != nil || tz == "Local" {
+		return []model.Violation{{
+			Pointer: "/timezone", Keyword: "timezone", Expected: "IANA time zone", Actual: tz,
+			Message: fmt.Sprintf("timezone: %q is not a known IANA time zone", tz),
+		}}
+	}
+	return nil
+}
+
+func windowsRule(doc any) []model.Violation {
+	windows, _ := field(doc, "windows").([]any)
+	var out []model.Violation
+	for i, w := range windows {
+		start, okS := parseTime(field(w, "start"))
+		end, okE := parseTime(field(w, "end"))
+		if !okS || !okE || start.Before(end) {
+			continue
+		}
+		out = append(out, model.Violation{
+			Pointer: "/windows/" + strconv.Itoa(i), Keyword: "startBeforeEnd", Actual: w,
+			Message: fmt.Sprintf("windows.%d: start must be before end", i),
+		})
+	}
+	return out
+}
+
+func thresholdRule(doc any) []model.Violation {
+	lo, okLo := field(doc, "min").(float64)
+	hi, okHi := field(doc, "max").(float64)
+	if !okLo || !okHi || lo <= hi {
+		return nil
+	}
+	return []model.Violation{{
+		Pointer: "/min", Keyword: "minNotAboveMax", Expected: hi, Actual: lo,
+		Message: fmt.Sprintf("min: %v must not exceed max %v", lo, hi),
+	}}
+}
+
+func variantsRule(doc any) []model.Violation {
+	variants, ok := field(doc, "variants").([]any)
+	if !ok {
+		return nil
+	}
+	var (
+		out  []model.Violation
+		sum  float64
+		seen = map[string]int{}
+	)
+	for i, v := range variants {
+		if w, ok := field(v, "weight").(float64); ok {
+			sum += w
+		}
+		name, ok := field(v, "name").(string)
+		if !ok {
+			continue
+		}
+		if first, dup := seen[name]; dup {
+			out = append(out, model.Violation{
+				Pointer: "/variants/" + strconv.Itoa(i) + "/name", Keyword: "uniqueName", Actual: name,
+				Message: fmt.Sprintf("variants.%d.name: %q is already used by variants.%d", i, name, first),
+			})
+			continue
+		}
+		seen[name] = i
+	}
+	if math.Abs(sum-100) > 1e-9 {
+		out = append(out, model.Violation{
+			Pointer: "/variants", Keyword: "weightsSum", Expected: float64(100), Actual: sum,
+			Message: fmt.Sprintf("variants: weights must sum to 100, got %v", sum),
+		})
+	}
+	return out
+}
+
+func minAppVersionRule(doc any) []model.Violation {
+	v, ok := field(field(doc, "audience"), "min_app_version").(string)
+	if !ok || semver.Valid(v) {
+		return nil
+	}
+	return []model.Violation{{
+		Pointer: "/audience/min_app_version", Keyword: "semver", Expected: "semantic version", Actual: v,
+		Message: fmt.Sprintf("audience.min_app_version: %q is not a semantic version", v),
+	}}
+}
+
+func layerRule(doc any) []model.Violation {
+	layer := field(doc, "layer")
+	start, okS := field(layer, "start").(float64)
+	end, okE := field(layer, "end").(float64)
+	if !okS || !okE || start < end {
+		return nil
+	}
+	return []model.Violation{{
+		Pointer: "/layer", Keyword: "startBeforeEnd", Actual: layer,
+		Message: fmt.Sprintf("layer: start %v must be below end %v", start, end),
+	}}
+}
+
+func toggleRulesRule(doc any) []model.Violation {
+	rules, _ := field(doc, "rules").([]any)
+	var out []model.Violation
+	for i, r := range rules {
+		at := "/rules/" + strconv.Itoa(i)
+		_, serve := field(r, "serve").(bool)
+		_, rollout := field(r, "rollout_percentage").(float64)
+		if serve == rollout {
+			out = append(out, model.Violation{
+				Pointer: at, Keyword: "serveOrRollout", Actual: r,
+				Message: fmt.Sprintf("rules.%d: exactly one of serve and rollout_percentage is required", i),
+			})
+		}
+		clauses, _ := field(r, "clauses").([]any)
+		segments, _ := field(r, "segments").([]any)
+		if len(clauses) == 0 && len(segments) == 0 {
+			out = append(out, model.Violation{
+				Pointer: at, Keyword: "clausesOrSegments", Actual: r,
+				Message: fmt.Sprintf("rules.%d: clauses or segments is required", i),
+			})
+		}
+		if v := clausesViolation(clauses, at); v != nil {
+			out = append(out, *v)
+		}
+	}
+	return out
+}
+
+func segmentClausesRule(doc any) []model.Violation {
+	if v := clausesViolation(field(doc, "clauses"), ""); v != nil {
+		return []model.Violation{*v}
+	}
+	return nil
+}
+
+// clausesViolation compiles the clauses array found at the pointer at and
+// reports the first clause that does not compile.
+func clausesViolation(doc any, at string) *model.Violation {
+	items, _ := doc.([]any)
+	if len(items) == 0 {
+		return nil
+	}
+	clauses := make([]targeting.Clause, len(items))
+	for i, c := range items {
+		clauses[i].Attribute, _ = field(c, "attribute").(string)
+		clauses[i].Op, _ = field(c, "op").(string)
+		clauses[i].Values, _ = field(c, "values").([]any)
+	}
+	_, err := targeting.Compile(clauses)
+	var cerr *targeting.Error
+	if !errors.As(err, &cerr) {
+		return nil
+	}
+	pointer := at + "/clauses/" + strconv.Itoa(cerr.Clause)
+	var actual any = items[cerr.Clause]
+	if cerr.Value >= 0 {
+		pointer += "/values/" + strconv.Itoa(cerr.Value)
+		actual = clauses[cerr.Clause].Values[cerr.Value]
+	}
+	return &model.Violation{
+		Pointer: pointer, Keyword: "clause", Actual: actual,
+		Message: strings.ReplaceAll(strings.TrimPrefix(pointer, "/"), "/", ".") + ": " + cerr.Message,
+	}
+}
+
+// field returns the member name of an object, or nil.
+func field(doc any, name string) any {
+	obj, _ := doc.(map[string]any)
+	return obj[name]
+}
+
+func parseTime(v any) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
@@ -0,0 +1,155 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/schema/builtin"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinRules(t *testing.T) {
+	sv := NewSchemaValidator(StaticSource(builtin.Schemas()), BuiltinRules())
+
+	cases := []struct {
+		name   string
+		schema string
+		data   string
+		want   []model.Violation
+	}{
+		{
+			name:   "when schedule_rule valid should return nil",
+			schema: "schedule_rule",
+			data:   `{"active":true,"timezone":"Asia/Jakarta","cron":"@daily","windows":[{"start":"2025-01-01T00:00:00Z","end":"2025-01-01T01:00:00Z"}]}`,
+		},
+		{
+			name:   "when schedule_rule cron, zone and window invalid should report all three",
+			schema: "schedule_rule",
+			data:   `{"active":true,"timezone":"Mars/Olympus","cron":"61 * * * *","windows":[{"start":"2025-01-01T00:00:00Z","end":"2025-01-01T01:00:00Z"},{"start":"2025-01-02T00:00:00Z","end":"2025-01-01T00:00:00Z"}]}`,
+			want: []model.Violation{
+				{Pointer: "/cron", Keyword: "cron", Expected: "5-field cron expression", Actual: "61 * * * *"},
+				{Pointer: "/timezone", Keyword: "timezone", Expected: "IANA time zone", Actual: "Mars/Olympus"},
+				{Pointer: "/windows/1", Keyword: "startBeforeEnd", Actual: map[string]any{"start": "2025-01-02T00:00:00Z", "end": "2025-01-01T00:00:00Z"}},
+			},
+		},
+		{
+			name:   "when schedule_rule zone is Local should report it",
+			schema: "schedule_rule",
+			data:   `{"active":true,"timezone":"Local"}`,
+			want:   []model.Violation{{Pointer: "/timezone", Keyword: "timezone", Expected: "IANA time zone", Actual: "Local"}},
+		},
+		{
+			name:   "when threshold_policy min above max should report it",
+			schema: "threshold_policy",
+			data:   `{"metric":"p95","unit":"ms","min":300,"max":200,"enabled":true}`,
+			want:   []model.Violation{{Pointer: "/min", Keyword: "minNotAboveMax", Expected: float64(200), Actual: float64(300)}},
+		},
+		{
+			name:   "when threshold_policy bound is null should return nil",
+			schema: "threshold_policy",
+			data:   `{"metric":"p95","unit":"ms","min":300,"max":null,"enabled":true}`,
+		},
+		{
+			name:   "when experiment_config weights off and names repeat should report both",
+			schema: "experiment_config",
+			data:   `{"experiment_key":"exp","active":true,"variants":[{"name":"A","weight":30},{"name":"B","weight":30},{"name":"A","weight":30}]}`,
+			want: []model.Violation{
+				{Pointer: "/variants", Keyword: "weightsSum", Expected: float64(100), Actual: float64(90)},
+				{Pointer: "/variants/2/name", Keyword: "uniqueName", Actual: "A"},
+			},
+		},
+		{
+			name:   "when experiment_config fractional weights sum to 100 should return nil",
+			schema: "experiment_config",
+			data:   `{"experiment_key":"exp","active":true,"variants":[{"name":"A","weight":33.3},{"name":"B","weight":33.3},{"name":"C","weight":33.4}]}`,
+		},
+		{
+			name:   "when experiment_config min_app_version is not semver should report it",
+			schema: "experiment_config",
+			data:   `{"experiment_key":"exp","active":true,"variants":[{"name":"A","weight":50},{"name":"B","weight":50}],"audience":{"min_app_version":"5.x"}}`,
+			want:   []model.Violation{{Pointer: "/audience/min_app_version", Keyword: "semver", Expected: "semantic version", Actual: "5.x"}},
+		},
+		{
+			name:   "when experiment_config min_app_version is short semver should return nil",
+			schema: "experiment_config",
+			data:   `{"experiment_key":"exp","active":true,"variants":[{"name":"A","weight":50},{"name":"B","weight":50}],"audience":{"min_app_version":"v5.2"}}`,
+		},
+		{
+			name:   "when experiment_config layer range is empty should report it",
+			schema: "experiment_config",
+			data:   `{"experiment_key":"exp","active":true,"variants":[{"name":"A","weight":50},{"name":"B","weight":50}],"layer":{"name":"checkout-page","start":50,"end":50}}`,
+			want:   []model.Violation{{Pointer: "/layer", Keyword: "startBeforeEnd", Actual: map[string]any{"name": "checkout-page", "start": float64(50), "end": float64(50)}}},
+		},
+		{
+			name:   "when experiment_config holdout has a salt and a layer range should return nil",
+			schema: "experiment_config",
+			data:   `{"experiment_key":"holdout-q4","active":true,"holdout":true,"salt":"2","variants":[{"name":"control","weight":95},{"name":"held-out","weight":5}],"layer":{"name":"checkout-page","start":0,"end":12.5}}`,
+		},
+		{
+			name:   "when feature_toggle rule has both serve and rollout_percentage should report it",
+			schema: "feature_toggle",
+			data:   `{"enabled":true,"rules":[{"clauses":[{"attribute":"country","op":"in","values":["ID"]}],"serve":true,"rollout_percentage":10}]}`,
+			want: []model.Violation{{Pointer: "/rules/0", Keyword: "serveOrRollout", Actual: map[string]any{
+				"clauses": []any{map[string]any{"attribute": "country", "op": "in", "values": []any{"ID"}}}, "serve": true, "rollout_percentage": float64(10),
+			}}},
+		},
+		{
+			name:   "when feature_toggle clause value does not compile should point at the value",
+			schema: "feature_toggle",
+			data:   `{"enabled":true,"rules":[{"clauses":[{"attribute":"country","op":"in","values":["ID"]}],"serve":true},{"clauses":[{"attribute":"country","op":"in","values":["ID"]},{"attribute":"email","op":"matches","values":["ok","(["]}],"rollout_percentage":10}]}`,
+			want:   []model.Violation{{Pointer: "/rules/1/clauses/1/values/1", Keyword: "clause", Actual: "(["}},
+		},
+		{
+			name:   "when feature_toggle rules compile should return nil",
+			schema: "feature_toggle",
+			data:   `{"enabled":true,"rules":[{"clauses":[{"attribute":"app_version","op":"semver_gte","values":["5.2"]},{"attribute":"signed_up","op":"after","values":["2024-01-01"]}],"rollout_percentage":50}]}`,
+		},
+		{
+			name:   "when feature_toggle rule has neither clauses nor segments should report it",
+			schema: "feature_toggle",
+			data:   `{"enabled":true,"rules":[{"serve":true}]}`,
+			want:   []model.Violation{{Pointer: "/rules/0", Keyword: "clausesOrSegments", Actual: map[string]any{"serve": true}}},
+		},
+		{
+			name:   "when feature_toggle rule only names segments should return nil",
+			schema: "feature_toggle",
+			data:   `{"enabled":true,"rules":[{"segments":["employees"],"serve":true}]}`,
+		},
+		{
+			name:   "when segment clause does not compile should point at it",
+			schema: "segment",
+			data:   `{"include":["user-1"],"clauses":[{"attribute":"orders","op":"gt","values":["10"]}]}`,
+			want:   []model.Violation{{Pointer: "/clauses/0/values/0", Keyword: "clause", Actual: "10"}},
+		},
+		{
+			name:   "when schema fails should not run rules",
+			schema: "threshold_policy",
+			data:   `{"metric":"p95","min":300,"max":200,"enabled":true}`,
+			want:   []model.Violation{{Pointer: "/unit", Keyword: "required"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := sv.Validate(context.Background(), tc.schema, json.RawMessage(tc.data))
+			if tc.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var verr *ValidationError
+			if !assert.True(t, errors.As(err, &verr), err) {
+				return
+			}
+			for i := range verr.Violations {
+				assert.NotEmpty(t, verr.Violations[i].Message)
+				verr.Violations[i].Message = ""
+			}
+			assert.Equal(t, tc.want, verr.Violations)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"configuration-management-service/internal/schema/compiler"
+	"context"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// schemaCache holds the compiled latest schema of each type so Validate
+// neither reads nor compiles it on every write. The registry reports every
+// version it stores through Invalidate, which drops the type until the next
+// Validate reads it again. Compiled schemas are read-only and safe to share
+// between goroutines.
+type schemaCache struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+	// gen counts invalidations, so a read that raced one is not cached.
+	gen uint64
+}
+
+type cacheEntry struct {
+	version  int
+	compiled *jsonschema.Schema
+}
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{entries: map[string]cacheEntry{}}
+}
+
+// latest returns the compiled latest schema of schemaType and its version,
+// reading and compiling it from src on a miss. A nil cache reads and
+// compiles on every call.
+func (c *schemaCache) latest(ctx context.Context, src SchemaSource, schemaType string) (*jsonschema.Schema, int, error) {
+	var gen uint64
+	if c != nil {
+		c.mu.RLock()
+		e, ok := c.entries[schemaType]
+		gen = c.gen
+		c.mu.RUnlock()
+		if ok {
+			return e.compiled, e.version, nil
+		}
+	}
+
+	schema, err := src.Schema(ctx, schemaType, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	compiled, err := compiler.Compile(schemaType, schema.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if c != nil {
+		c.mu.Lock()
+		if c.gen == gen {
+			c.entries[schemaType] = cacheEntry{version: schema.Version, compiled: compiled}
+		}
+		c.mu.Unlock()
+	}
+	return compiled, schema.Version, nil
+}
+
+// invalidate drops the cached schema of schemaType.
+func (c *schemaCache) invalidate(schemaType string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.entries, schemaType)
+	c.gen++
+	c.mu.Unlock()
+}
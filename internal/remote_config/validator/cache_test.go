@@ -0,0 +1,107 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"configuration-management-service/internal/schema/builtin"
+	"configuration-management-service/internal/schema/compiler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaCache_Latest(t *testing.T) {
+	reads := 0
+	body := `{"type":"object"}`
+	src := SourceFunc(func(_ context.Context, schemaType string, version int) (Schema, error) {
+		reads++
+		if schemaType != "geo_rule" {
+			return Schema{}, ErrUnknownType
+		}
+		return Schema{Version: reads, Body: json.RawMessage(body)}, nil
+	})
+	c := newSchemaCache()
+	ctx := context.Background()
+
+	first, v, err := c.latest(ctx, src, "geo_rule")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	again, v, err := c.latest(ctx, src, "geo_rule")
+	assert.NoError(t, err)
+	assert.Same(t, first, again, "cached type should not be read again")
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 1, reads)
+
+	body = `{"type":"array"}`
+	c.invalidate("geo_rule")
+	next, v, err := c.latest(ctx, src, "geo_rule")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v, "invalidated type should be read again")
+	failures, err := compiler.Validate(next, []byte(`[]`))
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+
+	_, _, err = c.latest(ctx, src, "unknown")
+	assert.ErrorIs(t, err, ErrUnknownType)
+	_, _, err = c.latest(ctx, src, "unknown")
+	assert.ErrorIs(t, err, ErrUnknownType)
+	assert.Equal(t, 4, reads, "errors should not be cached")
+}
+
+func TestSchemaValidator_ValidateConcurrent(t *testing.T) {
+	sv := NewSchemaValidator(StaticSource(builtin.Schemas()), BuiltinRules())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for typ, data := range benchData {
+				_, err := sv.Validate(context.Background(), typ, data)
+				assert.NoError(t, err, typ)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// benchData holds one valid document per built-in type.
+var benchData = map[string]json.RawMessage{
+	"feature_toggle":      json.RawMessage(`{"enabled":true,"rollout_percentage":10,"tags":["a","b"],"description":"ok"}`),
+	"experiment_config":   json.RawMessage(`{"experiment_key":"exp-1","active":true,"variants":[{"name":"A","weight":50},{"name":"B","weight":50}],"audience":{"countries":["ID","SG"],"os":["ios","android"]}}`),
+	"service_client":      json.RawMessage(`{"name":"payments","base_url":"https://payments.internal","timeout_ms":500,"retry":{"max_retries":3,"backoff_ms":100,"jitter":true},"headers":{"X-Env":"prod"}}`),
+	"rate_limit_policy":   json.RawMessage(`{"identifier_type":"user","window_seconds":60,"max_requests":100,"burst":10,"scope":["checkout"]}`),
+	"notification_policy": json.RawMessage(`{"channel":"email","enabled":true,"daily_limit":10,"template_id":"tpl","placeholders":["name"]}`),
+	"schedule_rule":       json.RawMessage(`{"active":true,"timezone":"Asia/Jakarta","cron":"0 9 * * 1-5","windows":[{"start":"2025-01-01T00:00:00Z","end":"2025-01-02T00:00:00Z"}]}`),
+	"threshold_policy":    json.RawMessage(`{"metric":"p95","unit":"ms","min":0,"max":250,"inclusive":true,"enabled":true}`),
+}
+
+// BenchmarkValidate compares Validate without the cache, which reads and
+// compiles the latest schema on every call, with the cached validator.
+func BenchmarkValidate(b *testing.B) {
+	src := StaticSource(builtin.Schemas())
+	uncached := schemaValidator{src: src, rules: BuiltinRules()}
+	sv := NewSchemaValidator(src, BuiltinRules())
+	ctx := context.Background()
+
+	for _, typ := range []string{"feature_toggle", "experiment_config", "service_client", "rate_limit_policy", "notification_policy", "schedule_rule", "threshold_policy"} {
+		data := benchData[typ]
+
+		b.Run(typ+"/uncached", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := uncached.Validate(ctx, typ, data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(typ+"/cached", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := sv.Validate(ctx, typ, data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
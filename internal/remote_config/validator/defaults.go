@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"bytes"
+	"configuration-management-service/internal/schema/compiler"
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Modes of the "x-defaults" schema keyword, which says when the "default"
+// values of a type's schema are filled in. Without it defaults are not applied.
+const (
+	// DefaultsWrite stores the defaults with the data on create and update.
+	DefaultsWrite = "write"
+	// DefaultsRead adds them to resolved reads and reports which fields were filled.
+	DefaultsRead = "read"
+)
+
+const defaultsKeyword = "x-defaults"
+
+// ApplyDefaults fills in the "default" of every property missing from data,
+// in nested objects and array items too, when the schema version (0 =
+// latest) declares mode in "x-defaults". It returns the JSON pointers it
+// filled; with another or no mode, data is returned untouched.
+func (s schemaValidator) ApplyDefaults(ctx context.Context, schemaType string, version int, mode string, data json.RawMessage) (json.RawMessage, []string, error) {
+	schema, err := s.src.Schema(ctx, schemaType, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := compiler.Inline(schema.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if declared, _ := doc[defaultsKeyword].(string); declared != mode {
+		return data, nil, nil
+	}
+
+	var node any
+	if err := decodeNumbers(data, &node); err != nil {
+		return nil, nil, err
+	}
+	var filled []string
+	fillDefaults(doc, node, "", &filled)
+	if len(filled) == 0 {
+		return data, nil, nil
+	}
+	out, err := json.Marshal(node)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, filled, nil
+}
+
+// decodeNumbers keeps numbers as written so re-encoding does not reformat them.
+func decodeNumbers(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// fillDefaults mutates node in place. Explicit nulls are kept: a default
+// only replaces a missing member.
+func fillDefaults(schema map[string]any, node any, ptr string, filled *[]string) {
+	switch n := node.(type) {
+	case map[string]any:
+		props, _ := schema["properties"].(map[string]any)
+		names := make([]string, 0, len(props))
+		for name := range props {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sub, ok := props[name].(map[string]any)
+			if !ok {
+				continue
+			}
+			child := ptr + "/" + strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+			if v, present := n[name]; present {
+				fillDefaults(sub, v, child, filled)
+				continue
+			}
+			def, ok := sub["default"]
+			if !ok {
+				continue
+			}
+			n[name] = clone(def)
+			*filled = append(*filled, child)
+			// Defaults inside the default itself belong to the same fill.
+			var nested []string
+			fillDefaults(sub, n[name], child, &nested)
+		}
+	case []any:
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			return
+		}
+		for i, v := range n {
+			fillDefaults(items, v, ptr+"/"+strconv.Itoa(i), filled)
+		}
+	}
+}
+
+// clone deep-copies a decoded JSON value so documents never share a default.
+func clone(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(x))
+		for k, e := range x {
+			out[k] = clone(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(x))
+		for i, e := range x {
+			out[i] = clone(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
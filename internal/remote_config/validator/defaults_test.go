@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"configuration-management-service/internal/schema/builtin"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaValidator_ApplyDefaults(t *testing.T) {
+	const nested = `{
+		"x-defaults": "write",
+		"type": "object",
+		"properties": {
+			"timeout_ms": { "type": "integer", "default": 1000000 },
+			"retry": {
+				"type": "object",
+				"default": {},
+				"properties": {
+					"max_retries": { "type": "integer", "default": 3 },
+					"jitter": { "type": "boolean", "default": true }
+				}
+			},
+			"routes": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": { "weight": { "type": "number", "default": 1 } }
+				}
+			},
+			"owner": { "type": ["string", "null"], "default": "platform" }
+		}
+	}`
+	schemas := builtin.Schemas()
+	schemas["client"] = nested
+	sv := NewSchemaValidator(StaticSource(schemas), nil)
+
+	cases := []struct {
+		name   string
+		schema string
+		mode   string
+		data   string
+		want   string
+		filled []string
+	}{
+		{
+			name:   "when mode matches should fill nested objects and array items",
+			schema: "client",
+			mode:   DefaultsWrite,
+			data:   `{"retry":{"jitter":false},"routes":[{"weight":5},{}],"owner":null}`,
+			want:   `{"timeout_ms":1000000,"retry":{"jitter":false,"max_retries":3},"routes":[{"weight":5},{"weight":1}],"owner":null}`,
+			filled: []string{"/retry/max_retries", "/routes/1/weight", "/timeout_ms"},
+		},
+		{
+			name:   "when object default applied should fill defaults inside it",
+			schema: "client",
+			mode:   DefaultsWrite,
+			data:   `{"timeout_ms":5}`,
+			want:   `{"timeout_ms":5,"retry":{"max_retries":3,"jitter":true},"owner":"platform"}`,
+			filled: []string{"/owner", "/retry"},
+		},
+		{
+			name:   "when mode differs should leave data untouched",
+			schema: "client",
+			mode:   DefaultsRead,
+			data:   `{"timeout_ms":5}`,
+			want:   `{"timeout_ms":5}`,
+		},
+		{
+			name:   "when threshold_policy read should default inclusive",
+			schema: "threshold_policy",
+			mode:   DefaultsRead,
+			data:   `{"metric":"p95","unit":"ms","enabled":true}`,
+			want:   `{"metric":"p95","unit":"ms","enabled":true,"inclusive":true}`,
+			filled: []string{"/inclusive"},
+		},
+		{
+			name:   "when schema has no x-defaults should leave data untouched",
+			schema: "feature_toggle",
+			mode:   DefaultsRead,
+			data:   `{"enabled":true}`,
+			want:   `{"enabled":true}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, filled, err := sv.ApplyDefaults(context.Background(), tc.schema, 0, tc.mode, json.RawMessage(tc.data))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+			assert.Equal(t, tc.filled, filled)
+		})
+	}
+
+	t.Run("when defaults applied should not share the default value", func(t *testing.T) {
+		first, _, _ := sv.ApplyDefaults(context.Background(), "client", 0, DefaultsWrite, json.RawMessage(`{}`))
+		second, _, _ := sv.ApplyDefaults(context.Background(), "client", 0, DefaultsWrite, json.RawMessage(`{}`))
+		assert.JSONEq(t, string(first), string(second))
+		assert.Contains(t, string(first), `"timeout_ms":1000000`)
+	})
+}
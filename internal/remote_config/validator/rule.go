@@ -0,0 +1,26 @@
+package validator
+
+import "configuration-management-service/internal/remote_config/model"
+
+// Rule checks what JSON Schema cannot express for a config type. It runs
+// only on data that passed the schema, decoded with encoding/json, and must
+// tolerate fields a later schema version made optional or removed.
+type Rule interface {
+	Check(doc any) []model.Violation
+}
+
+// RuleFunc adapts a plain function to Rule.
+type RuleFunc func(doc any) []model.Violation
+
+func (f RuleFunc) Check(doc any) []model.Violation { return f(doc) }
+
+// Rules maps a config type to the rules its data must pass.
+type Rules map[string][]Rule
+
+func (r Rules) check(schemaType string, doc any) []model.Violation {
+	var out []model.Violation
+	for _, rule := range r[schemaType] {
+		out = append(out, rule.Check(doc)...)
+	}
+	return out
+}
@@ -1,202 +1,101 @@
 package validator
 
 import (
+	"configuration-management-service/internal/schema/compiler"
+	"context"
 	"encoding/json"
-	"errors"
-
-	"github.com/xeipuuv/gojsonschema"
+	"sort"
+	"strings"
 )
 
+// ISchemaValidator validates config data against the schemas of the
+// registry. Validate reports the schema version it validated against, or a
+// *ValidationError listing every violation. Invalidate tells it a new
+// version of schemaType was registered.
 type ISchemaValidator interface {
-	Validate(schemaType string, data json.RawMessage) error
+	Validate(ctx context.Context, schemaType string, data json.RawMessage) (int, error)
+	SecretFields(ctx context.Context, schemaType string, version int) ([]string, error)
+	ApplyDefaults(ctx context.Context, schemaType string, version int, mode string, data json.RawMessage) (json.RawMessage, []string, error)
+	Invalidate(schemaType string)
 }
 
-type schemaValidator struct{}
-
-func NewSchemaValidator() ISchemaValidator {
-	return schemaValidator{}
+type schemaValidator struct {
+	src   SchemaSource
+	rules Rules
+	cache *schemaCache
 }
 
-// Registry of available schemas (hard-coded as required)
-var registry = map[string]string{
-	"feature_toggle": `
-	{
-	  "$schema": "http://json-schema.org/draft-07/schema#",
-	  "title": "feature_toggle",
-	  "type": "object",
-	  "properties": {
-		"enabled": { "type": "boolean" },
-		"rollout_percentage": { "type": "integer", "minimum": 0, "maximum": 100 },
-		"tags": { "type": "array", "items": { "type": "string" }, "uniqueItems": true },
-		"description": { "type": "string" }
-	  },
-	  "required": ["enabled"],
-	  "additionalProperties": false
-	}`,
-
-	"experiment_config": `
-	{
-	  "$schema": "http://json-schema.org/draft-07/schema#",
-	  "title": "experiment_config",
-	  "type": "object",
-	  "properties": {
-		"experiment_key": { "type": "string", "minLength": 1 },
-		"active": { "type": "boolean" },
-		"variants": {
-		  "type": "array",
-		  "items": {
-			"type": "object",
-			"properties": {
-			  "name": { "type": "string", "minLength": 1 },
-			  "weight": { "type": "number", "minimum": 0 }
-			},
-			"required": ["name", "weight"],
-			"additionalProperties": false
-		  },
-		  "minItems": 2
-		},
-		"audience": {
-		  "type": "object",
-		  "properties": {
-			"countries": { "type": "array", "items": { "type": "string" }, "uniqueItems": true },
-			"os": { "type": "array", "items": { "type": "string", "enum": ["ios", "android", "web"] }, "uniqueItems": true },
-			"min_app_version": { "type": "string" }
-		  },
-		  "additionalProperties": false
-		},
-		"description": { "type": "string" }
-	  },
-	  "required": ["experiment_key", "active", "variants"],
-	  "additionalProperties": false
-	}`,
-
-	"service_client": `
-	{
-	  "$schema": "http://json-schema.org/draft-07/schema#",
-	  "title": "service_client",
-	  "type": "object",
-	  "properties": {
-		"name": { "type": "string", "minLength": 1 },
-		"base_url": { "type": "string", "format": "uri" },
-		"timeout_ms": { "type": "integer", "minimum": 100 },
-		"retry": {
-		  "type": "object",
-		  "properties": {
-			"max_retries": { "type": "integer", "minimum": 0, "maximum": 10 },
-			"backoff_ms": { "type": "integer", "minimum": 0 },
-			"jitter": { "type": "boolean" }
-		  },
-		  "required": ["max_retries"],
-		  "additionalProperties": false
-		},
-		"headers": {
-		  "type": "object",
-		  "additionalProperties": { "type": "string" }
-		},
-		"description": { "type": "string" }
-	  },
-	  "required": ["name", "base_url", "timeout_ms"],
-	  "additionalProperties": false
-	}`,
+// NewSchemaValidator validates against src, then runs the semantic rules of
+// the type on data the schema accepted.
+func NewSchemaValidator(src SchemaSource, rules Rules) ISchemaValidator {
+	return schemaValidator{src: src, rules: rules, cache: newSchemaCache()}
+}
 
-	"rate_limit_policy": `
-	{
-	  "$schema": "http://json-schema.org/draft-07/schema#",
-	  "title": "rate_limit_policy",
-	  "type": "object",
-	  "properties": {
-		"identifier_type": { "type": "string", "enum": ["ip", "user", "api_key"] },
-		"window_seconds": { "type": "integer", "minimum": 1 },
-		"max_requests": { "type": "integer", "minimum": 1 },
-		"burst": { "type": "integer", "minimum": 0 },
-		"scope": {
-		  "type": "array",
-		  "items": { "type": "string", "minLength": 1 },
-		  "uniqueItems": true
-		},
-		"description": { "type": "string" }
-	  },
-	  "required": ["identifier_type", "window_seconds", "max_requests"],
-	  "additionalProperties": false
-	}`,
+// secretKeyword marks a property whose value must be encrypted at rest.
+const secretKeyword = "x-secret"
 
-	"notification_policy": `
-	{
-	  "$schema": "http://json-schema.org/draft-07/schema#",
-	  "title": "notification_policy",
-	  "type": "object",
-	  "properties": {
-		"channel": { "type": "string", "enum": ["email", "sms", "push"] },
-		"enabled": { "type": "boolean" },
-		"daily_limit": { "type": "integer", "minimum": 0 },
-		"template_id": { "type": "string" },
-		"placeholders": { "type": "array", "items": { "type": "string", "minLength": 1 }, "uniqueItems": true },
-		"description": { "type": "string" }
-	  },
-	  "required": ["channel", "enabled"],
-	  "additionalProperties": false
-	}`,
+func (s schemaValidator) Validate(ctx context.Context, schemaType string, data json.RawMessage) (int, error) {
+	compiled, version, err := s.cache.latest(ctx, s.src, schemaType)
+	if err != nil {
+		return 0, err
+	}
+	failures, err := compiler.Validate(compiled, data)
+	if err != nil {
+		return 0, err
+	}
+	if len(failures) > 0 {
+		return 0, &ValidationError{Violations: violations(data, failures)}
+	}
 
-	"schedule_rule": `
-	{
-	  "$schema": "http://json-schema.org/draft-07/schema#",
-	  "title": "schedule_rule",
-	  "type": "object",
-	  "properties": {
-		"active": { "type": "boolean" },
-		"timezone": { "type": "string", "minLength": 1 },
-		"cron": { "type": "string", "minLength": 1 },
-		"windows": {
-		  "type": "array",
-		  "items": {
-			"type": "object",
-			"properties": {
-			  "start": { "type": "string", "format": "date-time" },
-			  "end": { "type": "string", "format": "date-time" }
-			},
-			"required": ["start", "end"],
-			"additionalProperties": false
-		  }
-		},
-		"description": { "type": "string" }
-	  },
-	  "required": ["active", "timezone"],
-	  "additionalProperties": false
-	}`,
+	if len(s.rules[schemaType]) > 0 {
+		var doc any
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return 0, err
+		}
+		if found := s.rules.check(schemaType, doc); len(found) > 0 {
+			sortViolations(found)
+			return 0, &ValidationError{Violations: found}
+		}
+	}
+	return version, nil
+}
 
-	"threshold_policy": `
-	{
-	  "$schema": "http://json-schema.org/draft-07/schema#",
-	  "title": "threshold_policy",
-	  "type": "object",
-	  "properties": {
-		"metric": { "type": "string", "minLength": 1 },
-		"unit": { "type": "string", "enum": ["count", "ms", "percent", "amount"] },
-		"min": { "type": ["number", "null"] },
-		"max": { "type": ["number", "null"] },
-		"inclusive": { "type": "boolean", "default": true },
-		"enabled": { "type": "boolean" },
-		"description": { "type": "string" }
-	  },
-	  "required": ["metric", "unit", "enabled"],
-	  "additionalProperties": false
-	}`,
+// Invalidate makes the next Validate of schemaType read its latest schema
+// again.
+func (s schemaValidator) Invalidate(schemaType string) {
+	s.cache.invalidate(schemaType)
 }
 
-func (s schemaValidator) Validate(schemaType string, data json.RawMessage) error {
-	schema, ok := registry[schemaType]
-	if !ok {
-		return errors.New("unknown config type: " + schemaType)
+// SecretFields returns JSON pointers to every property marked with
+// "x-secret": true in the given schema version (0 = latest), including
+// those marked in the definitions it references.
+func (s schemaValidator) SecretFields(ctx context.Context, schemaType string, version int) ([]string, error) {
+	schema, err := s.src.Schema(ctx, schemaType, version)
+	if err != nil {
+		return nil, err
 	}
-	res, err := gojsonschema.Validate(gojsonschema.NewStringLoader(schema), gojsonschema.NewBytesLoader(data))
+	doc, err := compiler.Inline(schema.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if !res.Valid() {
-		if len(res.Errors()) > 0 {
-			return errors.New(res.Errors()[0].String())
+	var out []string
+	collectSecrets(doc, "", &out)
+	sort.Strings(out)
+	return out, nil
+}
+
+func collectSecrets(node map[string]any, prefix string, out *[]string) {
+	props, _ := node["properties"].(map[string]any)
+	for name, raw := range props {
+		prop, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		ptr := prefix + "/" + strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+		if secret, _ := prop[secretKeyword].(bool); secret {
+			*out = append(*out, ptr)
+			continue
 		}
-		return errors.New("validation failed")
+		collectSecrets(prop, ptr, out)
 	}
-	return nil
 }
@@ -1,15 +1,18 @@
 package validator
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
 
+	"configuration-management-service/internal/schema/builtin"
+
 	"github.com/stretchr/testify/assert"
 )
 
 func TestSchemaValidator_Validate(t *testing.T) {
-	sv := NewSchemaValidator()
+	sv := NewSchemaValidator(StaticSource(builtin.Schemas()), BuiltinRules())
 
 	type args struct {
 		schema string
@@ -43,12 +46,12 @@ func TestSchemaValidator_Validate(t *testing.T) {
 		{
 			name: "when feature_toggle missing required 'enabled' should return error",
 			in:   args{schema: "feature_toggle", data: json.RawMessage(`{"rollout_percentage": 10}`)},
-			out:  want{ok: false, errContains: []string{"(root): enabled is required", "enabled: enabled is required"}},
+			out:  want{ok: false, errContains: []string{"(root): missing property 'enabled'"}},
 		},
 		{
 			name: "when feature_toggle additional property rejected should return error",
 			in:   args{schema: "feature_toggle", data: json.RawMessage(`{"enabled": true, "unknown": 1}`)},
-			out:  want{ok: false, errContains: []string{"Additional property unknown is not allowed", "unknown additional property"}},
+			out:  want{ok: false, errContains: []string{"(root): additional properties 'unknown' not allowed"}},
 		},
 
 		{
@@ -61,7 +64,7 @@ func TestSchemaValidator_Validate(t *testing.T) {
 					"variants":[{"name":"A","weight":0.5}]
 				}`),
 			},
-			out: want{ok: false, errContains: []string{"variants: Array must have at least 2 items"}},
+			out: want{ok: false, errContains: []string{"/variants: minItems: got 1, want 2"}},
 		},
 		{
 			name: "when experiment_config valid should return nil",
@@ -70,7 +73,7 @@ func TestSchemaValidator_Validate(t *testing.T) {
 				data: json.RawMessage(`{
 					"experiment_key":"exp-1",
 					"active": true,
-					"variants":[{"name":"A","weight":0.5}, {"name":"B","weight":0.5}],
+					"variants":[{"name":"A","weight":50}, {"name":"B","weight":50}],
 					"audience":{"countries":["ID","SG"],"os":["ios","android"]},
 					"description":"A/B test"
 				}`),
@@ -81,13 +84,13 @@ func TestSchemaValidator_Validate(t *testing.T) {
 		{
 			name: "when service_client invalid uri should return error",
 			in:   args{schema: "service_client", data: json.RawMessage(`{"name":"svc","base_url":"not-a-uri","timeout_ms":200}`)},
-			out:  want{ok: false, errContains: []string{"base_url: Does not match format 'uri'"}},
+			out:  want{ok: false, errContains: []string{"/base_url: 'not-a-uri' is not valid uri"}},
 		},
 
 		{
 			name: "when rate_limit_policy invalid identifier_type should return error",
 			in:   args{schema: "rate_limit_policy", data: json.RawMessage(`{"identifier_type":"device","window_seconds":60,"max_requests":100}`)},
-			out:  want{ok: false, errContains: []string{"identifier_type must be one of the following", "identifier_type: identifier_type must be one of the following"}},
+			out:  want{ok: false, errContains: []string{"/identifier_type: value must be one of 'ip', 'user', 'api_key'"}},
 		},
 
 		{
@@ -107,7 +110,7 @@ func TestSchemaValidator_Validate(t *testing.T) {
 					"windows":[{"start":"2025-10-01T00:00:00Z"}]
 				}`),
 			},
-			out: want{ok: false, errContains: []string{"windows.0: end is required", "windows.0.end: end is required"}},
+			out: want{ok: false, errContains: []string{"/windows/0: missing property 'end'"}},
 		},
 
 		{
@@ -119,9 +122,10 @@ func TestSchemaValidator_Validate(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := sv.Validate(tc.in.schema, tc.in.data)
+			version, err := sv.Validate(context.Background(), tc.in.schema, tc.in.data)
 			if tc.out.ok {
 				assert.NoError(t, err)
+				assert.Equal(t, 1, version)
 				return
 			}
 			if assert.Error(t, err) {
@@ -140,3 +144,50 @@ func TestSchemaValidator_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaValidator_SecretFields(t *testing.T) {
+	schemas := builtin.Schemas()
+	schemas["vault_client"] = `{"$defs":{"credentials":{"type":"object","properties":{"token":{"type":"string","x-secret":true}}}},"properties":{"auth":{"$ref":"#/$defs/credentials"}}}`
+	sv := NewSchemaValidator(StaticSource(schemas), BuiltinRules())
+
+	cases := []struct {
+		name    string
+		schema  string
+		version int
+		want    []string
+		wantErr bool
+	}{
+		{name: "when service_client should return headers", schema: "service_client", want: []string{"/headers"}},
+		{name: "when feature_toggle should return none", schema: "feature_toggle", want: nil},
+		{name: "when secret is declared in a referenced definition should return it", schema: "vault_client", want: []string{"/auth/token"}},
+		{name: "when unknown schema type should return error", schema: "does_not_exist", wantErr: true},
+		{name: "when unknown schema version should return error", schema: "service_client", version: 2, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := sv.SecretFields(context.Background(), tc.schema, tc.version)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSchemaValidator_BuiltinExamples(t *testing.T) {
+	sv := NewSchemaValidator(StaticSource(builtin.Schemas()), BuiltinRules())
+
+	for schemaType, body := range builtin.Schemas() {
+		var doc struct {
+			Examples []json.RawMessage `json:"examples"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(body), &doc), schemaType)
+		assert.NotEmpty(t, doc.Examples, "%s has no examples", schemaType)
+		for i, ex := range doc.Examples {
+			_, err := sv.Validate(context.Background(), schemaType, ex)
+			assert.NoError(t, err, "%s example %d", schemaType, i)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownType is returned for a config type the registry has no schema for.
+var ErrUnknownType = errors.New("unknown config type")
+
+// Schema is one version of the JSON Schema of a config type.
+type Schema struct {
+	Version int
+	Body    json.RawMessage
+}
+
+// SchemaSource looks up schemas by type and version; version 0 means the
+// latest. Unknown types and versions are reported as ErrUnknownType.
+type SchemaSource interface {
+	Schema(ctx context.Context, schemaType string, version int) (Schema, error)
+}
+
+// SourceFunc adapts a plain function to SchemaSource.
+type SourceFunc func(ctx context.Context, schemaType string, version int) (Schema, error)
+
+func (f SourceFunc) Schema(ctx context.Context, schemaType string, version int) (Schema, error) {
+	return f(ctx, schemaType, version)
+}
+
+// StaticSource serves a fixed set of schemas, each as version 1.
+func StaticSource(schemas map[string]string) SchemaSource {
+	return SourceFunc(func(_ context.Context, schemaType string, version int) (Schema, error) {
+		body, ok := schemas[schemaType]
+		if !ok || version > 1 {
+			return Schema{}, fmt.Errorf("%w: %s", ErrUnknownType, schemaType)
+		}
+		return Schema{Version: 1, Body: json.RawMessage(body)}, nil
+	})
+}
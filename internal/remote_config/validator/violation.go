@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/schema/compiler"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidationError carries every violation found in the data, in pointer order.
+type ValidationError struct {
+	Violations []model.Violation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Message)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// violations converts schema failures into violations, reading the actual
+// values from data itself.
+func violations(data json.RawMessage, failures []compiler.Failure) []model.Violation {
+	var doc any
+	_ = json.Unmarshal(data, &doc)
+
+	out := make([]model.Violation, 0, len(failures))
+	for _, f := range failures {
+		v := model.Violation{
+			Pointer:  pointer(f.Path),
+			Keyword:  f.Keyword,
+			Expected: f.Expected,
+			Message:  f.Message,
+		}
+		if f.Keyword != "required" && f.Keyword != "dependentRequired" && f.Keyword != "dependencies" {
+			v.Actual, _ = lookup(doc, f.Path)
+		}
+		out = append(out, v)
+	}
+	sortViolations(out)
+	return out
+}
+
+func sortViolations(v []model.Violation) {
+	sort.SliceStable(v, func(i, j int) bool {
+		if v[i].Pointer != v[j].Pointer {
+			return v[i].Pointer < v[j].Pointer
+		}
+		return v[i].Keyword < v[j].Keyword
+	})
+}
+
+func pointer(segments []string) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteString("/")
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// lookup walks doc along segments; array segments are decimal indexes.
+func lookup(doc any, segments []string) (any, bool) {
+	cur := doc
+	for _, s := range segments {
+		switch node := cur.(type) {
+		case map[string]any:
+			v, ok := node[s]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []any:
+			i, err := strconv.Atoi(s)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			cur = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
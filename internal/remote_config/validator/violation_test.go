@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/schema/builtin"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaValidator_Violations(t *testing.T) {
+	sv := NewSchemaValidator(StaticSource(builtin.Schemas()), BuiltinRules())
+
+	cases := []struct {
+		name   string
+		schema string
+		data   string
+		want   []model.Violation
+	}{
+		{
+			name:   "when experiment_config has many errors should report all of them in pointer order",
+			schema: "experiment_config",
+			data:   `{"active":"yes","variants":[{"name":"","weight":-1,"x/y":1}],"audience":{"os":["tv"]}}`,
+			want: []model.Violation{
+				{Pointer: "/active", Keyword: "type", Expected: "boolean", Actual: "yes"},
+				{Pointer: "/audience/os/0", Keyword: "enum", Expected: []any{"ios", "android", "web"}, Actual: "tv"},
+				{Pointer: "/experiment_key", Keyword: "required"},
+				{Pointer: "/variants", Keyword: "minItems", Expected: 2, Actual: []any{map[string]any{"name": "", "weight": float64(-1), "x/y": float64(1)}}},
+				{Pointer: "/variants/0/name", Keyword: "minLength", Expected: 1, Actual: ""},
+				{Pointer: "/variants/0/weight", Keyword: "minimum", Expected: float64(0), Actual: float64(-1)},
+				{Pointer: "/variants/0/x~1y", Keyword: "additionalProperties", Expected: false, Actual: float64(1)},
+			},
+		},
+		{
+			name:   "when service_client base_url bad format should report format",
+			schema: "service_client",
+			data:   `{"name":"svc","base_url":"not-a-uri","timeout_ms":200}`,
+			want: []model.Violation{
+				{Pointer: "/base_url", Keyword: "format", Expected: "uri", Actual: "not-a-uri"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := sv.Validate(context.Background(), tc.schema, json.RawMessage(tc.data))
+
+			var verr *ValidationError
+			if !assert.True(t, errors.As(err, &verr)) {
+				return
+			}
+			for i := range verr.Violations {
+				assert.NotEmpty(t, verr.Violations[i].Message)
+				verr.Violations[i].Message = ""
+			}
+			assert.Equal(t, tc.want, verr.Violations)
+		})
+	}
+}
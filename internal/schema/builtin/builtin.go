@@ -0,0 +1,274 @@
+// Package builtin holds the schemas of the config types the service ships
+// with. They are seeded as version 1 of their type in the schema registry.
+package builtin
+
+// definitions is the shared definitions document. Schemas of any type
+// reference its fragments as "common.json#/$defs/<name>".
+const definitions = `
+{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "common",
+  "$defs": {
+	"description": { "type": "string" },
+	"nonEmptyString": { "type": "string", "minLength": 1 },
+	"percentage": { "type": "integer", "minimum": 0, "maximum": 100 },
+	"stringSet": { "type": "array", "items": { "type": "string" }, "uniqueItems": true },
+	"nonEmptyStringSet": { "type": "array", "items": { "$ref": "#/$defs/nonEmptyString" }, "uniqueItems": true },
+	"clause": {
+	  "type": "object",
+	  "properties": {
+		"attribute": { "$ref": "#/$defs/nonEmptyString" },
+		"op": {
+		  "type": "string",
+		  "enum": ["in", "not_in", "starts_with", "matches", "semver_eq", "semver_lt", "semver_lte", "semver_gt", "semver_gte", "lt", "lte", "gt", "gte", "before", "after"]
+		},
+		"values": { "type": "array", "items": { "type": ["string", "number", "boolean"] }, "minItems": 1 }
+	  },
+	  "required": ["attribute", "op", "values"],
+	  "additionalProperties": false
+	}
+  }
+}`
+
+// Definitions returns the shared definitions document.
+func Definitions() string {
+	return definitions
+}
+
+var schemas = map[string]string{
+	"feature_toggle": `
+	{
+	  "$schema": "http://json-schema.org/draft-07/schema#",
+	  "title": "feature_toggle",
+	  "type": "object",
+	  "properties": {
+		"enabled": { "type": "boolean" },
+		"rollout_percentage": { "$ref": "common.json#/$defs/percentage" },
+		"rules": {
+		  "type": "array",
+		  "items": {
+			"type": "object",
+			"properties": {
+			  "clauses": { "type": "array", "items": { "$ref": "common.json#/$defs/clause" }, "minItems": 1 },
+			  "segments": { "$ref": "common.json#/$defs/nonEmptyStringSet" },
+			  "serve": { "type": "boolean" },
+			  "rollout_percentage": { "$ref": "common.json#/$defs/percentage" },
+			  "description": { "$ref": "common.json#/$defs/description" }
+			},
+			"additionalProperties": false
+		  }
+		},
+		"tags": { "$ref": "common.json#/$defs/stringSet" },
+		"description": { "$ref": "common.json#/$defs/description" }
+	  },
+	  "required": ["enabled"],
+	  "additionalProperties": false,
+	  "examples": [
+		{ "enabled": true, "rollout_percentage": 25, "rules": [{ "segments": ["employees"], "serve": true }, { "clauses": [{ "attribute": "country", "op": "in", "values": ["ID", "SG"] }, { "attribute": "app_version", "op": "semver_gte", "values": ["5.2.0"] }], "rollout_percentage": 50 }], "tags": ["checkout"], "description": "New checkout flow" }
+	  ]
+	}`,
+
+	"experiment_config": `
+	{
+	  "$schema": "http://json-schema.org/draft-07/schema#",
+	  "title": "experiment_config",
+	  "type": "object",
+	  "properties": {
+		"experiment_key": { "$ref": "common.json#/$defs/nonEmptyString" },
+		"active": { "type": "boolean" },
+		"variants": {
+		  "type": "array",
+		  "items": {
+			"type": "object",
+			"properties": {
+			  "name": { "$ref": "common.json#/$defs/nonEmptyString" },
+			  "weight": { "type": "number", "minimum": 0 }
+			},
+			"required": ["name", "weight"],
+			"additionalProperties": false
+		  },
+		  "minItems": 2
+		},
+		"audience": {
+		  "type": "object",
+		  "properties": {
+			"countries": { "$ref": "common.json#/$defs/stringSet" },
+			"os": { "type": "array", "items": { "type": "string", "enum": ["ios", "android", "web"] }, "uniqueItems": true },
+			"min_app_version": { "type": "string" },
+			"segments": { "$ref": "common.json#/$defs/nonEmptyStringSet" }
+		  },
+		  "additionalProperties": false
+		},
+		"salt": { "$ref": "common.json#/$defs/nonEmptyString" },
+		"layer": {
+		  "type": "object",
+		  "properties": {
+			"name": { "$ref": "common.json#/$defs/nonEmptyString" },
+			"start": { "type": "number", "minimum": 0, "maximum": 100 },
+			"end": { "type": "number", "minimum": 0, "maximum": 100 }
+		  },
+		  "required": ["name", "start", "end"],
+		  "additionalProperties": false
+		},
+		"holdout": { "type": "boolean" },
+		"tags": { "$ref": "common.json#/$defs/stringSet" },
+		"description": { "$ref": "common.json#/$defs/description" }
+	  },
+	  "required": ["experiment_key", "active", "variants"],
+	  "additionalProperties": false,
+	  "examples": [
+		{ "experiment_key": "checkout-button", "active": true, "variants": [{ "name": "control", "weight": 50 }, { "name": "green", "weight": 50 }], "audience": { "countries": ["ID", "SG"], "os": ["ios", "android"] }, "layer": { "name": "checkout-page", "start": 0, "end": 50 }, "tags": ["checkout"] }
+	  ]
+	}`,
+
+	"segment": `
+	{
+	  "$schema": "http://json-schema.org/draft-07/schema#",
+	  "title": "segment",
+	  "type": "object",
+	  "properties": {
+		"include": { "$ref": "common.json#/$defs/nonEmptyStringSet" },
+		"exclude": { "$ref": "common.json#/$defs/nonEmptyStringSet" },
+		"clauses": { "type": "array", "items": { "$ref": "common.json#/$defs/clause" } },
+		"description": { "$ref": "common.json#/$defs/description" }
+	  },
+	  "additionalProperties": false,
+	  "examples": [
+		{ "include": ["user-1", "user-2"], "exclude": ["user-3"], "clauses": [{ "attribute": "email", "op": "matches", "values": ["@example\\.com$"] }], "description": "Employees and named testers" }
+	  ]
+	}`,
+
+	"service_client": `
+	{
+	  "$schema": "http://json-schema.org/draft-07/schema#",
+	  "title": "service_client",
+	  "type": "object",
+	  "properties": {
+		"name": { "$ref": "common.json#/$defs/nonEmptyString" },
+		"base_url": { "type": "string", "format": "uri" },
+		"timeout_ms": { "type": "integer", "minimum": 100 },
+		"retry": {
+		  "type": "object",
+		  "properties": {
+			"max_retries": { "type": "integer", "minimum": 0, "maximum": 10 },
+			"backoff_ms": { "type": "integer", "minimum": 0 },
+			"jitter": { "type": "boolean" }
+		  },
+		  "required": ["max_retries"],
+		  "additionalProperties": false
+		},
+		"headers": {
+		  "type": "object",
+		  "additionalProperties": { "type": "string" },
+		  "x-secret": true
+		},
+		"description": { "$ref": "common.json#/$defs/description" }
+	  },
+	  "required": ["name", "base_url", "timeout_ms"],
+	  "additionalProperties": false,
+	  "examples": [
+		{ "name": "payments", "base_url": "https://payments.internal.example.com", "timeout_ms": 2000, "retry": { "max_retries": 3, "backoff_ms": 200, "jitter": true } }
+	  ]
+	}`,
+
+	"rate_limit_policy": `
+	{
+	  "$schema": "http://json-schema.org/draft-07/schema#",
+	  "title": "rate_limit_policy",
+	  "type": "object",
+	  "properties": {
+		"identifier_type": { "type": "string", "enum": ["ip", "user", "api_key"] },
+		"window_seconds": { "type": "integer", "minimum": 1 },
+		"max_requests": { "type": "integer", "minimum": 1 },
+		"burst": { "type": "integer", "minimum": 0 },
+		"scope": { "$ref": "common.json#/$defs/nonEmptyStringSet" },
+		"description": { "$ref": "common.json#/$defs/description" }
+	  },
+	  "required": ["identifier_type", "window_seconds", "max_requests"],
+	  "additionalProperties": false,
+	  "examples": [
+		{ "identifier_type": "api_key", "window_seconds": 60, "max_requests": 1000, "burst": 50, "scope": ["/api/orders"] }
+	  ]
+	}`,
+
+	"notification_policy": `
+	{
+	  "$schema": "http://json-schema.org/draft-07/schema#",
+	  "title": "notification_policy",
+	  "type": "object",
+	  "properties": {
+		"channel": { "type": "string", "enum": ["email", "sms", "push"] },
+		"enabled": { "type": "boolean" },
+		"daily_limit": { "type": "integer", "minimum": 0 },
+		"template_id": { "type": "string" },
+		"placeholders": { "$ref": "common.json#/$defs/nonEmptyStringSet" },
+		"description": { "$ref": "common.json#/$defs/description" }
+	  },
+	  "required": ["channel", "enabled"],
+	  "additionalProperties": false,
+	  "examples": [
+		{ "channel": "email", "enabled": true, "daily_limit": 3, "template_id": "order-shipped", "placeholders": ["name", "order_id"] }
+	  ]
+	}`,
+
+	"schedule_rule": `
+	{
+	  "$schema": "http://json-schema.org/draft-07/schema#",
+	  "title": "schedule_rule",
+	  "type": "object",
+	  "properties": {
+		"active": { "type": "boolean" },
+		"timezone": { "$ref": "common.json#/$defs/nonEmptyString" },
+		"cron": { "$ref": "common.json#/$defs/nonEmptyString" },
+		"windows": {
+		  "type": "array",
+		  "items": {
+			"type": "object",
+			"properties": {
+			  "start": { "type": "string", "format": "date-time" },
+			  "end": { "type": "string", "format": "date-time" }
+			},
+			"required": ["start", "end"],
+			"additionalProperties": false
+		  }
+		},
+		"description": { "$ref": "common.json#/$defs/description" }
+	  },
+	  "required": ["active", "timezone"],
+	  "additionalProperties": false,
+	  "examples": [
+		{ "active": true, "timezone": "Asia/Jakarta", "cron": "0 2 * * *", "windows": [{ "start": "2025-12-24T00:00:00Z", "end": "2025-12-26T00:00:00Z" }] }
+	  ]
+	}`,
+
+	"threshold_policy": `
+	{
+	  "$schema": "http://json-schema.org/draft-07/schema#",
+	  "title": "threshold_policy",
+	  "type": "object",
+	  "x-defaults": "read",
+	  "properties": {
+		"metric": { "$ref": "common.json#/$defs/nonEmptyString" },
+		"unit": { "type": "string", "enum": ["count", "ms", "percent", "amount"] },
+		"min": { "type": ["number", "null"] },
+		"max": { "type": ["number", "null"] },
+		"inclusive": { "type": "boolean", "default": true },
+		"enabled": { "type": "boolean" },
+		"description": { "$ref": "common.json#/$defs/description" }
+	  },
+	  "required": ["metric", "unit", "enabled"],
+	  "additionalProperties": false,
+	  "examples": [
+		{ "metric": "p95_latency", "unit": "ms", "min": null, "max": 500, "inclusive": true, "enabled": true }
+	  ]
+	}`,
+}
+
+// Schemas returns a copy of the built-in schemas keyed by config type.
+func Schemas() map[string]string {
+	out := make(map[string]string, len(schemas))
+	for k, v := range schemas {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,212 @@
+// Package compat compares two versions of a JSON Schema structurally.
+//
+// Widened reports the places where next accepts documents prev rejects,
+// which is what breaks forward compatibility: readers still on prev would
+// reject data written against next. Both schemas are compared with their
+// references inlined. The comparison covers the keywords the config
+// schemas use (type, enum, const, required, dependentRequired, properties,
+// additionalProperties, unevaluatedProperties, items, numeric and length
+// bounds, pattern, format, uniqueItems); a change to anything else is not
+// detected.
+package compat
+
+import (
+	"configuration-management-service/internal/schema/compiler"
+	"configuration-management-service/internal/schema/model"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Widened returns the issues sorted by path; none means next is at least as
+// strict as prev for the covered keywords.
+func Widened(prev, next json.RawMessage) ([]model.SchemaIssue, error) {
+	p, err := compiler.Inline(prev)
+	if err != nil {
+		return nil, fmt.Errorf("previous schema: %w", err)
+	}
+	n, err := compiler.Inline(next)
+	if err != nil {
+		return nil, fmt.Errorf("new schema: %w", err)
+	}
+	var out []model.SchemaIssue
+	widened(p, n, "", &out)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+func widened(prev, next map[string]any, path string, out *[]model.SchemaIssue) {
+	add := func(p, format string, args ...any) {
+		*out = append(*out, model.SchemaIssue{Path: p, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if pt, ok := types(prev); ok {
+		nt, ok := types(next)
+		if !ok {
+			add(path+"/type", "type constraint %v removed", sorted(pt))
+		} else {
+			for t := range nt {
+				if !pt[t] && !(t == "integer" && pt["number"]) {
+					add(path+"/type", "type %q is newly accepted", t)
+				}
+			}
+		}
+	}
+
+	if pe, ok := prev["enum"].([]any); ok {
+		ne, ok := next["enum"].([]any)
+		if !ok {
+			add(path+"/enum", "enum constraint removed")
+		} else {
+			for _, v := range ne {
+				if !contains(pe, v) {
+					add(path+"/enum", "value %v is newly accepted", v)
+				}
+			}
+		}
+	}
+	if pc, ok := prev["const"]; ok && !reflect.DeepEqual(pc, next["const"]) {
+		add(path+"/const", "const changed or removed")
+	}
+
+	nr := stringSet(next["required"])
+	for _, r := range sorted(stringSet(prev["required"])) {
+		if !nr[r] {
+			add(path+"/required", "%q is no longer required", r)
+		}
+	}
+
+	pd, _ := prev["dependentRequired"].(map[string]any)
+	nd, _ := next["dependentRequired"].(map[string]any)
+	for _, name := range sortedKeys(pd) {
+		nr := stringSet(nd[name])
+		for _, r := range sorted(stringSet(pd[name])) {
+			if !nr[r] {
+				add(path+"/dependentRequired/"+escape(name), "%q is no longer required with %q", r, name)
+			}
+		}
+	}
+
+	pp, _ := prev["properties"].(map[string]any)
+	np, _ := next["properties"].(map[string]any)
+	closed := prev["additionalProperties"] == false || prev["unevaluatedProperties"] == false
+	for _, name := range sortedKeys(np) {
+		child := path + "/properties/" + escape(name)
+		nChild, _ := np[name].(map[string]any)
+		pChild, known := pp[name].(map[string]any)
+		switch {
+		case known && nChild != nil:
+			widened(pChild, nChild, child, out)
+		case !known && closed:
+			add(child, "property %q is newly accepted", name)
+		}
+	}
+	if closed && next["additionalProperties"] != false && next["unevaluatedProperties"] != false {
+		add(path+"/additionalProperties", "additional properties are newly accepted")
+	}
+	if pa, ok := prev["additionalProperties"].(map[string]any); ok {
+		if na, ok := next["additionalProperties"].(map[string]any); ok {
+			widened(pa, na, path+"/additionalProperties", out)
+		}
+	}
+
+	if pi, ok := prev["items"].(map[string]any); ok {
+		if ni, ok := next["items"].(map[string]any); ok {
+			widened(pi, ni, path+"/items", out)
+		} else {
+			add(path+"/items", "items constraint removed")
+		}
+	}
+
+	for _, kw := range []string{"minimum", "exclusiveMinimum", "minLength", "minItems", "minProperties"} {
+		pv, ok := number(prev[kw])
+		if !ok {
+			continue
+		}
+		if nv, ok := number(next[kw]); !ok || nv < pv {
+			add(path+"/"+kw, "lower bound relaxed from %v", pv)
+		}
+	}
+	for _, kw := range []string{"maximum", "exclusiveMaximum", "maxLength", "maxItems", "maxProperties"} {
+		pv, ok := number(prev[kw])
+		if !ok {
+			continue
+		}
+		if nv, ok := number(next[kw]); !ok || nv > pv {
+			add(path+"/"+kw, "upper bound relaxed from %v", pv)
+		}
+	}
+
+	for _, kw := range []string{"pattern", "format"} {
+		if pv, ok := prev[kw]; ok && pv != next[kw] {
+			add(path+"/"+kw, "%s changed from %v", kw, pv)
+		}
+	}
+	if prev["uniqueItems"] == true && next["uniqueItems"] != true {
+		add(path+"/uniqueItems", "duplicate items are newly accepted")
+	}
+}
+
+func types(node map[string]any) (map[string]bool, bool) {
+	switch t := node["type"].(type) {
+	case string:
+		return map[string]bool{t: true}, true
+	case []any:
+		return stringSet(t), true
+	default:
+		return nil, false
+	}
+}
+
+func stringSet(v any) map[string]bool {
+	out := map[string]bool{}
+	list, _ := v.([]any)
+	for _, x := range list {
+		if s, ok := x.(string); ok {
+			out[s] = true
+		}
+	}
+	return out
+}
+
+func sorted(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sortedKeys(m map[string]any) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func contains(list []any, v any) bool {
+	for _, x := range list {
+		if reflect.DeepEqual(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func number(v any) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+func escape(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,110 @@
+package compat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWidened(t *testing.T) {
+	const prev = `{
+		"type": "object",
+		"properties": {
+			"enabled": { "type": "boolean" },
+			"pct": { "type": "integer", "minimum": 0, "maximum": 100 },
+			"os": { "type": "array", "items": { "type": "string", "enum": ["ios", "android"] } }
+		},
+		"required": ["enabled"],
+		"additionalProperties": false
+	}`
+
+	cases := []struct {
+		name string
+		next string
+		want []model.SchemaIssue
+	}{
+		{
+			name: "when identical should report nothing",
+			next: prev,
+		},
+		{
+			name: "when stricter should report nothing",
+			next: `{
+				"type": "object",
+				"properties": {
+					"enabled": { "type": "boolean" },
+					"pct": { "type": "integer", "minimum": 10, "maximum": 50 }
+				},
+				"required": ["enabled", "pct"],
+				"additionalProperties": false
+			}`,
+		},
+		{
+			name: "when relaxed should report every widened keyword",
+			next: `{
+				"type": "object",
+				"properties": {
+					"enabled": { "type": ["boolean", "string"] },
+					"pct": { "type": "integer", "minimum": 0 },
+					"os": { "type": "array", "items": { "type": "string", "enum": ["ios", "android", "web"] } },
+					"region": { "type": "string" }
+				},
+				"additionalProperties": false
+			}`,
+			want: []model.SchemaIssue{
+				{Path: "/properties/enabled/type", Message: `type "string" is newly accepted`},
+				{Path: "/properties/os/items/enum", Message: "value web is newly accepted"},
+				{Path: "/properties/pct/maximum", Message: "upper bound relaxed from 100"},
+				{Path: "/properties/region", Message: `property "region" is newly accepted`},
+				{Path: "/required", Message: `"enabled" is no longer required`},
+			},
+		},
+		{
+			name: "when additional properties opened should report it",
+			next: `{"type":"object","properties":{"enabled":{"type":"boolean"},"pct":{"type":"integer","minimum":0,"maximum":100}},"required":["enabled"]}`,
+			want: []model.SchemaIssue{
+				{Path: "/additionalProperties", Message: "additional properties are newly accepted"},
+			},
+		},
+		{
+			name: "when fields move to shared definitions and unevaluatedProperties should report nothing",
+			next: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"enabled": { "type": "boolean" },
+					"pct": { "$ref": "common.json#/$defs/percentage" },
+					"os": { "type": "array", "items": { "type": "string", "enum": ["ios", "android"] } }
+				},
+				"required": ["enabled"],
+				"unevaluatedProperties": false
+			}`,
+		},
+		{
+			name: "when a shared definition is swapped for a looser one should report the relaxed bound",
+			next: `{
+				"type": "object",
+				"properties": {
+					"enabled": { "type": "boolean" },
+					"pct": { "$ref": "common.json#/$defs/percentage", "maximum": 1000 }
+				},
+				"required": ["enabled"],
+				"additionalProperties": false
+			}`,
+			want: []model.SchemaIssue{
+				{Path: "/properties/pct/maximum", Message: "upper bound relaxed from 100"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Widened(json.RawMessage(prev), json.RawMessage(tc.next))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Package compiler compiles and applies the JSON Schemas of config types.
+//
+// A schema declares its draft in "$schema": draft-07, which is also the
+// default, 2019-09 or 2020-12, so unevaluatedProperties, dependentRequired
+// and if/then/else work as their draft defines them. Formats are asserted
+// in every draft.
+//
+// References are resolved in process: a schema can $ref into its own
+// $defs or definitions, and into the shared definitions document of the
+// builtin package as "common.json#/$defs/<name>". Nothing is fetched from
+// the network or the file system; any other reference fails to compile.
+package compiler
+
+import (
+	"bytes"
+	"configuration-management-service/internal/schema/builtin"
+	"configuration-management-service/pkg/jsonx"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const (
+	// BaseURL is the base URI of every compiled schema; type schemas are
+	// compiled as BaseURL+<type>.
+	BaseURL = "https://config-service.local/schemas/"
+	// DefinitionsURL is where the shared definitions document resolves.
+	DefinitionsURL = BaseURL + "common.json"
+)
+
+// Compile compiles the schema of schemaType.
+func Compile(schemaType string, schema []byte) (*jsonschema.Schema, error) {
+	doc, err := jsonx.Decode(schema)
+	if err != nil {
+		return nil, err
+	}
+	defs, err := jsonx.Decode([]byte(builtin.Definitions()))
+	if err != nil {
+		return nil, err
+	}
+
+	c := jsonschema.NewCompiler()
+	c.DefaultDraft(jsonschema.Draft7)
+	c.AssertFormat()
+	c.UseLoader(offline{})
+	if err := c.AddResource(DefinitionsURL, defs); err != nil {
+		return nil, err
+	}
+	loc := BaseURL + url.PathEscape(schemaType)
+	if err := c.AddResource(loc, doc); err != nil {
+		return nil, err
+	}
+	return c.Compile(loc)
+}
+
+// printer renders failure messages in English, as the library does.
+var printer = message.NewPrinter(language.English)
+
+// offline refuses every URL the compiler has not been given.
+type offline struct{}
+
+func (offline) Load(string) (any, error) {
+	return nil, fmt.Errorf("not available offline; only %s can be referenced", DefinitionsURL)
+}
+
+// Failure is one value a schema rejects.
+type Failure struct {
+	// Path locates the value in the document; for a missing property it
+	// ends with the property name.
+	Path     []string
+	Keyword  string
+	Expected any
+	Message  string
+}
+
+// Validate returns every failure of data against schema, none when the
+// data is valid. An error means data is not JSON.
+func Validate(schema *jsonschema.Schema, data []byte) ([]Failure, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	err = schema.Validate(doc)
+	if err == nil {
+		return nil, nil
+	}
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return nil, err
+	}
+	var out []Failure
+	flatten(verr, &out)
+	return out, nil
+}
+
+// flatten collects the failures under e. Errors that only group others
+// are skipped; anyOf, oneOf and not are reported themselves, since which
+// branch was meant cannot be told.
+func flatten(e *jsonschema.ValidationError, out *[]Failure) {
+	at := e.InstanceLocation
+	switch k := e.ErrorKind.(type) {
+	case *kind.Schema, *kind.Group, *kind.Reference, *kind.AllOf:
+		for _, c := range e.Causes {
+			flatten(c, out)
+		}
+	case *kind.Required:
+		for _, name := range k.Missing {
+			*out = append(*out, failure(at, name, "required", nil, &kind.Required{Missing: []string{name}}))
+		}
+	case *kind.DependentRequired:
+		for _, name := range k.Missing {
+			*out = append(*out, failure(at, name, "dependentRequired", k.Prop, &kind.DependentRequired{Prop: k.Prop, Missing: []string{name}}))
+		}
+	case *kind.Dependency:
+		for _, name := range k.Missing {
+			*out = append(*out, failure(at, name, "dependencies", k.Prop, &kind.Dependency{Prop: k.Prop, Missing: []string{name}}))
+		}
+	case *kind.AdditionalProperties:
+		for _, name := range k.Properties {
+			*out = append(*out, failure(at, name, "additionalProperties", false, &kind.AdditionalProperties{Properties: []string{name}}))
+		}
+	case *kind.FalseSchema:
+		keyword := falseKeyword(e.SchemaURL)
+		if (keyword == "unevaluatedProperties" || keyword == "additionalProperties") && len(at) > 0 {
+			// The value itself is reported; name it like additionalProperties does.
+			parent, name := at[:len(at)-1], at[len(at)-1]
+			f := failure(parent, name, keyword, false, k)
+			f.Message = label(parent) + ": " + printer.Sprintf("%s %q not allowed", keyword, name)
+			*out = append(*out, f)
+			return
+		}
+		*out = append(*out, failure(at, "", keyword, false, k))
+	default:
+		keyword := "schema"
+		if path := k.KeywordPath(); len(path) > 0 {
+			keyword = path[0]
+		}
+		*out = append(*out, failure(at, "", keyword, expected(k), k))
+	}
+}
+
+func failure(at []string, name, keyword string, want any, k jsonschema.ErrorKind) Failure {
+	path := append([]string(nil), at...)
+	if name != "" {
+		path = append(path, name)
+	}
+	return Failure{Path: path, Keyword: keyword, Expected: want, Message: label(at) + ": " + k.LocalizedString(printer)}
+}
+
+// label names a location in messages.
+func label(at []string) string {
+	if len(at) == 0 {
+		return "(root)"
+	}
+	return pointer(at)
+}
+
+// falseKeyword names the keyword whose false schema rejected a value, e.g.
+// unevaluatedProperties; a property declared false reports "false".
+func falseKeyword(schemaURL string) string {
+	_, frag, _ := strings.Cut(schemaURL, "#")
+	last := frag[strings.LastIndex(frag, "/")+1:]
+	switch last {
+	case "additionalProperties", "unevaluatedProperties", "items", "additionalItems", "unevaluatedItems":
+		return last
+	}
+	return "false"
+}
+
+// expected returns the value the keyword of k asked for.
+func expected(k jsonschema.ErrorKind) any {
+	switch k := k.(type) {
+	case *kind.Type:
+		if len(k.Want) == 1 {
+			return k.Want[0]
+		}
+		return k.Want
+	case *kind.Enum:
+		return k.Want
+	case *kind.Const:
+		return k.Want
+	case *kind.Format:
+		return k.Want
+	case *kind.Pattern:
+		return k.Want
+	case *kind.Minimum:
+		return number(k.Want)
+	case *kind.Maximum:
+		return number(k.Want)
+	case *kind.ExclusiveMinimum:
+		return number(k.Want)
+	case *kind.ExclusiveMaximum:
+		return number(k.Want)
+	case *kind.MultipleOf:
+		return number(k.Want)
+	case *kind.MinLength:
+		return k.Want
+	case *kind.MaxLength:
+		return k.Want
+	case *kind.MinItems:
+		return k.Want
+	case *kind.MaxItems:
+		return k.Want
+	case *kind.MinProperties:
+		return k.Want
+	case *kind.MaxProperties:
+		return k.Want
+	default:
+		return nil
+	}
+}
+
+// number turns the exact bounds the library keeps into plain JSON numbers.
+func number(r *big.Rat) any {
+	if r == nil {
+		return nil
+	}
+	f, _ := r.Float64()
+	return f
+}
+
+func pointer(segments []string) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteString("/" + strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
@@ -0,0 +1,172 @@
+package compiler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"configuration-management-service/internal/schema/builtin"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		err    string
+	}{
+		{
+			name:   "when draft-07 schema references the shared definitions should compile",
+			schema: `{"$schema":"http://json-schema.org/draft-07/schema#","properties":{"tags":{"$ref":"common.json#/$defs/stringSet"}}}`,
+		},
+		{
+			name:   "when 2020-12 schema references its own $defs should compile",
+			schema: `{"$schema":"https://json-schema.org/draft/2020-12/schema","$defs":{"port":{"type":"integer"}},"properties":{"port":{"$ref":"#/$defs/port"}}}`,
+		},
+		{
+			name:   "when reference is absolute to the definitions URL should compile",
+			schema: `{"properties":{"pct":{"$ref":"https://config-service.local/schemas/common.json#/$defs/percentage"}}}`,
+		},
+		{
+			name:   "when reference points to the network should fail offline",
+			schema: `{"properties":{"a":{"$ref":"https://example.com/defs.json#/a"}}}`,
+			err:    "not available offline",
+		},
+		{
+			name:   "when reference points to a file should fail offline",
+			schema: `{"properties":{"a":{"$ref":"file:///etc/defs.json"}}}`,
+			err:    "not available offline",
+		},
+		{
+			name:   "when shared definition does not exist should fail",
+			schema: `{"properties":{"a":{"$ref":"common.json#/$defs/nope"}}}`,
+			err:    "common.json#/$defs/nope",
+		},
+		{
+			name:   "when schema breaks its meta-schema should fail",
+			schema: `{"type":"nope"}`,
+			err:    "/type",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Compile("geo_rule", []byte(tc.schema))
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestCompile_Builtin(t *testing.T) {
+	for typ, schema := range builtin.Schemas() {
+		_, err := Compile(typ, []byte(schema))
+		assert.NoError(t, err, typ)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		data   string
+		want   []Failure
+	}{
+		{
+			name:   "when shared definition rejects a value should report it at the value",
+			schema: `{"properties":{"pct":{"$ref":"common.json#/$defs/percentage"}}}`,
+			data:   `{"pct":120}`,
+			want:   []Failure{{Path: []string{"pct"}, Keyword: "maximum", Expected: float64(100), Message: "/pct: maximum: got 120, want 100"}},
+		},
+		{
+			name:   "when unevaluatedProperties false sees a property no subschema declared should reject it",
+			schema: `{"$schema":"https://json-schema.org/draft/2020-12/schema","allOf":[{"properties":{"a":{}}}],"properties":{"b":{}},"unevaluatedProperties":false}`,
+			data:   `{"a":1,"b":2,"c":3}`,
+			want:   []Failure{{Path: []string{"c"}, Keyword: "unevaluatedProperties", Expected: false, Message: `(root): unevaluatedProperties "c" not allowed`}},
+		},
+		{
+			name:   "when dependentRequired property is missing should report each missing property",
+			schema: `{"$schema":"https://json-schema.org/draft/2019-09/schema","dependentRequired":{"card":["expiry","cvv"]}}`,
+			data:   `{"card":"4111"}`,
+			want: []Failure{
+				{Path: []string{"expiry"}, Keyword: "dependentRequired", Expected: "card", Message: "(root): properties 'expiry' required, if 'card' exists"},
+				{Path: []string{"cvv"}, Keyword: "dependentRequired", Expected: "card", Message: "(root): properties 'cvv' required, if 'card' exists"},
+			},
+		},
+		{
+			name:   "when if matches and then fails should report what then requires",
+			schema: `{"$schema":"https://json-schema.org/draft/2020-12/schema","if":{"properties":{"mode":{"const":"fixed"}}},"then":{"required":["value"]},"else":{"required":["formula"]}}`,
+			data:   `{"mode":"fixed"}`,
+			want:   []Failure{{Path: []string{"value"}, Keyword: "required", Message: "(root): missing property 'value'"}},
+		},
+		{
+			name:   "when if does not match and else fails should report what else requires",
+			schema: `{"$schema":"https://json-schema.org/draft/2020-12/schema","if":{"properties":{"mode":{"const":"fixed"}}},"then":{"required":["value"]},"else":{"required":["formula"]}}`,
+			data:   `{"mode":"computed"}`,
+			want:   []Failure{{Path: []string{"formula"}, Keyword: "required", Message: "(root): missing property 'formula'"}},
+		},
+		{
+			name:   "when data is valid should return nothing",
+			schema: `{"properties":{"tags":{"$ref":"common.json#/$defs/stringSet"}}}`,
+			data:   `{"tags":["a","b"]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			compiled, err := Compile("geo_rule", []byte(tc.schema))
+			if !assert.NoError(t, err) {
+				return
+			}
+			got, err := Validate(compiled, []byte(tc.data))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestInline(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{
+			name:   "when property references the shared definitions should inline the target",
+			schema: `{"properties":{"names":{"$ref":"common.json#/$defs/nonEmptyStringSet"}}}`,
+			want:   `{"properties":{"names":{"type":"array","items":{"type":"string","minLength":1},"uniqueItems":true}}}`,
+		},
+		{
+			name:   "when reference has siblings should keep them over the target",
+			schema: `{"$defs":{"pct":{"type":"integer","maximum":100}},"properties":{"p":{"$ref":"#/$defs/pct","maximum":50,"description":"Share"}}}`,
+			want:   `{"properties":{"p":{"type":"integer","maximum":50,"description":"Share"}}}`,
+		},
+		{
+			name:   "when reference is recursive should leave the inner one in place",
+			schema: `{"definitions":{"node":{"type":"object","properties":{"next":{"$ref":"#/definitions/node"}}}},"properties":{"head":{"$ref":"#/definitions/node"}}}`,
+			want:   `{"properties":{"head":{"type":"object","properties":{"next":{"$ref":"#/definitions/node"}}}}}`,
+		},
+		{
+			name:   "when reference is unknown should leave it in place",
+			schema: `{"properties":{"a":{"$ref":"https://example.com/a.json"}}}`,
+			want:   `{"properties":{"a":{"$ref":"https://example.com/a.json"}}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Inline([]byte(tc.schema))
+			if !assert.NoError(t, err) {
+				return
+			}
+			raw, err := json.Marshal(got)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(raw))
+		})
+	}
+}
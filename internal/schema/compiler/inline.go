@@ -0,0 +1,107 @@
+package compiler
+
+import (
+	"configuration-management-service/internal/schema/builtin"
+	"configuration-management-service/pkg/jsonx"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// rootURL stands for the schema being inlined when resolving its references.
+const rootURL = BaseURL + "schema"
+
+// Inline decodes schema with every $ref it can resolve replaced by its
+// target, for code that walks schemas keyword by keyword (secrets,
+// defaults, compatibility, generators). Keywords next to a $ref are kept
+// and win over the target's. References into a schema already being
+// inlined, to anchors or to unknown documents stay as they are. The
+// root's $defs and definitions are dropped; numbers stay json.Number.
+func Inline(schema []byte) (map[string]any, error) {
+	doc, err := jsonx.Decode(schema)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return nil, errors.New("schema is not an object")
+	}
+	defs, err := jsonx.Decode([]byte(builtin.Definitions()))
+	if err != nil {
+		return nil, err
+	}
+
+	in := inliner{docs: map[string]any{rootURL: root, DefinitionsURL: defs}}
+	out := in.node(root, rootURL, nil).(map[string]any)
+	delete(out, "$defs")
+	delete(out, "definitions")
+	return out, nil
+}
+
+type inliner struct {
+	// docs holds the documents references can point into, by URL.
+	docs map[string]any
+}
+
+// node inlines v, a value of the document at base; seen holds the
+// references being inlined above it.
+func (in inliner) node(v any, base string, seen []string) any {
+	switch x := v.(type) {
+	case map[string]any:
+		if ref, ok := x["$ref"].(string); ok {
+			if target, doc, key, ok := in.resolve(base, ref); ok && !slices.Contains(seen, key) {
+				if resolved, ok := in.node(target, doc, append(seen, key)).(map[string]any); ok {
+					out := make(map[string]any, len(resolved)+len(x))
+					for k, e := range resolved {
+						out[k] = e
+					}
+					for k, e := range x {
+						if k != "$ref" {
+							out[k] = in.node(e, base, seen)
+						}
+					}
+					return out
+				}
+			}
+		}
+		out := make(map[string]any, len(x))
+		for k, e := range x {
+			out[k] = in.node(e, base, seen)
+		}
+		return out
+	case []any:
+		out := make([]any, len(x))
+		for i, e := range x {
+			out[i] = in.node(e, base, seen)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// resolve returns the target of ref, the URL of the document holding it
+// and ref made absolute. Only JSON pointer fragments are followed.
+func (in inliner) resolve(base, ref string) (any, string, string, bool) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return nil, "", "", false
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return nil, "", "", false
+	}
+	abs := b.ResolveReference(r)
+	frag := abs.Fragment
+	abs.Fragment = ""
+	doc, ok := in.docs[abs.String()]
+	if !ok || (frag != "" && !strings.HasPrefix(frag, "/")) {
+		return nil, "", "", false
+	}
+	target, ok := jsonx.Lookup(doc, frag)
+	if !ok {
+		return nil, "", "", false
+	}
+	return target, abs.String(), abs.String() + "#" + frag, true
+}
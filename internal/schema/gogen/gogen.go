@@ -0,0 +1,561 @@
+// Package gogen renders config type schemas as Go types for API consumers.
+//
+// Every config type becomes a struct named after the type (feature_toggle
+// becomes FeatureToggle) with a Validate method, nested objects and array
+// items become structs of their own, and string enums become named types
+// with one constant per value. pkg/configclient holds the generated types
+// of the built-in schemas; regenerate them with `go run ./cmd/typegen`
+// after changing a built-in schema, a test fails while the file is out of
+// date.
+//
+// Properties the schema does not require, and nullable ones, are pointers
+// so an absent value stays distinguishable from the zero value. Validate
+// checks the keywords a decoded value can still break: enum and const,
+// numeric bounds, string lengths, item counts and uniqueItems. Required
+// properties, additionalProperties and formats are enforced by decoding
+// and by the service itself, and the semantic rules of the built-in types
+// are not repeated. References are inlined, so a shared definition becomes
+// a field of every struct that uses it; keywords without a Go counterpart,
+// such as if/then/else or dependentRequired, are left to the service.
+package gogen
+
+import (
+	"bytes"
+	"configuration-management-service/internal/schema/compiler"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ClientImport is the import path of the client the typed helpers use.
+const ClientImport = "configuration-management-service/pkg/configclient"
+
+// Options controls the generated file.
+type Options struct {
+	// Package is the package name of the generated file.
+	Package string
+	// Source names the schemas in the file header, e.g. "the built-in schemas".
+	Source string
+}
+
+// initialisms are written upper-case in Go names.
+var initialisms = map[string]bool{
+	"api": true, "http": true, "id": true, "ip": true, "json": true,
+	"os": true, "sms": true, "uri": true, "url": true, "uuid": true,
+}
+
+// TypeName names the Go type of a config type: feature_toggle becomes
+// FeatureToggle.
+func TypeName(schemaType string) string {
+	return camel(schemaType)
+}
+
+// camel joins the alphanumeric words of s in CamelCase.
+func camel(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// Generate renders the types of schemas, keyed by config type, as a
+// formatted Go file. In package configclient the typed getters are methods
+// of Client; elsewhere they are functions taking one.
+func Generate(opts Options, schemas map[string]string) ([]byte, error) {
+	types := make([]string, 0, len(schemas))
+	for t := range schemas {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	g := &generator{names: map[string]bool{}, structs: map[string]bool{}, inClient: opts.Package == "configclient"}
+	for _, t := range types {
+		obj, err := compiler.Inline([]byte(schemas[t]))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+		if obj["type"] != "object" {
+			return nil, fmt.Errorf("%s: schema is not an object schema", t)
+		}
+		name := TypeName(t)
+		if name == "" {
+			return nil, fmt.Errorf("%s: type has no usable name", t)
+		}
+		if err := g.structType(name, fmt.Sprintf("%s is the data of %s configs.", name, t), obj); err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by go run ./cmd/typegen from %s; DO NOT EDIT.\n\n", opts.Source)
+	fmt.Fprintf(&src, "package %s\n\n", opts.Package)
+
+	body := g.body(types)
+	src.WriteString("import (\n")
+	for _, imp := range []string{"context", "errors", "fmt", "strconv", "time", "unicode/utf8"} {
+		short := imp[strings.LastIndex(imp, "/")+1:]
+		if strings.Contains(body, short+".") {
+			fmt.Fprintf(&src, "%q\n", imp)
+		}
+	}
+	if !g.inClient {
+		fmt.Fprintf(&src, "\n%q\n", ClientImport)
+	}
+	src.WriteString(")\n\n")
+	src.WriteString(body)
+
+	out, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return out, nil
+}
+
+type generator struct {
+	decls bytes.Buffer
+	// names holds every declared Go name.
+	names map[string]bool
+	// structs holds the names of the declared structs.
+	structs  map[string]bool
+	inClient bool
+	// unique is set when a uniqueItems check needs the duplicate helper.
+	unique bool
+}
+
+// claim reserves a Go name; two schema paths generating the same name
+// fail the generation.
+func (g *generator) claim(name string) error {
+	if g.names[name] {
+		return fmt.Errorf("%s is generated twice", name)
+	}
+	g.names[name] = true
+	return nil
+}
+
+// body renders the type constants, declarations, helpers and getters.
+func (g *generator) body(types []string) string {
+	var b strings.Builder
+	b.WriteString("// Config types with generated data types.\nconst (\n")
+	for _, t := range types {
+		fmt.Fprintf(&b, "Type%s = %q\n", TypeName(t), t)
+	}
+	b.WriteString(")\n\n")
+	b.WriteString(g.decls.String())
+
+	if g.unique {
+		b.WriteString(`// duplicate reports whether items holds a value twice.
+func duplicate[T comparable](items []T) bool {
+	seen := make(map[T]bool, len(items))
+	for _, item := range items {
+		if seen[item] {
+			return true
+		}
+		seen[item] = true
+	}
+	return false
+}
+
+`)
+	}
+
+	client := "c *Client"
+	call := "c.GetAs"
+	if !g.inClient {
+		client = "c *configclient.Client"
+	}
+	for _, t := range types {
+		name := TypeName(t)
+		fmt.Fprintf(&b, "// Get%[1]s reads the resolved latest version of the %[2]s config name\n// and validates it.\n", name, t)
+		if g.inClient {
+			fmt.Fprintf(&b, "func (%s) Get%s(ctx context.Context, name string) (%s, error) {\n", client, name, name)
+		} else {
+			fmt.Fprintf(&b, "func Get%s(ctx context.Context, %s, name string) (%s, error) {\n", name, client, name)
+		}
+		fmt.Fprintf(&b, "var v %s\nerr := %s(ctx, Type%s, name, &v)\nreturn v, err\n}\n\n", name, call, name)
+	}
+	return b.String()
+}
+
+// field is a property of a generated struct.
+type field struct {
+	name, json, typ string
+	// ptr is set for optional and nullable properties.
+	ptr    bool
+	schema map[string]any
+	doc    []string
+}
+
+// structType declares name for an object schema, with Validate on roots
+// and validate on every struct.
+func (g *generator) structType(name, doc string, schema map[string]any) error {
+	if err := g.claim(name); err != nil {
+		return err
+	}
+	g.structs[name] = true
+	props, _ := schema["properties"].(map[string]any)
+	required := map[string]bool{}
+	if list, ok := schema["required"].([]any); ok {
+		for _, r := range list {
+			if s, ok := r.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fields := make([]field, 0, len(keys))
+	for _, k := range keys {
+		p, ok := props[k].(map[string]any)
+		if !ok {
+			return fmt.Errorf("property %s is not a schema", k)
+		}
+		f := field{name: camel(k), json: k, schema: p}
+		if f.name == "" {
+			return fmt.Errorf("property %q has no usable name", k)
+		}
+		typ, nullable, err := g.goType(name+f.name, p)
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		f.typ = typ
+		f.ptr = (nullable || !required[k]) && !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[")
+		f.doc = fieldDoc(p)
+		fields = append(fields, f)
+	}
+
+	fmt.Fprintf(&g.decls, "// %s\ntype %s struct {\n", doc, name)
+	for _, f := range fields {
+		for _, line := range f.doc {
+			fmt.Fprintf(&g.decls, "// %s\n", line)
+		}
+		typ, tag := f.typ, f.json
+		if f.ptr {
+			typ = "*" + typ
+		}
+		if !required[f.json] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&g.decls, "%s %s `json:%q`\n", f.name, typ, tag)
+	}
+	g.decls.WriteString("}\n\n")
+
+	var checks strings.Builder
+	for _, f := range fields {
+		path := fmt.Sprintf("path+%q", "/"+f.json)
+		if f.ptr {
+			var inner strings.Builder
+			g.checks(&inner, "*v."+f.name, path, f.typ, f.schema, 0)
+			if inner.Len() > 0 {
+				fmt.Fprintf(&checks, "if v.%s != nil {\n%s}\n", f.name, inner.String())
+			}
+			continue
+		}
+		if _, min := f.schema["minItems"]; min && !required[f.json] && strings.HasPrefix(f.typ, "[]") {
+			// An absent optional array decodes to nil and has no items to count.
+			var inner strings.Builder
+			g.checks(&inner, "v."+f.name, path, f.typ, f.schema, 0)
+			fmt.Fprintf(&checks, "if v.%s != nil {\n%s}\n", f.name, inner.String())
+			continue
+		}
+		g.checks(&checks, "v."+f.name, path, f.typ, f.schema, 0)
+	}
+
+	fmt.Fprintf(&g.decls, "// Validate reports every value of v the schema rejects.\nfunc (v %s) Validate() error {\nreturn errors.Join(v.validate(\"\")...)\n}\n\n", name)
+	fmt.Fprintf(&g.decls, "func (v %s) validate(path string) []error {\n", name)
+	if checks.Len() == 0 {
+		g.decls.WriteString("return nil\n}\n\n")
+		return nil
+	}
+	fmt.Fprintf(&g.decls, "var errs []error\n%sreturn errs\n}\n\n", checks.String())
+	return nil
+}
+
+// goType returns the Go type of schema, declaring the structs and enums it
+// needs under name, and whether null is an allowed value.
+func (g *generator) goType(name string, schema map[string]any) (string, bool, error) {
+	typ, nullable := schemaType(schema)
+	if values := enumValues(schema); values != nil && (typ == "string" || typ == "") {
+		if err := g.enumType(name, values); err != nil {
+			return "", false, err
+		}
+		return name, nullable, nil
+	}
+
+	switch typ {
+	case "string":
+		if schema["format"] == "date-time" {
+			return "time.Time", nullable, nil
+		}
+		return "string", nullable, nil
+	case "integer":
+		return "int", nullable, nil
+	case "number":
+		return "float64", nullable, nil
+	case "boolean":
+		return "bool", nullable, nil
+	case "array":
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			return "[]any", nullable, nil
+		}
+		// Enum items are named after the property, object items get a
+		// name of their own.
+		itemName := name + "Item"
+		if enumValues(items) != nil {
+			itemName = name
+		}
+		elem, _, err := g.goType(itemName, items)
+		if err != nil {
+			return "", false, err
+		}
+		return "[]" + elem, nullable, nil
+	case "object":
+		if props, ok := schema["properties"].(map[string]any); ok && len(props) > 0 {
+			if err := g.structType(name, fmt.Sprintf("%s is a nested object of the config data.", name), schema); err != nil {
+				return "", false, err
+			}
+			return name, nullable, nil
+		}
+		if extra, ok := schema["additionalProperties"].(map[string]any); ok {
+			elem, _, err := g.goType(name+"Value", extra)
+			if err != nil {
+				return "", false, err
+			}
+			return "map[string]" + elem, nullable, nil
+		}
+		return "map[string]any", nullable, nil
+	}
+	return "any", nullable, nil
+}
+
+// enumType declares name as a string type with a constant per value.
+func (g *generator) enumType(name string, values []string) error {
+	if err := g.claim(name); err != nil {
+		return err
+	}
+	fmt.Fprintf(&g.decls, "// %s is one of the values the schema allows.\ntype %s string\n\n", name, name)
+	fmt.Fprintf(&g.decls, "// Values of %s.\nconst (\n", name)
+	for _, v := range values {
+		c := name + camel(v)
+		if c == name {
+			return fmt.Errorf("enum value %q has no usable name", v)
+		}
+		if err := g.claim(c); err != nil {
+			return err
+		}
+		fmt.Fprintf(&g.decls, "%s %s = %q\n", c, name, v)
+	}
+	g.decls.WriteString(")\n\n")
+	fmt.Fprintf(&g.decls, "// Valid reports whether v is one of the values of %s.\nfunc (v %s) Valid() bool {\nswitch v {\ncase ", name, name)
+	for i, v := range values {
+		if i > 0 {
+			g.decls.WriteString(", ")
+		}
+		g.decls.WriteString(name + camel(v))
+	}
+	g.decls.WriteString(":\nreturn true\n}\nreturn false\n}\n\n")
+	return nil
+}
+
+// checks writes the validation of expr, a value of Go type typ, against
+// schema. path is the Go expression of its JSON pointer; depth names the
+// loop variables of nested arrays and maps.
+func (g *generator) checks(b *strings.Builder, expr, path, typ string, schema map[string]any, depth int) {
+	errf := func(format string, args ...any) {
+		fmt.Fprintf(b, "errs = append(errs, fmt.Errorf(\"%%s: %s\", %s))\n", fmt.Sprintf(format, args...), path)
+	}
+
+	if enumValues(schema) != nil && !strings.HasPrefix(typ, "[]") && typ != "string" {
+		fmt.Fprintf(b, "if !%s.Valid() {\n", recv(expr))
+		fmt.Fprintf(b, "errs = append(errs, fmt.Errorf(\"%%s: %%q is not one of the allowed values\", %s, %s))\n}\n", path, expr)
+		return
+	}
+
+	switch typ {
+	case "int", "float64":
+		for _, c := range []struct{ key, op, msg string }{
+			{"minimum", "<", "must be >="},
+			{"maximum", ">", "must be <="},
+			{"exclusiveMinimum", "<=", "must be >"},
+			{"exclusiveMaximum", ">=", "must be <"},
+		} {
+			n, ok := schema[c.key].(json.Number)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(b, "if %s %s %s {\n", expr, c.op, n)
+			errf("%s %s", c.msg, n)
+			b.WriteString("}\n")
+		}
+		return
+	case "string":
+		for _, c := range []struct{ key, op, msg string }{
+			{"minLength", "<", "length must be >= %s"},
+			{"maxLength", ">", "length must be <= %s"},
+		} {
+			n, ok := schema[c.key].(json.Number)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(b, "if utf8.RuneCountInString(%s) %s %s {\n", expr, c.op, n)
+			errf(c.msg, n)
+			b.WriteString("}\n")
+		}
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(typ, "[]"):
+		elem := typ[2:]
+		for _, c := range []struct{ key, op, msg string }{
+			{"minItems", "<", "must have >= %s items"},
+			{"maxItems", ">", "must have <= %s items"},
+		} {
+			n, ok := schema[c.key].(json.Number)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(b, "if len(%s) %s %s {\n", expr, c.op, n)
+			errf(c.msg, n)
+			b.WriteString("}\n")
+		}
+		if u, _ := schema["uniqueItems"].(bool); u && g.comparable(elem) {
+			g.unique = true
+			fmt.Fprintf(b, "if duplicate(%s) {\n", expr)
+			errf("items must be unique")
+			b.WriteString("}\n")
+		}
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			return
+		}
+		var inner strings.Builder
+		i, e := fmt.Sprintf("i%d", depth), fmt.Sprintf("e%d", depth)
+		g.checks(&inner, e, concat(path, "/")+"+strconv.Itoa("+i+")", elem, items, depth+1)
+		if inner.Len() > 0 {
+			fmt.Fprintf(b, "for %s, %s := range %s {\n%s}\n", i, e, expr, inner.String())
+		}
+	case strings.HasPrefix(typ, "map[string]"):
+		extra, ok := schema["additionalProperties"].(map[string]any)
+		if !ok {
+			return
+		}
+		var inner strings.Builder
+		k, e := fmt.Sprintf("k%d", depth), fmt.Sprintf("e%d", depth)
+		g.checks(&inner, e, concat(path, "/")+"+"+k, typ[len("map[string]"):], extra, depth+1)
+		if inner.Len() > 0 {
+			fmt.Fprintf(b, "for %s, %s := range %s {\n%s}\n", k, e, expr, inner.String())
+		}
+	default:
+		if g.structs[typ] {
+			fmt.Fprintf(b, "errs = append(errs, %s.validate(%s)...)\n", recv(expr), path)
+		}
+	}
+}
+
+// recv returns expr as a method receiver; methods of a dereferenced
+// pointer are called on the pointer.
+func recv(expr string) string {
+	return strings.TrimPrefix(expr, "*")
+}
+
+// concat appends the literal lit to the string expression path, merging
+// it into a trailing literal.
+func concat(path, lit string) string {
+	if strings.HasSuffix(path, `"`) {
+		return path[:len(path)-1] + lit + `"`
+	}
+	return fmt.Sprintf("%s+%q", path, lit)
+}
+
+// comparable reports whether values of typ can be map keys. Generated
+// structs may hold slices, so they are left out.
+func (g *generator) comparable(typ string) bool {
+	switch typ {
+	case "string", "int", "float64", "bool", "time.Time":
+		return true
+	}
+	return g.names[typ] && !g.structs[typ]
+}
+
+// schemaType returns the single non-null type of schema and whether null
+// is allowed too. Schemas allowing several other types yield "".
+func schemaType(schema map[string]any) (string, bool) {
+	switch t := schema["type"].(type) {
+	case string:
+		return t, false
+	case []any:
+		var kept []string
+		nullable := false
+		for _, e := range t {
+			s, _ := e.(string)
+			if s == "null" {
+				nullable = true
+				continue
+			}
+			kept = append(kept, s)
+		}
+		if len(kept) == 1 {
+			return kept[0], nullable
+		}
+		return "", nullable
+	}
+	if _, ok := schema["const"].(string); ok {
+		return "string", false
+	}
+	return "", false
+}
+
+// enumValues returns the string values of enum or const, or nil when the
+// schema has neither or allows other values.
+func enumValues(schema map[string]any) []string {
+	var list []any
+	if c, ok := schema["const"]; ok {
+		list = []any{c}
+	} else if e, ok := schema["enum"].([]any); ok {
+		list = e
+	} else {
+		return nil
+	}
+	values := make([]string, 0, len(list))
+	for _, v := range list {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		values = append(values, s)
+	}
+	return values
+}
+
+// fieldDoc returns the comment lines of a property.
+func fieldDoc(schema map[string]any) []string {
+	var lines []string
+	if d, ok := schema["description"].(string); ok && d != "" {
+		lines = append(lines, strings.Split(strings.TrimSpace(d), "\n")...)
+	}
+	if secret, _ := schema["x-secret"].(bool); secret {
+		lines = append(lines, "Secret: encrypted at rest and redacted unless read with reveal.")
+	}
+	if d, ok := schema["default"]; ok {
+		raw, _ := json.Marshal(d)
+		lines = append(lines, fmt.Sprintf("Defaults to %s.", raw))
+	}
+	return lines
+}
@@ -0,0 +1,116 @@
+package gogen
+
+import (
+	"os"
+	"testing"
+
+	"configuration-management-service/internal/schema/builtin"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeName(t *testing.T) {
+	cases := map[string]string{
+		"feature_toggle":  "FeatureToggle",
+		"service_client":  "ServiceClient",
+		"geo":             "Geo",
+		"api_key":         "APIKey",
+		"p95-latency.ms":  "P95LatencyMs",
+		"__experiment__2": "Experiment2",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, TypeName(in), in)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	const geo = `{
+	  "type": "object",
+	  "properties": {
+		"country": { "type": "string", "minLength": 2, "maxLength": 2 },
+		"mode": { "enum": ["allow", "deny"] },
+		"radius_km": { "type": ["number", "null"], "exclusiveMinimum": 0 },
+		"cities": { "type": "array", "items": { "type": "string" }, "maxItems": 5, "uniqueItems": true },
+		"zones": { "type": "array", "items": { "type": "string" }, "minItems": 1 },
+		"labels": { "type": "object", "additionalProperties": { "type": "string", "minLength": 1 } }
+	  },
+	  "required": ["country", "mode"]
+	}`
+
+	cases := []struct {
+		name     string
+		pkg      string
+		schemas  map[string]string
+		contains []string
+		err      string
+	}{
+		{
+			name:    "when custom package should generate types, checks and getter functions",
+			pkg:     "geoconfig",
+			schemas: map[string]string{"geo_rule": geo},
+			contains: []string{
+				`"configuration-management-service/pkg/configclient"`,
+				"TypeGeoRule = \"geo_rule\"",
+				"type GeoRuleMode string",
+				"GeoRuleModeDeny  GeoRuleMode = \"deny\"",
+				"Country  string            `json:\"country\"`",
+				"RadiusKm *float64          `json:\"radius_km,omitempty\"`",
+				"Labels   map[string]string `json:\"labels,omitempty\"`",
+				"if utf8.RuneCountInString(v.Country) > 2 {",
+				"if *v.RadiusKm <= 0 {",
+				"if len(v.Cities) > 5 {",
+				"if duplicate(v.Cities) {",
+				"if v.Zones != nil {\n\t\tif len(v.Zones) < 1 {",
+				"for k0, e0 := range v.Labels {",
+				`path+"/labels/"+k0`,
+				"if !v.Mode.Valid() {",
+				"func GetGeoRule(ctx context.Context, c *configclient.Client, name string) (GeoRule, error) {",
+			},
+		},
+		{
+			name:    "when client package should generate getter methods",
+			pkg:     "configclient",
+			schemas: map[string]string{"geo_rule": geo},
+			contains: []string{
+				"func (c *Client) GetGeoRule(ctx context.Context, name string) (GeoRule, error) {",
+			},
+		},
+		{
+			name:    "when schema is not an object schema should fail",
+			pkg:     "geoconfig",
+			schemas: map[string]string{"geo_rule": `{"type":"string"}`},
+			err:     "geo_rule: schema is not an object schema",
+		},
+		{
+			name:    "when two properties generate the same name should fail",
+			pkg:     "geoconfig",
+			schemas: map[string]string{"geo_rule": `{"type":"object","properties":{"zone":{"type":"object","properties":{"a":{"type":"string"}}}}}`, "geo_rule_zone": `{"type":"object"}`},
+			err:     "geo_rule_zone: GeoRuleZone is generated twice",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Generate(Options{Package: tc.pkg, Source: "test schemas"}, tc.schemas)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			for _, want := range tc.contains {
+				assert.Contains(t, string(got), want)
+			}
+		})
+	}
+}
+
+// TestGeneratedUpToDate fails when pkg/configclient drifts from the
+// built-in schemas the validator uses.
+func TestGeneratedUpToDate(t *testing.T) {
+	current, err := os.ReadFile("../../../pkg/configclient/types_gen.go")
+	assert.NoError(t, err)
+
+	want, err := Generate(Options{Package: "configclient", Source: "the built-in schemas"}, builtin.Schemas())
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), string(current), "pkg/configclient/types_gen.go is out of date, run go run ./cmd/typegen")
+}
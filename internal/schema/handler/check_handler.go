@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Check reports what registering the schema would break without storing it.
+func (h *handler) Check(c echo.Context) error {
+	if !httpx.IsJSON(c) {
+		return httpx.WriteError(c, http.StatusUnsupportedMediaType, "content-type must be application/json", nil)
+	}
+
+	schemaType := strings.TrimSpace(c.Param("type"))
+	if schemaType == "" {
+		return httpx.WriteError(c, http.StatusBadRequest, "type is required", nil)
+	}
+
+	var req model.SchemaRegisterRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.WriteError(c, http.StatusBadRequest, "invalid JSON", err.Error())
+	}
+	if len(req.Schema) == 0 {
+		return httpx.WriteError(c, http.StatusBadRequest, "schema is required", nil)
+	}
+
+	res, err := h.srv.Check(c.Request().Context(), schemaType, req.Schema, req.Compatibility)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
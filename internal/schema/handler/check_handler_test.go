@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/service"
+	srvMock "configuration-management-service/internal/schema/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	type input struct {
+		ct   string
+		body string
+	}
+	type expected struct {
+		code int
+		json string
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		in       input
+		ex       expected
+	}{
+		{
+			name:     "when schema missing should status code 400",
+			in:       input{ct: echo.MIMEApplicationJSON, body: `{"compatibility":"full"}`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"schema is required","details":null}}`,
+			},
+		},
+		{
+			name: "when mode invalid should status code 400",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"schema":{},"compatibility":"up"}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Check(gomock.Any(), "geo_rule", json.RawMessage(`{}`), "up").
+					Return(model.CompatibilityReport{}, fmt.Errorf("%w: bad mode", service.ErrInvalidInput))
+			},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid input","details":"invalid input: bad mode"}}`,
+			},
+		},
+		{
+			name: "when incompatible should still status code 200 with report",
+			in:   input{ct: echo.MIMEApplicationJSON, body: `{"schema":{"type":"object"},"compatibility":"forward"}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Check(gomock.Any(), "geo_rule", json.RawMessage(`{"type":"object"}`), "forward").
+					Return(model.CompatibilityReport{
+						Type: "geo_rule", Mode: "forward",
+						SchemaIssues: []model.SchemaIssue{{Path: "/additionalProperties", Message: "additional properties are newly accepted"}},
+					}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"type":"geo_rule","mode":"forward","compatible":false,"checked":0,"schema_issues":[{"path":"/additionalProperties","message":"additional properties are newly accepted"}]}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodPost, "/schemas/_placeholder/compatibility", bytes.NewBufferString(tc.in.body))
+			req.Header.Set(echo.HeaderContentType, tc.in.ct)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("type")
+			c.SetParamValues("geo_rule")
+
+			_ = h.Check(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.ex.json, string(b))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handler) Get(c echo.Context) error {
+	schemaType := strings.TrimSpace(c.Param("type"))
+	if schemaType == "" {
+		return httpx.WriteError(c, http.StatusBadRequest, "type is required", nil)
+	}
+
+	var v *int
+	if q := strings.TrimSpace(c.QueryParam("version")); q != "" {
+		iv, err := strconv.Atoi(q)
+		if err != nil || iv <= 0 {
+			return httpx.WriteError(c, http.StatusBadRequest, "invalid version", "version must be a positive integer")
+		}
+		v = &iv
+	}
+
+	res, err := h.srv.Get(c.Request().Context(), schemaType, v)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
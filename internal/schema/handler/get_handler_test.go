@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/service"
+	srvMock "configuration-management-service/internal/schema/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	cases := []struct {
+		name     string
+		version  string
+		mockFunc func(m *srvMock.MockIService)
+		code     int
+		json     string
+	}{
+		{
+			name:     "when version invalid should status code 400",
+			version:  "0",
+			mockFunc: func(m *srvMock.MockIService) {},
+			code:     http.StatusBadRequest,
+			json:     `{"error":{"code":"Bad Request","message":"invalid version","details":"version must be a positive integer"}}`,
+		},
+		{
+			name: "when not found should status code 404",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "geo_rule", (*int)(nil)).Return(model.Schema{}, service.ErrNotFound)
+			},
+			code: http.StatusNotFound,
+			json: `{"error":{"code":"Not Found","message":"not found","details":null}}`,
+		},
+		{
+			name:    "when by version success",
+			version: "2",
+			mockFunc: func(m *srvMock.MockIService) {
+				v := 2
+				m.EXPECT().Get(gomock.Any(), "geo_rule", &v).
+					Return(model.Schema{Type: "geo_rule", Version: 2, Schema: json.RawMessage(`{}`)}, nil)
+			},
+			code: http.StatusOK,
+			json: `{"type":"geo_rule","version":2,"schema":{},"compatibility":"","created_at":""}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			url := "/schemas/_placeholder"
+			if tc.version != "" {
+				url += "?version=" + tc.version
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, url, nil), rec)
+			c.SetParamNames("type")
+			c.SetParamValues("geo_rule")
+
+			_ = h.Get(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.json, string(b))
+		})
+	}
+}
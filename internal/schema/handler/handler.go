@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"configuration-management-service/internal/schema/service"
+	"configuration-management-service/pkg/httpx"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IHandler interface {
+	Register(c echo.Context) error
+	Get(c echo.Context) error
+	ListVersions(c echo.Context) error
+	List(c echo.Context) error
+	Check(c echo.Context) error
+}
+
+type handler struct {
+	srv service.IService
+}
+
+func NewHandler(srv service.IService) IHandler {
+	return &handler{srv: srv}
+}
+
+func (h *handler) writeServiceError(c echo.Context, err error) error {
+	var incompatible *service.IncompatibleError
+	switch {
+	case errors.As(err, &incompatible):
+		return httpx.WriteError(c, http.StatusConflict, "schema change is incompatible", incompatible.Report)
+	case errors.Is(err, service.ErrNotFound):
+		return httpx.WriteError(c, http.StatusNotFound, err.Error(), nil)
+	case errors.Is(err, service.ErrInvalidInput):
+		return httpx.WriteError(c, http.StatusBadRequest, "invalid input", err.Error())
+	default:
+		return httpx.WriteError(c, http.StatusInternalServerError, "internal error", nil)
+	}
+}
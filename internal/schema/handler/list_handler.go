@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handler) ListVersions(c echo.Context) error {
+	schemaType := strings.TrimSpace(c.Param("type"))
+	if schemaType == "" {
+		return httpx.WriteError(c, http.StatusBadRequest, "type is required", nil)
+	}
+
+	res, err := h.srv.ListVersions(c.Request().Context(), schemaType)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]any{"versions": res})
+}
+
+// List returns the latest schema of every registered type.
+func (h *handler) List(c echo.Context) error {
+	res, err := h.srv.List(c.Request().Context())
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]any{"schemas": res})
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/service"
+	srvMock "configuration-management-service/internal/schema/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListVersions(t *testing.T) {
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		code     int
+		json     string
+	}{
+		{
+			name: "when not found should status code 404",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().ListVersions(gomock.Any(), "geo_rule").Return(nil, service.ErrNotFound)
+			},
+			code: http.StatusNotFound,
+			json: `{"error":{"code":"Not Found","message":"not found","details":null}}`,
+		},
+		{
+			name: "when success",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().ListVersions(gomock.Any(), "geo_rule").Return([]model.Schema{{Type: "geo_rule", Version: 1, Schema: []byte(`{}`)}}, nil)
+			},
+			code: http.StatusOK,
+			json: `{"versions":[{"type":"geo_rule","version":1,"schema":{},"compatibility":"","created_at":""}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/schemas/_placeholder/versions", nil), rec)
+			c.SetParamNames("type")
+			c.SetParamValues("geo_rule")
+
+			_ = h.ListVersions(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.json, string(b))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		code     int
+		json     string
+	}{
+		{
+			name: "when service error should status code 500",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().List(gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			code: http.StatusInternalServerError,
+			json: `{"error":{"code":"Internal Server Error","message":"internal error","details":null}}`,
+		},
+		{
+			name: "when success should return latest schema of every type",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().List(gomock.Any()).Return([]model.Schema{
+					{Type: "feature_toggle", Version: 1, Schema: []byte(`{"type":"object","examples":[{"enabled":true}]}`), Compatibility: "backward"},
+				}, nil)
+			},
+			code: http.StatusOK,
+			json: `{"schemas":[{"type":"feature_toggle","version":1,"schema":{"type":"object","examples":[{"enabled":true}]},"compatibility":"backward","created_at":""}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/schemas", nil), rec)
+
+			_ = h.List(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.json, string(b))
+		})
+	}
+}
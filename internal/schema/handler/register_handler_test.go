@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/service"
+	srvMock "configuration-management-service/internal/schema/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	type input struct {
+		ct   string
+		typ  string
+		body string
+	}
+	type expected struct {
+		code int
+		json string
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		in       input
+		ex       expected
+	}{
+		{
+			name:     "when unsupported media type should status code 415",
+			in:       input{ct: "text/plain", typ: "geo_rule", body: `{}`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusUnsupportedMediaType,
+				json: `{"error":{"code":"Unsupported Media Type","message":"content-type must be application/json","details":null}}`,
+			},
+		},
+		{
+			name:     "when schema missing should status code 400",
+			in:       input{ct: echo.MIMEApplicationJSON, typ: "geo_rule", body: `{}`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"schema is required","details":null}}`,
+			},
+		},
+		{
+			name: "when schema invalid should status code 400",
+			in:   input{ct: echo.MIMEApplicationJSON, typ: "geo_rule", body: `{"schema":{"type":"nope"}}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Register(gomock.Any(), "geo_rule", json.RawMessage(`{"type":"nope"}`), "").
+					Return(model.Schema{}, fmt.Errorf("%w: bad type", service.ErrInvalidInput))
+			},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid input","details":"invalid input: bad type"}}`,
+			},
+		},
+		{
+			name: "when incompatible should status code 409 with report",
+			in:   input{ct: echo.MIMEApplicationJSON, typ: "geo_rule", body: `{"schema":{"type":"object","required":["region"]}}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Register(gomock.Any(), "geo_rule", json.RawMessage(`{"type":"object","required":["region"]}`), "").
+					Return(model.Schema{}, &service.IncompatibleError{Report: model.CompatibilityReport{
+						Type: "geo_rule", Mode: "backward", Checked: 1,
+						Breaking: []model.BreakingConfig{{Name: "legacy", Version: 1, Error: "(root): region is required"}},
+					}})
+			},
+			ex: expected{
+				code: http.StatusConflict,
+				json: `{"error":{"code":"Conflict","message":"schema change is incompatible","details":{"type":"geo_rule","mode":"backward","compatible":false,"checked":1,"breaking":[{"name":"legacy","version":1,"error":"(root): region is required"}]}}}`,
+			},
+		},
+		{
+			name: "when success should status code 201",
+			in:   input{ct: echo.MIMEApplicationJSON, typ: "geo_rule", body: `{"schema":{"type":"object"},"compatibility":"full"}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Register(gomock.Any(), "geo_rule", json.RawMessage(`{"type":"object"}`), "full").
+					Return(model.Schema{Type: "geo_rule", Version: 1, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: "full"}, nil)
+			},
+			ex: expected{
+				code: http.StatusCreated,
+				json: `{"type":"geo_rule","version":1,"schema":{"type":"object"},"compatibility":"full","created_at":""}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodPost, "/schemas/_placeholder", bytes.NewBufferString(tc.in.body))
+			req.Header.Set(echo.HeaderContentType, tc.in.ct)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("type")
+			c.SetParamValues(tc.in.typ)
+
+			_ = h.Register(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.ex.json, string(b))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import "encoding/json"
+
+// Compatibility modes, as in a schema registry.
+const (
+	// CompatBackward: the new schema accepts every stored config of the type.
+	CompatBackward = "backward"
+	// CompatForward: the previous schema accepts everything the new one does.
+	CompatForward = "forward"
+	// CompatFull is backward and forward.
+	CompatFull = "full"
+	// CompatNone skips the check.
+	CompatNone = "none"
+)
+
+// StoredConfig is the effective data of the latest version of one config.
+type StoredConfig struct {
+	Name    string
+	Version int
+	Data    json.RawMessage
+}
+
+// CompatibilityReport lists what a schema change would break.
+type CompatibilityReport struct {
+	Type       string `json:"type"`
+	Mode       string `json:"mode"`
+	Compatible bool   `json:"compatible"`
+	// Checked is the number of stored configs validated against the new schema.
+	Checked      int              `json:"checked"`
+	Breaking     []BreakingConfig `json:"breaking,omitempty"`
+	SchemaIssues []SchemaIssue    `json:"schema_issues,omitempty"`
+}
+
+// BreakingConfig is a stored config the new schema rejects.
+type BreakingConfig struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+	Error   string `json:"error"`
+}
+
+// SchemaIssue is a place where the new schema accepts data the previous one
+// rejects. Path is a JSON pointer into the schema document.
+type SchemaIssue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
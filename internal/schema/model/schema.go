@@ -0,0 +1,20 @@
+package model
+
+import "encoding/json"
+
+// Schema is one version of the JSON Schema that validates a config type.
+type Schema struct {
+	Type    string          `json:"type"`
+	Version int             `json:"version"`
+	Schema  json.RawMessage `json:"schema"`
+	// Compatibility is the mode the next version of the type is checked against.
+	Compatibility string `json:"compatibility"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type SchemaRegisterRequest struct {
+	Schema json.RawMessage `json:"schema"`
+	// Compatibility overrides the mode of the latest version; it is stored
+	// with the new version and applies to the versions after it.
+	Compatibility string `json:"compatibility,omitempty"`
+}
@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"configuration-management-service/internal/schema/builtin"
+	"configuration-management-service/internal/schema/handler"
+	"configuration-management-service/internal/schema/repository"
+	"configuration-management-service/internal/schema/schemadir"
+	"configuration-management-service/internal/schema/service"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IModule interface {
+	RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc)
+	Service() service.IService
+	LoadDir(ctx context.Context, dir string, strict bool) error
+	UpgradeBuiltins(ctx context.Context) error
+}
+
+type module struct {
+	srv  service.IService
+	repo repository.IRepo
+	h    handler.IHandler
+}
+
+func New(repo repository.IRepo, configs service.ConfigSource, changes service.ChangeListener) IModule {
+	srv := service.NewService(repo, configs, changes)
+	return &module{
+		srv:  srv,
+		repo: repo,
+		h:    handler.NewHandler(srv),
+	}
+}
+
+// InitModule wires the registry and seeds the built-in config types that
+// have no version yet. configs supplies the stored configs schema changes
+// are checked against; nil disables that part of the check. changes, when
+// set, is told of every version registered.
+func InitModule(ctx context.Context, db *sql.DB, configs service.ConfigSource, changes service.ChangeListener) (IModule, error) {
+	m := New(repository.NewRepo(db), configs, changes)
+	if err := m.Service().Seed(ctx, builtin.Schemas()); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Service exposes the registry to modules that validate against it.
+func (m *module) Service() service.IService {
+	return m.srv
+}
+
+// UpgradeBuiltins registers the built-in schemas changed since they were
+// seeded. Call it once configs are bound, so the new versions are checked
+// against the stored configs.
+func (m *module) UpgradeBuiltins(ctx context.Context) error {
+	return m.srv.Upgrade(ctx, builtin.Schemas())
+}
+
+// LoadDir registers the custom types of the schema files in dir next to
+// the built-in ones; a changed file becomes the next version of its type.
+// Files named after a built-in type, schemas that do not compile and
+// changes that fail the compatibility check are reported together. When
+// strict, any of them fails the load before a schema is written;
+// otherwise they are logged and skipped.
+func (m *module) LoadDir(ctx context.Context, dir string, strict bool) error {
+	files, problems := schemadir.Read(dir, builtin.Schemas())
+
+	valid := make([]schemadir.File, 0, len(files))
+	for _, f := range files {
+		report, err := m.srv.Check(ctx, f.Type, f.Schema, "")
+		if err == nil && !report.Compatible {
+			err = &service.IncompatibleError{Report: report}
+		}
+		if err != nil {
+			problems = append(problems, schemadir.Problem{Path: f.Path, Err: err})
+			continue
+		}
+		valid = append(valid, f)
+	}
+	if strict && len(problems) > 0 {
+		return loadError(dir, problems)
+	}
+
+	for _, f := range valid {
+		res, changed, err := m.srv.Sync(ctx, f.Type, f.Schema)
+		if err != nil {
+			if strict {
+				return loadError(dir, []schemadir.Problem{{Path: f.Path, Err: err}})
+			}
+			problems = append(problems, schemadir.Problem{Path: f.Path, Err: err})
+			continue
+		}
+		if changed {
+			log.Printf("schema: registered %s version %d from %s", res.Type, res.Version, f.Path)
+		}
+	}
+	for _, p := range problems {
+		log.Printf("schema: skipped %s", p.Error())
+	}
+	return nil
+}
+
+func loadError(dir string, problems []schemadir.Problem) error {
+	msgs := make([]string, 0, len(problems))
+	for _, p := range problems {
+		msgs = append(msgs, p.Error())
+	}
+	return fmt.Errorf("schema dir %s: bad schemas:\n  %s", dir, strings.Join(msgs, "\n  "))
+}
+
+func (m *module) RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc) {
+	if g == nil {
+		return
+	}
+
+	schemas := g.Group("/schemas")
+	schemas.GET("", m.h.List)
+	schemas.POST("/:type", m.h.Register, writeLimit)
+	schemas.GET("/:type", m.h.Get)
+	schemas.GET("/:type/versions", m.h.ListVersions)
+	schemas.POST("/:type/compatibility", m.h.Check)
+}
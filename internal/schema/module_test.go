@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/service"
+	srvMock "configuration-management-service/internal/schema/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_module_LoadDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "circuit_breaker.json"), []byte(`{"type":"object"}`), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ip_allowlist.json"), []byte(`{"type":"nope"}`), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "rate_card.json"), []byte(`{"required":["tier"]}`), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "feature_toggle.json"), []byte(`{"type":"object"}`), 0o644))
+
+	cases := []struct {
+		name   string
+		strict bool
+		err    string
+	}{
+		{
+			name:   "when strict should report every bad schema in one error and write nothing",
+			strict: true,
+			err: fmt.Sprintf("schema dir %s: bad schemas:\n  %s: type feature_toggle collides with the built-in type\n  %s: invalid input: bad schema\n  %s: incompatible schema: 1 breaking configs, 0 schema issues",
+				dir, filepath.Join(dir, "feature_toggle.json"), filepath.Join(dir, "ip_allowlist.json"), filepath.Join(dir, "rate_card.json")),
+		},
+		{
+			name: "when not strict should register the good schemas and skip the bad ones",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			srv.EXPECT().Check(gomock.Any(), "circuit_breaker", json.RawMessage(`{"type":"object"}`), "").
+				Return(model.CompatibilityReport{Compatible: true}, nil)
+			srv.EXPECT().Check(gomock.Any(), "ip_allowlist", json.RawMessage(`{"type":"nope"}`), "").
+				Return(model.CompatibilityReport{}, fmt.Errorf("%w: bad schema", service.ErrInvalidInput))
+			srv.EXPECT().Check(gomock.Any(), "rate_card", json.RawMessage(`{"required":["tier"]}`), "").
+				Return(model.CompatibilityReport{Breaking: []model.BreakingConfig{{Name: "gold"}}}, nil)
+			if !tc.strict {
+				srv.EXPECT().Sync(gomock.Any(), "circuit_breaker", json.RawMessage(`{"type":"object"}`)).
+					Return(model.Schema{Type: "circuit_breaker", Version: 1}, true, nil)
+			}
+			m := &module{srv: srv}
+
+			err := m.LoadDir(context.Background(), dir, tc.strict)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
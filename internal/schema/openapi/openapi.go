@@ -0,0 +1,318 @@
+// Package openapi renders config type schemas as OpenAPI 3.0 components.
+//
+// api/openapi.yml holds a generated block with a RemoteConfigData oneOf over
+// every built-in type and one <Type>Data component per type, rendered from
+// the same schemas the validator seeds into the registry. Regenerate it with
+// `go run ./cmd/openapigen` after changing a built-in schema; a test fails
+// while the file is out of date.
+//
+// JSON Schema and OpenAPI 3.0 schemas mostly overlap. References are
+// inlined first, so the shared definitions appear in every component that
+// uses them. The differences the conversion handles: type lists with
+// "null" become nullable, const becomes a one-value enum, numeric exclusive
+// bounds become boolean ones, examples becomes example, and $schema and the
+// 2019-09/2020-12 keywords OpenAPI 3.0 lacks (unevaluatedProperties,
+// dependentRequired, if/then/else, ...) are dropped; the service still
+// enforces them. x-* keywords are kept as specification extensions.
+package openapi
+
+import (
+	"bytes"
+	"configuration-management-service/internal/schema/compiler"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// BeginMarker and EndMarker delimit the generated block in the document.
+	BeginMarker = "    # BEGIN GENERATED config type components (go run ./cmd/openapigen); DO NOT EDIT"
+	EndMarker   = "    # END GENERATED config type components"
+
+	// indent is the indentation of components under components.schemas.
+	indent = "    "
+
+	dataDescription = "Validated against the latest schema of the config type; the built-in types are listed here."
+	secretNote      = "Secret. Encrypted at rest and returned as `[REDACTED]` unless revealed."
+)
+
+// ComponentName names the component of a config type: feature_toggle
+// becomes FeatureToggleData.
+func ComponentName(schemaType string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(schemaType, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	b.WriteString("Data")
+	return b.String()
+}
+
+// Components renders RemoteConfigData and the component of every type in
+// schemas, keyed by config type, as YAML indented for components.schemas.
+func Components(schemas map[string]string) ([]byte, error) {
+	types := make([]string, 0, len(schemas))
+	for t := range schemas {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	refs := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, t := range types {
+		refs.Content = append(refs.Content, mapping(true, "$ref", str("#/components/schemas/"+ComponentName(t))))
+	}
+	root.Content = append(root.Content, str("RemoteConfigData"), mapping(false, "description", str(dataDescription), "oneOf", refs))
+
+	for _, t := range types {
+		obj, err := compiler.Inline([]byte(schemas[t]))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+		root.Content = append(root.Content, str(ComponentName(t)), node(convert(obj), true))
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	for i, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		if i > 0 && !strings.HasPrefix(line, " ") && line != "" {
+			out.WriteString("\n")
+		}
+		out.WriteString(indent + line + "\n")
+	}
+	return out.Bytes(), nil
+}
+
+// Splice replaces the lines between the markers of doc with block.
+func Splice(doc, block []byte) ([]byte, error) {
+	s := string(doc)
+	begin := strings.Index(s, BeginMarker+"\n")
+	end := strings.Index(s, EndMarker+"\n")
+	if begin < 0 || end < begin {
+		return nil, errors.New("generated block markers not found")
+	}
+	head := s[:begin+len(BeginMarker)+1]
+	return []byte(head + string(block) + s[end:]), nil
+}
+
+// schemaNode is a converted schema object; its keywords are ordered by
+// keyOrder when rendered, other objects by name.
+type schemaNode map[string]any
+
+// convert rewrites a JSON Schema node into an OpenAPI 3.0 schema.
+func convert(schema map[string]any) schemaNode {
+	out := make(schemaNode, len(schema))
+	for k, v := range schema {
+		switch k {
+		case "$schema", "$id", "$comment", "$anchor", "$ref", "$defs", "definitions",
+			"unevaluatedProperties", "unevaluatedItems", "dependentRequired", "dependentSchemas",
+			"dependencies", "if", "then", "else", "prefixItems", "contains", "propertyNames":
+		case "type":
+			types, ok := v.([]any)
+			if !ok {
+				out[k] = v
+				continue
+			}
+			var kept []any
+			for _, t := range types {
+				if t == "null" {
+					out["nullable"] = true
+					continue
+				}
+				kept = append(kept, t)
+			}
+			if len(kept) == 1 {
+				out[k] = kept[0]
+			} else {
+				alts := make([]any, 0, len(kept))
+				for _, t := range kept {
+					alts = append(alts, schemaNode{"type": t})
+				}
+				out["oneOf"] = alts
+			}
+		case "const":
+			out["enum"] = []any{v}
+		case "exclusiveMinimum", "exclusiveMaximum":
+			if _, ok := v.(json.Number); ok {
+				out[inclusiveBound[k]] = v
+				out[k] = true
+				continue
+			}
+			out[k] = v
+		case "examples":
+			if list, ok := v.([]any); ok && len(list) > 0 {
+				out["example"] = list[0]
+			}
+		case "properties":
+			props := map[string]any{}
+			for name, p := range v.(map[string]any) {
+				props[name] = convertAny(p)
+			}
+			out[k] = props
+		case "items", "additionalProperties", "not":
+			out[k] = convertAny(v)
+		case "allOf", "anyOf", "oneOf":
+			list := v.([]any)
+			alts := make([]any, 0, len(list))
+			for _, s := range list {
+				alts = append(alts, convertAny(s))
+			}
+			out[k] = alts
+		default:
+			out[k] = v
+		}
+	}
+	if secret, _ := out["x-secret"].(bool); secret {
+		if _, ok := out["description"]; !ok {
+			out["description"] = secretNote
+		}
+	}
+	return out
+}
+
+func convertAny(v any) any {
+	if m, ok := v.(map[string]any); ok {
+		return convert(m)
+	}
+	return v
+}
+
+// inclusiveBound maps a draft-07 exclusive bound to the OpenAPI 3.0 bound
+// it becomes exclusive with.
+var inclusiveBound = map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"}
+
+// keyOrder puts the keywords people look for first; the rest follow by name.
+var keyOrder = map[string]int{
+	"title": 1, "description": 2, "type": 3, "nullable": 4, "format": 5, "enum": 6,
+	"required": 7, "properties": 8, "items": 9, "additionalProperties": 10,
+}
+
+func sortedKeys(m map[string]any, schema bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if !schema {
+		sort.Strings(keys)
+		return keys
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keyOrder[keys[i]], keyOrder[keys[j]]
+		if a == 0 {
+			a = len(keyOrder) + 1
+		}
+		if b == 0 {
+			b = len(keyOrder) + 1
+		}
+		if a != b {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// node renders v as YAML. Objects and arrays holding only scalars are
+// written in flow style, like the hand-written parts of the document;
+// properties and example values below top keep block style.
+func node(v any, top bool) *yaml.Node {
+	switch x := v.(type) {
+	case schemaNode:
+		return object(x, true, top)
+	case map[string]any:
+		return object(x, false, top)
+	case []any:
+		n := &yaml.Node{Kind: yaml.SequenceNode}
+		flow := true
+		for _, e := range x {
+			if _, ok := e.([]any); ok || nested(e) {
+				flow = false
+			}
+			n.Content = append(n.Content, node(e, false))
+		}
+		if flow {
+			n.Style = yaml.FlowStyle
+		}
+		return n
+	case string:
+		return str(x)
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(string(x), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: string(x)}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(x)}
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	default:
+		return str(fmt.Sprint(x))
+	}
+}
+
+func object(m map[string]any, schema, top bool) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	if !top && flat(m) {
+		n.Style = yaml.FlowStyle
+	}
+	for _, k := range sortedKeys(m, schema) {
+		n.Content = append(n.Content, str(k), node(m[k], false))
+	}
+	return n
+}
+
+// flat reports whether m holds no objects, so it fits on one line.
+func flat(m map[string]any) bool {
+	for _, v := range m {
+		if nested(v) {
+			return false
+		}
+		if list, ok := v.([]any); ok {
+			for _, e := range list {
+				if nested(e) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func nested(v any) bool {
+	switch v.(type) {
+	case schemaNode, map[string]any:
+		return true
+	}
+	return false
+}
+
+func str(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+func mapping(flow bool, kv ...any) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	if flow {
+		n.Style = yaml.FlowStyle
+	}
+	for i := 0; i < len(kv); i += 2 {
+		n.Content = append(n.Content, str(kv[i].(string)), kv[i+1].(*yaml.Node))
+	}
+	return n
+}
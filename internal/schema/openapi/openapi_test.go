@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"os"
+	"testing"
+
+	"configuration-management-service/internal/schema/builtin"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestComponentName(t *testing.T) {
+	cases := map[string]string{
+		"feature_toggle":    "FeatureToggleData",
+		"experiment_config": "ExperimentConfigData",
+		"geo":               "GeoData",
+		"geo__rule_":        "GeoRuleData",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, ComponentName(in), in)
+	}
+}
+
+func TestComponents(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{
+			name:   "when type list has null should become nullable",
+			schema: `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object","properties":{"max":{"type":["number","null"]}}}`,
+			want: `
+    RemoteConfigData:
+      description: Validated against the latest schema of the config type; the built-in types are listed here.
+      oneOf:
+        - {$ref: '#/components/schemas/GeoRuleData'}
+
+    GeoRuleData:
+      type: object
+      properties:
+        max: {type: number, nullable: true}
+`,
+		},
+		{
+			name:   "when const and exclusive bounds should use their OpenAPI 3.0 forms",
+			schema: `{"type":"object","properties":{"kind":{"const":"geo"},"pct":{"type":"number","exclusiveMinimum":0,"exclusiveMaximum":1}}}`,
+			want: `
+    RemoteConfigData:
+      description: Validated against the latest schema of the config type; the built-in types are listed here.
+      oneOf:
+        - {$ref: '#/components/schemas/GeoRuleData'}
+
+    GeoRuleData:
+      type: object
+      properties:
+        kind: {enum: [geo]}
+        pct: {type: number, exclusiveMaximum: true, exclusiveMinimum: true, maximum: 1, minimum: 0}
+`,
+		},
+		{
+			name:   "when examples and secrets should keep first example and describe the secret",
+			schema: `{"type":"object","x-defaults":"read","properties":{"token":{"type":"string","x-secret":true}},"examples":[{"token":"t1"},{"token":"t2"}]}`,
+			want: `
+    RemoteConfigData:
+      description: Validated against the latest schema of the config type; the built-in types are listed here.
+      oneOf:
+        - {$ref: '#/components/schemas/GeoRuleData'}
+
+    GeoRuleData:
+      type: object
+      properties:
+        token: {description: 'Secret. Encrypted at rest and returned as ` + "`[REDACTED]`" + ` unless revealed.', type: string, x-secret: true}
+      example: {token: t1}
+      x-defaults: read
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Components(map[string]string{"geo_rule": tc.schema})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want[1:], string(got))
+		})
+	}
+
+	_, err := Components(map[string]string{"geo_rule": `[]`})
+	assert.EqualError(t, err, "geo_rule: schema is not an object")
+}
+
+func TestSplice(t *testing.T) {
+	doc := "a:\n" + BeginMarker + "\n    old: 1\n" + EndMarker + "\nb: 2\n"
+	got, err := Splice([]byte(doc), []byte("    new: 1\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a:\n"+BeginMarker+"\n    new: 1\n"+EndMarker+"\nb: 2\n", string(got))
+
+	_, err = Splice([]byte("a: 1\n"), nil)
+	assert.Error(t, err)
+}
+
+// TestDocumentUpToDate fails when api/openapi.yml drifts from the built-in
+// schemas the validator uses.
+func TestDocumentUpToDate(t *testing.T) {
+	doc, err := os.ReadFile("../../../api/openapi.yml")
+	assert.NoError(t, err)
+
+	block, err := Components(builtin.Schemas())
+	assert.NoError(t, err)
+	want, err := Splice(doc, block)
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), string(doc), "api/openapi.yml is out of date, run go run ./cmd/openapigen")
+
+	var parsed map[string]any
+	assert.NoError(t, yaml.Unmarshal(doc, &parsed))
+}
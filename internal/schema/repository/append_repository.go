@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"configuration-management-service/internal/schema/model"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// Append stores schema as the next version of schemaType (version 1 when new).
+func (r *repo) Append(ctx context.Context, schemaType string, schema json.RawMessage, compatibility string) (model.Schema, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return model.Schema{}, fmt.Errorf("append.begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const qIns = `
+		INSERT INTO schemas(type, version, schema, compatibility)
+		SELECT ?, COALESCE(MAX(version), 0) + 1, ?, ?
+		FROM schemas
+		WHERE type = ?
+	`
+	if _, err := tx.ExecContext(ctx, qIns, schemaType, string(schema), compatibility, schemaType); err != nil {
+		return model.Schema{}, fmt.Errorf("append.insert: %w", err)
+	}
+
+	s, err := scanSchema(tx.QueryRowContext(ctx, qLatest, schemaType))
+	if err != nil {
+		return model.Schema{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.Schema{}, fmt.Errorf("append.commit: %w", err)
+	}
+	return s, nil
+}
+
+// Seed stores schema as version 1 of schemaType unless the type already has
+// a version, and records it as the seeded version of the type. It reports
+// whether a row was written.
+func (r *repo) Seed(ctx context.Context, schemaType string, schema json.RawMessage) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("seed.begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const q = `INSERT OR IGNORE INTO schemas(type, version, schema) VALUES (?, 1, ?)`
+	res, err := tx.ExecContext(ctx, q, schemaType, string(schema))
+	if err != nil {
+		return false, fmt.Errorf("seed: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		return false, nil
+	}
+	if err := markSeeded(ctx, tx, schemaType, 1); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("seed.commit: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Append(t *testing.T) {
+	const qIns = `INSERT INTO schemas(type, version, schema, compatibility) SELECT ?, COALESCE(MAX(version), 0) + 1, ?, ? FROM schemas WHERE type = ?`
+	const qSel = `SELECT type, version, schema, compatibility, created_at FROM schemas WHERE type = ? ORDER BY version DESC LIMIT 1`
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		res      model.Schema
+		err      bool
+	}{
+		{
+			name: "when insert error should rollback and return error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(qIns).WithArgs("geo_rule", `{"type":"object"}`, "backward", "geo_rule").WillReturnError(errors.New("insert err"))
+				m.ExpectRollback()
+			},
+			err: true,
+		},
+		{
+			name: "when success should return stored version",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(qIns).WithArgs("geo_rule", `{"type":"object"}`, "backward", "geo_rule").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery(qSel).WithArgs("geo_rule").
+					WillReturnRows(sqlmock.NewRows([]string{"type", "version", "schema", "compatibility", "created_at"}).
+						AddRow("geo_rule", 2, `{"type":"object"}`, "backward", "2025-10-01T00:00:00Z"))
+				m.ExpectCommit()
+			},
+			res: model.Schema{Type: "geo_rule", Version: 2, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: "backward", CreatedAt: "2025-10-01T00:00:00Z"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.Append(context.Background(), "geo_rule", json.RawMessage(`{"type":"object"}`), "backward")
+
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_Seed(t *testing.T) {
+	const qIns = `INSERT OR IGNORE INTO schemas(type, version, schema) VALUES (?, 1, ?)`
+	const qMark = `INSERT INTO seeded_schemas(type, version) VALUES (?, ?) ON CONFLICT(type) DO UPDATE SET version = excluded.version`
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		want     bool
+		err      bool
+	}{
+		{
+			name: "when type has no version should seed it and record it as seeded",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(qIns).WithArgs("feature_toggle", `{}`).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(qMark).WithArgs("feature_toggle", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+			want: true,
+		},
+		{
+			name: "when type already registered should skip it",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(qIns).WithArgs("feature_toggle", `{}`).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectRollback()
+			},
+		},
+		{
+			name: "when recording fails should roll back the seed",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(qIns).WithArgs("feature_toggle", `{}`).WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(qMark).WithArgs("feature_toggle", 1).WillReturnError(errors.New("disk full"))
+				m.ExpectRollback()
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.Seed(context.Background(), "feature_toggle", json.RawMessage(`{}`))
+
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"configuration-management-service/internal/schema/model"
+	"context"
+)
+
+func (r *repo) ByVersion(ctx context.Context, schemaType string, version int) (model.Schema, error) {
+	const q = `
+		SELECT ` + schemaColumns + `
+		FROM schemas
+		WHERE type = ? AND version = ?
+		LIMIT 1
+	`
+	return scanSchema(r.db.QueryRowContext(ctx, q, schemaType, version))
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ByVersion(t *testing.T) {
+	const q = `SELECT type, version, schema, compatibility, created_at FROM schemas WHERE type = ? AND version = ? LIMIT 1`
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		exErr    error
+	}{
+		{
+			name: "when not found should return ErrNotFound",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("geo_rule", 2).WillReturnError(sql.ErrNoRows)
+			},
+			exErr: ErrNotFound,
+		},
+		{
+			name: "when success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("geo_rule", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"type", "version", "schema", "compatibility", "created_at"}).
+						AddRow("geo_rule", 2, `{}`, "backward", "2025-10-01T00:00:00Z"))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			_, err := r.ByVersion(context.Background(), "geo_rule", 2)
+
+			assert.Equal(t, tc.exErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
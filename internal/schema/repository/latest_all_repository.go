@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"configuration-management-service/internal/schema/model"
+	"context"
+)
+
+// LatestAll returns the latest version of every registered type, by type.
+func (r *repo) LatestAll(ctx context.Context) ([]model.Schema, error) {
+	const q = `
+		SELECT ` + schemaColumns + `
+		FROM schemas s
+		WHERE version = (SELECT MAX(version) FROM schemas WHERE type = s.type)
+		ORDER BY type ASC
+	`
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.Schema{}
+	for rows.Next() {
+		s, err := scanSchema(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
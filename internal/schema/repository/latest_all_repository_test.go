@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LatestAll(t *testing.T) {
+	const q = `SELECT type, version, schema, compatibility, created_at FROM schemas s WHERE version = (SELECT MAX(version) FROM schemas WHERE type = s.type) ORDER BY type ASC`
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		res      []model.Schema
+		err      bool
+	}{
+		{
+			name: "when query error should return error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WillReturnError(errors.New("query err"))
+			},
+			err: true,
+		},
+		{
+			name: "when empty should return empty list",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WillReturnRows(sqlmock.NewRows([]string{"type", "version", "schema", "compatibility", "created_at"}))
+			},
+			res: []model.Schema{},
+		},
+		{
+			name: "when success should return latest of every type",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).
+					WillReturnRows(sqlmock.NewRows([]string{"type", "version", "schema", "compatibility", "created_at"}).
+						AddRow("feature_toggle", 1, `{}`, "backward", "2025-10-01T00:00:00Z").
+						AddRow("geo_rule", 3, `{"type":"object"}`, "full", "2025-10-01T00:01:00Z"))
+			},
+			res: []model.Schema{
+				{Type: "feature_toggle", Version: 1, Schema: json.RawMessage(`{}`), Compatibility: "backward", CreatedAt: "2025-10-01T00:00:00Z"},
+				{Type: "geo_rule", Version: 3, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: "full", CreatedAt: "2025-10-01T00:01:00Z"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.LatestAll(context.Background())
+
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.res, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"configuration-management-service/internal/schema/model"
+	"context"
+)
+
+const qLatest = `
+	SELECT ` + schemaColumns + `
+	FROM schemas
+	WHERE type = ?
+	ORDER BY version DESC
+	LIMIT 1
+`
+
+func (r *repo) Latest(ctx context.Context, schemaType string) (model.Schema, error) {
+	return scanSchema(r.db.QueryRowContext(ctx, qLatest, schemaType))
+}
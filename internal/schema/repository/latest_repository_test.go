@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Latest(t *testing.T) {
+	const q = `SELECT type, version, schema, compatibility, created_at FROM schemas WHERE type = ? ORDER BY version DESC LIMIT 1`
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		exErr    error
+	}{
+		{
+			name: "when no rows should return ErrNotFound",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("geo_rule").WillReturnError(sql.ErrNoRows)
+			},
+			exErr: ErrNotFound,
+		},
+		{
+			name: "when success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("geo_rule").
+					WillReturnRows(sqlmock.NewRows([]string{"type", "version", "schema", "compatibility", "created_at"}).
+						AddRow("geo_rule", 3, `{}`, "backward", "2025-10-01T00:00:00Z"))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.Latest(context.Background(), "geo_rule")
+
+			assert.Equal(t, tc.exErr, err)
+			if tc.exErr == nil {
+				assert.Equal(t, 3, got.Version)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
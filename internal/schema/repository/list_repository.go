@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"configuration-management-service/internal/schema/model"
+	"context"
+)
+
+func (r *repo) List(ctx context.Context, schemaType string) ([]model.Schema, error) {
+	const q = `
+		SELECT ` + schemaColumns + `
+		FROM schemas
+		WHERE type = ?
+		ORDER BY version ASC
+	`
+	rows, err := r.db.QueryContext(ctx, q, schemaType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []model.Schema
+	for rows.Next() {
+		s, err := scanSchema(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_List(t *testing.T) {
+	const q = `SELECT type, version, schema, compatibility, created_at FROM schemas WHERE type = ? ORDER BY version ASC`
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		count    int
+		err      bool
+	}{
+		{
+			name: "when query error should return error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("geo_rule").WillReturnError(errors.New("query err"))
+			},
+			err: true,
+		},
+		{
+			name: "when success with rows should return rows",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("geo_rule").
+					WillReturnRows(sqlmock.NewRows([]string{"type", "version", "schema", "compatibility", "created_at"}).
+						AddRow("geo_rule", 1, `{}`, "backward", "2025-10-01T00:00:00Z").
+						AddRow("geo_rule", 2, `{}`, "backward", "2025-10-01T00:01:00Z"))
+			},
+			count: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.List(context.Background(), "geo_rule")
+
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, got, tc.count)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
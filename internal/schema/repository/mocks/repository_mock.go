@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/schema/repository/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "configuration-management-service/internal/schema/model"
+	context "context"
+	json "encoding/json"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRepo is a mock of IRepo interface.
+type MockIRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRepoMockRecorder
+}
+
+// MockIRepoMockRecorder is the mock recorder for MockIRepo.
+type MockIRepoMockRecorder struct {
+	mock *MockIRepo
+}
+
+// NewMockIRepo creates a new mock instance.
+func NewMockIRepo(ctrl *gomock.Controller) *MockIRepo {
+	mock := &MockIRepo{ctrl: ctrl}
+	mock.recorder = &MockIRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRepo) EXPECT() *MockIRepoMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockIRepo) Append(ctx context.Context, schemaType string, schema json.RawMessage, compatibility string) (model.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, schemaType, schema, compatibility)
+	ret0, _ := ret[0].(model.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockIRepoMockRecorder) Append(ctx, schemaType, schema, compatibility interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockIRepo)(nil).Append), ctx, schemaType, schema, compatibility)
+}
+
+// ByVersion mocks base method.
+func (m *MockIRepo) ByVersion(ctx context.Context, schemaType string, version int) (model.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ByVersion", ctx, schemaType, version)
+	ret0, _ := ret[0].(model.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ByVersion indicates an expected call of ByVersion.
+func (mr *MockIRepoMockRecorder) ByVersion(ctx, schemaType, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByVersion", reflect.TypeOf((*MockIRepo)(nil).ByVersion), ctx, schemaType, version)
+}
+
+// Latest mocks base method.
+func (m *MockIRepo) Latest(ctx context.Context, schemaType string) (model.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Latest", ctx, schemaType)
+	ret0, _ := ret[0].(model.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Latest indicates an expected call of Latest.
+func (mr *MockIRepoMockRecorder) Latest(ctx, schemaType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockIRepo)(nil).Latest), ctx, schemaType)
+}
+
+// LatestAll mocks base method.
+func (m *MockIRepo) LatestAll(ctx context.Context) ([]model.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestAll", ctx)
+	ret0, _ := ret[0].([]model.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestAll indicates an expected call of LatestAll.
+func (mr *MockIRepoMockRecorder) LatestAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestAll", reflect.TypeOf((*MockIRepo)(nil).LatestAll), ctx)
+}
+
+// List mocks base method.
+func (m *MockIRepo) List(ctx context.Context, schemaType string) ([]model.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, schemaType)
+	ret0, _ := ret[0].([]model.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIRepoMockRecorder) List(ctx, schemaType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIRepo)(nil).List), ctx, schemaType)
+}
+
+// MarkSeeded mocks base method.
+func (m *MockIRepo) MarkSeeded(ctx context.Context, schemaType string, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSeeded", ctx, schemaType, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSeeded indicates an expected call of MarkSeeded.
+func (mr *MockIRepoMockRecorder) MarkSeeded(ctx, schemaType, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSeeded", reflect.TypeOf((*MockIRepo)(nil).MarkSeeded), ctx, schemaType, version)
+}
+
+// Seed mocks base method.
+func (m *MockIRepo) Seed(ctx context.Context, schemaType string, schema json.RawMessage) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seed", ctx, schemaType, schema)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seed indicates an expected call of Seed.
+func (mr *MockIRepoMockRecorder) Seed(ctx, schemaType, schema interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seed", reflect.TypeOf((*MockIRepo)(nil).Seed), ctx, schemaType, schema)
+}
+
+// SeededVersion mocks base method.
+func (m *MockIRepo) SeededVersion(ctx context.Context, schemaType string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeededVersion", ctx, schemaType)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeededVersion indicates an expected call of SeededVersion.
+func (mr *MockIRepoMockRecorder) SeededVersion(ctx, schemaType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeededVersion", reflect.TypeOf((*MockIRepo)(nil).SeededVersion), ctx, schemaType)
+}
+
+// MockrowScanner is a mock of rowScanner interface.
+type MockrowScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockrowScannerMockRecorder
+}
+
+// MockrowScannerMockRecorder is the mock recorder for MockrowScanner.
+type MockrowScannerMockRecorder struct {
+	mock *MockrowScanner
+}
+
+// NewMockrowScanner creates a new mock instance.
+func NewMockrowScanner(ctrl *gomock.Controller) *MockrowScanner {
+	mock := &MockrowScanner{ctrl: ctrl}
+	mock.recorder = &MockrowScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrowScanner) EXPECT() *MockrowScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockrowScanner) Scan(dest ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockrowScannerMockRecorder) Scan(dest ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockrowScanner)(nil).Scan), dest...)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"configuration-management-service/internal/schema/model"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type IRepo interface {
+	Append(ctx context.Context, schemaType string, schema json.RawMessage, compatibility string) (model.Schema, error)
+	Seed(ctx context.Context, schemaType string, schema json.RawMessage) (bool, error)
+	SeededVersion(ctx context.Context, schemaType string) (int, error)
+	MarkSeeded(ctx context.Context, schemaType string, version int) error
+	Latest(ctx context.Context, schemaType string) (model.Schema, error)
+	ByVersion(ctx context.Context, schemaType string, version int) (model.Schema, error)
+	List(ctx context.Context, schemaType string) ([]model.Schema, error)
+	LatestAll(ctx context.Context) ([]model.Schema, error)
+}
+
+type repo struct {
+	db *sql.DB
+}
+
+func NewRepo(db *sql.DB) IRepo {
+	return &repo{db: db}
+}
+
+// schemaColumns is the column list every scanSchema query selects.
+const schemaColumns = `type, version, schema, compatibility, created_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSchema(row rowScanner) (model.Schema, error) {
+	var s model.Schema
+	var body string
+	if err := row.Scan(&s.Type, &s.Version, &body, &s.Compatibility, &s.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Schema{}, ErrNotFound
+		}
+		return model.Schema{}, err
+	}
+	s.Schema = json.RawMessage(body)
+	return s, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockRepoEq(t *testing.T) (*repo, sqlmock.Sqlmock, *sql.DB) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	return &repo{db: db}, mock, db
+}
+
+func Test_NewRepo(t *testing.T) {
+	assert.NotPanics(t, func() { NewRepo(nil) })
+}
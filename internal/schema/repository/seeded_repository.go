@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SeededVersion returns the version of schemaType last written from the
+// built-in schema, or 0 when the type was never seeded.
+func (r *repo) SeededVersion(ctx context.Context, schemaType string) (int, error) {
+	const q = `SELECT version FROM seeded_schemas WHERE type = ?`
+	var version int
+	if err := r.db.QueryRowContext(ctx, q, schemaType).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("seeded: %w", err)
+	}
+	return version, nil
+}
+
+// MarkSeeded records version as the version of schemaType written from the
+// built-in schema.
+func (r *repo) MarkSeeded(ctx context.Context, schemaType string, version int) error {
+	return markSeeded(ctx, r.db, schemaType, version)
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func markSeeded(ctx context.Context, db execer, schemaType string, version int) error {
+	const q = `
+		INSERT INTO seeded_schemas(type, version) VALUES (?, ?)
+		ON CONFLICT(type) DO UPDATE SET version = excluded.version
+	`
+	if _, err := db.ExecContext(ctx, q, schemaType, version); err != nil {
+		return fmt.Errorf("seeded.mark: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SeededVersion(t *testing.T) {
+	const q = `SELECT version FROM seeded_schemas WHERE type = ?`
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		want     int
+		err      bool
+	}{
+		{
+			name: "when type was seeded should return its version",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("feature_toggle").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+			},
+			want: 3,
+		},
+		{
+			name: "when type was never seeded should return 0",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("feature_toggle").WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "when query fails should return error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("feature_toggle").WillReturnError(errors.New("db down"))
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.SeededVersion(context.Background(), "feature_toggle")
+
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
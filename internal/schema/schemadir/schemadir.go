@@ -0,0 +1,63 @@
+// Package schemadir reads the schemas of custom config types from a
+// directory. Every <type>.json file holds the JSON Schema of one type,
+// e.g. circuit_breaker.json; other files are ignored.
+package schemadir
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// File is a schema file of the directory.
+type File struct {
+	Type   string
+	Path   string
+	Schema json.RawMessage
+}
+
+// Problem is a file that cannot be registered.
+type Problem struct {
+	Path string
+	Err  error
+}
+
+func (p Problem) Error() string {
+	return fmt.Sprintf("%s: %v", p.Path, p.Err)
+}
+
+// Read returns the schema files of dir sorted by type, and a problem for
+// every file named after a reserved type or that cannot be read. reserved
+// holds the built-in types, which custom schemas must not replace.
+func Read(dir string, reserved map[string]string) ([]File, []Problem) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, []Problem{{Path: dir, Err: err}}
+	}
+
+	var files []File
+	var problems []Problem
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		schemaType := strings.TrimSuffix(e.Name(), ".json")
+		if _, ok := reserved[schemaType]; ok {
+			problems = append(problems, Problem{Path: path, Err: fmt.Errorf("type %s collides with the built-in type", schemaType)})
+			continue
+		}
+		body, err := os.ReadFile(path)
+		if err != nil {
+			problems = append(problems, Problem{Path: path, Err: err})
+			continue
+		}
+		files = append(files, File{Type: schemaType, Path: path, Schema: body})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Type < files[j].Type })
+	return files, problems
+}
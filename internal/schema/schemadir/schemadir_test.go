@@ -0,0 +1,40 @@
+package schemadir
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644))
+	}
+	write("ip_allowlist.json", `{"type":"object"}`)
+	write("circuit_breaker.json", `{"type":"object","required":["threshold"]}`)
+	write("feature_toggle.json", `{"type":"object"}`)
+	write("README.md", `not a schema`)
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "nested.json"), 0o755))
+
+	files, problems := Read(dir, map[string]string{"feature_toggle": "{}"})
+	assert.Equal(t, []File{
+		{Type: "circuit_breaker", Path: filepath.Join(dir, "circuit_breaker.json"), Schema: json.RawMessage(`{"type":"object","required":["threshold"]}`)},
+		{Type: "ip_allowlist", Path: filepath.Join(dir, "ip_allowlist.json"), Schema: json.RawMessage(`{"type":"object"}`)},
+	}, files)
+	assert.Len(t, problems, 1)
+	assert.EqualError(t, problems[0], filepath.Join(dir, "feature_toggle.json")+": type feature_toggle collides with the built-in type")
+}
+
+func TestRead_MissingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+
+	files, problems := Read(dir, nil)
+	assert.Empty(t, files)
+	assert.Len(t, problems, 1)
+	assert.Equal(t, dir, problems[0].Path)
+	assert.True(t, os.IsNotExist(problems[0].Err))
+}
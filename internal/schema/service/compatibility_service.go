@@ -0,0 +1,103 @@
+package service
+
+import (
+	"configuration-management-service/internal/schema/compat"
+	"configuration-management-service/internal/schema/compiler"
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var modes = map[string]bool{
+	model.CompatBackward: true,
+	model.CompatForward:  true,
+	model.CompatFull:     true,
+	model.CompatNone:     true,
+}
+
+// Check reports what registering schema as the next version of schemaType
+// would break, without storing it. An empty compatibility uses the mode of
+// the latest version.
+func (s service) Check(ctx context.Context, schemaType string, schema json.RawMessage, compatibility string) (model.CompatibilityReport, error) {
+	schemaType, body, err := prepare(schemaType, schema, compatibility)
+	if err != nil {
+		return model.CompatibilityReport{}, err
+	}
+
+	prev, err := s.repo.Latest(ctx, schemaType)
+	if errors.Is(err, repository.ErrNotFound) {
+		// A new type has nothing stored to break.
+		if compatibility == "" {
+			compatibility = model.CompatBackward
+		}
+		return model.CompatibilityReport{Type: schemaType, Mode: compatibility, Compatible: true}, nil
+	}
+	if err != nil {
+		return model.CompatibilityReport{}, err
+	}
+
+	if compatibility == "" {
+		compatibility = prev.Compatibility
+	}
+	return s.check(ctx, prev, body, compatibility)
+}
+
+// check compares body against prev. Backward validates every stored latest
+// config of the type against body; forward compares the two schemas
+// structurally, since the data a future writer produces is not known yet.
+func (s service) check(ctx context.Context, prev model.Schema, body json.RawMessage, mode string) (model.CompatibilityReport, error) {
+	report := model.CompatibilityReport{Type: prev.Type, Mode: mode}
+
+	if mode == model.CompatBackward || mode == model.CompatFull {
+		breaking, checked, err := s.breaking(ctx, prev.Type, body)
+		if err != nil {
+			return model.CompatibilityReport{}, err
+		}
+		report.Breaking, report.Checked = breaking, checked
+	}
+	if mode == model.CompatForward || mode == model.CompatFull {
+		issues, err := compat.Widened(prev.Schema, body)
+		if err != nil {
+			return model.CompatibilityReport{}, err
+		}
+		report.SchemaIssues = issues
+	}
+
+	report.Compatible = len(report.Breaking) == 0 && len(report.SchemaIssues) == 0
+	return report, nil
+}
+
+func (s service) breaking(ctx context.Context, schemaType string, body json.RawMessage) ([]model.BreakingConfig, int, error) {
+	if s.configs == nil {
+		return nil, 0, nil
+	}
+	configs, err := s.configs.LatestOfType(ctx, schemaType)
+	if err != nil {
+		return nil, 0, err
+	}
+	compiled, err := compiler.Compile(schemaType, body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var out []model.BreakingConfig
+	for _, cfg := range configs {
+		failures, err := compiler.Validate(compiled, cfg.Data)
+		if err != nil {
+			out = append(out, model.BreakingConfig{Name: cfg.Name, Version: cfg.Version, Error: err.Error()})
+			continue
+		}
+		if len(failures) == 0 {
+			continue
+		}
+		msgs := make([]string, 0, len(failures))
+		for _, f := range failures {
+			msgs = append(msgs, f.Message)
+		}
+		out = append(out, model.BreakingConfig{Name: cfg.Name, Version: cfg.Version, Error: strings.Join(msgs, "; ")})
+	}
+	return out, len(configs), nil
+}
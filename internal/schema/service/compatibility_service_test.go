@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/repository"
+	repoMock "configuration-management-service/internal/schema/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Check(t *testing.T) {
+	prev := model.Schema{
+		Type:          "geo_rule",
+		Version:       3,
+		Schema:        json.RawMessage(`{"type":"object","properties":{"pct":{"type":"integer","maximum":100}},"additionalProperties":false}`),
+		Compatibility: model.CompatBackward,
+	}
+	configs := []model.StoredConfig{
+		{Name: "eu", Version: 4, Data: json.RawMessage(`{"pct":80}`)},
+		{Name: "us", Version: 1, Data: json.RawMessage(`{"pct":20}`)},
+	}
+
+	cases := []struct {
+		name      string
+		schema    string
+		compat    string
+		mockFunc  func(m *repoMock.MockIRepo)
+		sourceErr error
+		res       model.CompatibilityReport
+		err       error
+	}{
+		{
+			name:   "when type unknown should report compatible",
+			schema: `{"type":"object"}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(model.Schema{}, repository.ErrNotFound)
+			},
+			res: model.CompatibilityReport{Type: "geo_rule", Mode: model.CompatBackward, Compatible: true},
+		},
+		{
+			name:   "when backward and a stored config breaks should list it",
+			schema: `{"type":"object","properties":{"pct":{"type":"integer","maximum":50}},"additionalProperties":false}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(prev, nil)
+			},
+			res: model.CompatibilityReport{
+				Type: "geo_rule", Mode: model.CompatBackward, Checked: 2,
+				Breaking: []model.BreakingConfig{{Name: "eu", Version: 4, Error: "/pct: maximum: got 80, want 50"}},
+			},
+		},
+		{
+			name:   "when forward and schema widened should list schema issues only",
+			schema: `{"type":"object","properties":{"pct":{"type":"integer"}},"additionalProperties":false}`,
+			compat: model.CompatForward,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(prev, nil)
+			},
+			res: model.CompatibilityReport{
+				Type: "geo_rule", Mode: model.CompatForward,
+				SchemaIssues: []model.SchemaIssue{{Path: "/properties/pct/maximum", Message: "upper bound relaxed from 100"}},
+			},
+		},
+		{
+			name:   "when full and schema unchanged should be compatible",
+			schema: string(prev.Schema),
+			compat: model.CompatFull,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(prev, nil)
+			},
+			res: model.CompatibilityReport{Type: "geo_rule", Mode: model.CompatFull, Compatible: true, Checked: 2},
+		},
+		{
+			name:   "when config source fails should return error",
+			schema: `{"type":"object"}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(prev, nil)
+			},
+			sourceErr: errors.New("db down"),
+			err:       errors.New("db down"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo, configs: ConfigSourceFunc(func(context.Context, string) ([]model.StoredConfig, error) {
+				return configs, tc.sourceErr
+			})}
+
+			got, err := svc.Check(context.Background(), "geo_rule", json.RawMessage(tc.schema), tc.compat)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
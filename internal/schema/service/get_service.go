@@ -0,0 +1,34 @@
+package service
+
+import (
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+// Get returns the latest schema of schemaType, or the given version.
+func (s service) Get(ctx context.Context, schemaType string, version *int) (model.Schema, error) {
+	schemaType = strings.TrimSpace(schemaType)
+	if schemaType == "" {
+		return model.Schema{}, ErrInvalidInput
+	}
+
+	var (
+		res model.Schema
+		err error
+	)
+	if version == nil {
+		res, err = s.repo.Latest(ctx, schemaType)
+	} else {
+		res, err = s.repo.ByVersion(ctx, schemaType, *version)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return model.Schema{}, ErrNotFound
+		}
+		return model.Schema{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/repository"
+	repoMock "configuration-management-service/internal/schema/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Get(t *testing.T) {
+	two := 2
+
+	cases := []struct {
+		name       string
+		schemaType string
+		version    *int
+		mockFunc   func(m *repoMock.MockIRepo)
+		res        model.Schema
+		err        error
+	}{
+		{
+			name:       "when empty type should return ErrInvalidInput",
+			schemaType: " ",
+			mockFunc:   func(m *repoMock.MockIRepo) {},
+			err:        ErrInvalidInput,
+		},
+		{
+			name:       "when latest not found should return ErrNotFound",
+			schemaType: "geo_rule",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(model.Schema{}, repository.ErrNotFound)
+			},
+			err: ErrNotFound,
+		},
+		{
+			name:       "when version given should read that version",
+			schemaType: "geo_rule",
+			version:    &two,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().ByVersion(gomock.Any(), "geo_rule", 2).Return(model.Schema{Type: "geo_rule", Version: 2}, nil)
+			},
+			res: model.Schema{Type: "geo_rule", Version: 2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo}
+
+			got, err := svc.Get(context.Background(), tc.schemaType, tc.version)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
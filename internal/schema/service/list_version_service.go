@@ -0,0 +1,27 @@
+package service
+
+import (
+	"configuration-management-service/internal/schema/model"
+	"context"
+	"strings"
+)
+
+func (s service) ListVersions(ctx context.Context, schemaType string) ([]model.Schema, error) {
+	schemaType = strings.TrimSpace(schemaType)
+	if schemaType == "" {
+		return nil, ErrInvalidInput
+	}
+	res, err := s.repo.List(ctx, schemaType)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, ErrNotFound
+	}
+	return res, nil
+}
+
+// List returns the latest schema of every registered type.
+func (s service) List(ctx context.Context) ([]model.Schema, error) {
+	return s.repo.LatestAll(ctx)
+}
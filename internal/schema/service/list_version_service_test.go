@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	repoMock "configuration-management-service/internal/schema/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_ListVersions(t *testing.T) {
+	cases := []struct {
+		name     string
+		mockFunc func(m *repoMock.MockIRepo)
+		count    int
+		err      error
+	}{
+		{
+			name: "when no versions should return ErrNotFound",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().List(gomock.Any(), "geo_rule").Return(nil, nil)
+			},
+			err: ErrNotFound,
+		},
+		{
+			name: "when success",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().List(gomock.Any(), "geo_rule").Return([]model.Schema{{Version: 1}, {Version: 2}}, nil)
+			},
+			count: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo}
+
+			got, err := svc.ListVersions(context.Background(), "geo_rule")
+			assert.Equal(t, tc.err, err)
+			assert.Len(t, got, tc.count)
+		})
+	}
+}
+
+func Test_service_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repoMock.NewMockIRepo(ctrl)
+	repo.EXPECT().LatestAll(gomock.Any()).Return([]model.Schema{{Type: "feature_toggle", Version: 1}, {Type: "geo_rule", Version: 2}}, nil)
+	svc := service{repo: repo}
+
+	got, err := svc.List(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Schema{{Type: "feature_toggle", Version: 1}, {Type: "geo_rule", Version: 2}}, got)
+}
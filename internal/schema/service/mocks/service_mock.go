@@ -0,0 +1,194 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/schema/service/service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "configuration-management-service/internal/schema/model"
+	context "context"
+	json "encoding/json"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConfigSource is a mock of ConfigSource interface.
+type MockConfigSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigSourceMockRecorder
+}
+
+// MockConfigSourceMockRecorder is the mock recorder for MockConfigSource.
+type MockConfigSourceMockRecorder struct {
+	mock *MockConfigSource
+}
+
+// NewMockConfigSource creates a new mock instance.
+func NewMockConfigSource(ctrl *gomock.Controller) *MockConfigSource {
+	mock := &MockConfigSource{ctrl: ctrl}
+	mock.recorder = &MockConfigSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigSource) EXPECT() *MockConfigSourceMockRecorder {
+	return m.recorder
+}
+
+// LatestOfType mocks base method.
+func (m *MockConfigSource) LatestOfType(ctx context.Context, schemaType string) ([]model.StoredConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestOfType", ctx, schemaType)
+	ret0, _ := ret[0].([]model.StoredConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestOfType indicates an expected call of LatestOfType.
+func (mr *MockConfigSourceMockRecorder) LatestOfType(ctx, schemaType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestOfType", reflect.TypeOf((*MockConfigSource)(nil).LatestOfType), ctx, schemaType)
+}
+
+// MockIService is a mock of IService interface.
+type MockIService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceMockRecorder
+}
+
+// MockIServiceMockRecorder is the mock recorder for MockIService.
+type MockIServiceMockRecorder struct {
+	mock *MockIService
+}
+
+// NewMockIService creates a new mock instance.
+func NewMockIService(ctrl *gomock.Controller) *MockIService {
+	mock := &MockIService{ctrl: ctrl}
+	mock.recorder = &MockIServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIService) EXPECT() *MockIServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockIService) Check(ctx context.Context, schemaType string, schema json.RawMessage, compatibility string) (model.CompatibilityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, schemaType, schema, compatibility)
+	ret0, _ := ret[0].(model.CompatibilityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockIServiceMockRecorder) Check(ctx, schemaType, schema, compatibility interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockIService)(nil).Check), ctx, schemaType, schema, compatibility)
+}
+
+// Get mocks base method.
+func (m *MockIService) Get(ctx context.Context, schemaType string, version *int) (model.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, schemaType, version)
+	ret0, _ := ret[0].(model.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIServiceMockRecorder) Get(ctx, schemaType, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIService)(nil).Get), ctx, schemaType, version)
+}
+
+// List mocks base method.
+func (m *MockIService) List(ctx context.Context) ([]model.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]model.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIServiceMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIService)(nil).List), ctx)
+}
+
+// ListVersions mocks base method.
+func (m *MockIService) ListVersions(ctx context.Context, schemaType string) ([]model.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVersions", ctx, schemaType)
+	ret0, _ := ret[0].([]model.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVersions indicates an expected call of ListVersions.
+func (mr *MockIServiceMockRecorder) ListVersions(ctx, schemaType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersions", reflect.TypeOf((*MockIService)(nil).ListVersions), ctx, schemaType)
+}
+
+// Register mocks base method.
+func (m *MockIService) Register(ctx context.Context, schemaType string, schema json.RawMessage, compatibility string) (model.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, schemaType, schema, compatibility)
+	ret0, _ := ret[0].(model.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockIServiceMockRecorder) Register(ctx, schemaType, schema, compatibility interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIService)(nil).Register), ctx, schemaType, schema, compatibility)
+}
+
+// Seed mocks base method.
+func (m *MockIService) Seed(ctx context.Context, defaults map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seed", ctx, defaults)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Seed indicates an expected call of Seed.
+func (mr *MockIServiceMockRecorder) Seed(ctx, defaults interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seed", reflect.TypeOf((*MockIService)(nil).Seed), ctx, defaults)
+}
+
+// Sync mocks base method.
+func (m *MockIService) Sync(ctx context.Context, schemaType string, schema json.RawMessage) (model.Schema, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx, schemaType, schema)
+	ret0, _ := ret[0].(model.Schema)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockIServiceMockRecorder) Sync(ctx, schemaType, schema interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockIService)(nil).Sync), ctx, schemaType, schema)
+}
+
+// Upgrade mocks base method.
+func (m *MockIService) Upgrade(ctx context.Context, defaults map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upgrade", ctx, defaults)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upgrade indicates an expected call of Upgrade.
+func (mr *MockIServiceMockRecorder) Upgrade(ctx, defaults interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upgrade", reflect.TypeOf((*MockIService)(nil).Upgrade), ctx, defaults)
+}
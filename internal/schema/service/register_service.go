@@ -0,0 +1,95 @@
+package service
+
+import (
+	"bytes"
+	"configuration-management-service/internal/schema/compiler"
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Register stores schema as the next version of schemaType. The first
+// version of an unknown type creates that type; later versions must pass
+// the compatibility check, and an empty compatibility keeps the mode of
+// the latest version.
+func (s service) Register(ctx context.Context, schemaType string, schema json.RawMessage, compatibility string) (model.Schema, error) {
+	schemaType, body, err := prepare(schemaType, schema, compatibility)
+	if err != nil {
+		return model.Schema{}, err
+	}
+
+	prev, err := s.repo.Latest(ctx, schemaType)
+	if errors.Is(err, repository.ErrNotFound) {
+		if compatibility == "" {
+			compatibility = model.CompatBackward
+		}
+		return s.store(ctx, schemaType, body, compatibility)
+	}
+	if err != nil {
+		return model.Schema{}, err
+	}
+
+	if compatibility == "" {
+		compatibility = prev.Compatibility
+	}
+	report, err := s.check(ctx, prev, body, compatibility)
+	if err != nil {
+		return model.Schema{}, err
+	}
+	if !report.Compatible {
+		return model.Schema{}, &IncompatibleError{Report: report}
+	}
+	return s.store(ctx, schemaType, body, compatibility)
+}
+
+// store appends body as the next version of schemaType and tells the
+// listener.
+func (s service) store(ctx context.Context, schemaType string, body json.RawMessage, compatibility string) (model.Schema, error) {
+	res, err := s.repo.Append(ctx, schemaType, body, compatibility)
+	if err != nil {
+		return model.Schema{}, err
+	}
+	if s.changes != nil {
+		s.changes.SchemaChanged(schemaType)
+	}
+	return res, nil
+}
+
+// prepare validates the arguments shared by Register and Check.
+func prepare(schemaType string, schema json.RawMessage, compatibility string) (string, json.RawMessage, error) {
+	schemaType = strings.TrimSpace(schemaType)
+	if !typePattern.MatchString(schemaType) {
+		return "", nil, fmt.Errorf("%w: type must match %s", ErrInvalidInput, typePattern.String())
+	}
+	if compatibility != "" && !modes[compatibility] {
+		return "", nil, fmt.Errorf("%w: compatibility must be one of backward, forward, full, none", ErrInvalidInput)
+	}
+	body, err := compile(schemaType, schema)
+	if err != nil {
+		return "", nil, err
+	}
+	return schemaType, body, nil
+}
+
+// compile checks that schema is a usable JSON Schema object and returns it compacted.
+func compile(schemaType string, schema json.RawMessage) (json.RawMessage, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, fmt.Errorf("%w: schema must be a JSON object", ErrInvalidInput)
+	}
+	if mode, ok := doc["x-defaults"]; ok && mode != "read" && mode != "write" {
+		return nil, fmt.Errorf("%w: x-defaults must be \"read\" or \"write\"", ErrInvalidInput)
+	}
+	if _, err := compiler.Compile(schemaType, schema); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, schema); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err.Error())
+	}
+	return buf.Bytes(), nil
+}
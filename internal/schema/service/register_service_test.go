@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/repository"
+	repoMock "configuration-management-service/internal/schema/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Register(t *testing.T) {
+	cases := []struct {
+		name       string
+		schemaType string
+		schema     string
+		compat     string
+		configs    []model.StoredConfig
+		mockFunc   func(m *repoMock.MockIRepo)
+		res        model.Schema
+		err        error
+	}{
+		{
+			name:       "when type name invalid should return ErrInvalidInput",
+			schemaType: "Geo-Rule",
+			schema:     `{"type":"object"}`,
+			mockFunc:   func(m *repoMock.MockIRepo) {},
+			err:        ErrInvalidInput,
+		},
+		{
+			name:       "when schema is not an object should return ErrInvalidInput",
+			schemaType: "geo_rule",
+			schema:     `[1]`,
+			mockFunc:   func(m *repoMock.MockIRepo) {},
+			err:        ErrInvalidInput,
+		},
+		{
+			name:       "when schema does not compile should return ErrInvalidInput",
+			schemaType: "geo_rule",
+			schema:     `{"type":"no-such-type"}`,
+			mockFunc:   func(m *repoMock.MockIRepo) {},
+			err:        ErrInvalidInput,
+		},
+		{
+			name:       "when schema references a remote document should return ErrInvalidInput",
+			schemaType: "geo_rule",
+			schema:     `{"properties":{"region":{"$ref":"https://example.com/defs.json#/region"}}}`,
+			mockFunc:   func(m *repoMock.MockIRepo) {},
+			err:        ErrInvalidInput,
+		},
+		{
+			name:       "when 2020-12 schema references the shared definitions should store it",
+			schemaType: "geo_rule",
+			schema:     `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"tags":{"$ref":"common.json#/$defs/stringSet"}},"unevaluatedProperties":false}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(model.Schema{}, repository.ErrNotFound)
+				m.EXPECT().Append(gomock.Any(), "geo_rule", gomock.Any(), model.CompatBackward).
+					Return(model.Schema{Type: "geo_rule", Version: 1, Compatibility: model.CompatBackward}, nil)
+			},
+			res: model.Schema{Type: "geo_rule", Version: 1, Compatibility: model.CompatBackward},
+		},
+		{
+			name:       "when x-defaults mode unknown should return ErrInvalidInput",
+			schemaType: "geo_rule",
+			schema:     `{"type":"object","x-defaults":"always"}`,
+			mockFunc:   func(m *repoMock.MockIRepo) {},
+			err:        ErrInvalidInput,
+		},
+		{
+			name:       "when compatibility unknown should return ErrInvalidInput",
+			schemaType: "geo_rule",
+			schema:     `{"type":"object"}`,
+			compat:     "sideways",
+			mockFunc:   func(m *repoMock.MockIRepo) {},
+			err:        ErrInvalidInput,
+		},
+		{
+			name:       "when new type should store compacted schema as version 1 with backward mode",
+			schemaType: "geo_rule",
+			schema:     `{ "type": "object" }`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(model.Schema{}, repository.ErrNotFound)
+				m.EXPECT().Append(gomock.Any(), "geo_rule", json.RawMessage(`{"type":"object"}`), model.CompatBackward).
+					Return(model.Schema{Type: "geo_rule", Version: 1, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: model.CompatBackward}, nil)
+			},
+			res: model.Schema{Type: "geo_rule", Version: 1, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: model.CompatBackward},
+		},
+		{
+			name:       "when stored configs break should return IncompatibleError",
+			schemaType: "geo_rule",
+			schema:     `{"type":"object","required":["region"]}`,
+			configs: []model.StoredConfig{
+				{Name: "eu", Version: 2, Data: json.RawMessage(`{"region":"eu"}`)},
+				{Name: "legacy", Version: 1, Data: json.RawMessage(`{}`)},
+			},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").
+					Return(model.Schema{Type: "geo_rule", Version: 1, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: model.CompatBackward}, nil)
+			},
+			err: ErrIncompatible,
+		},
+		{
+			name:       "when stored configs still valid should inherit mode and store next version",
+			schemaType: "geo_rule",
+			schema:     `{"type":"object","required":["region"]}`,
+			configs: []model.StoredConfig{
+				{Name: "eu", Version: 2, Data: json.RawMessage(`{"region":"eu"}`)},
+			},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").
+					Return(model.Schema{Type: "geo_rule", Version: 1, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: model.CompatFull}, nil)
+				m.EXPECT().Append(gomock.Any(), "geo_rule", json.RawMessage(`{"type":"object","required":["region"]}`), model.CompatFull).
+					Return(model.Schema{Type: "geo_rule", Version: 2, Compatibility: model.CompatFull}, nil)
+			},
+			res: model.Schema{Type: "geo_rule", Version: 2, Compatibility: model.CompatFull},
+		},
+		{
+			name:       "when mode none should skip the check",
+			schemaType: "geo_rule",
+			schema:     `{"type":"object","required":["region"]}`,
+			compat:     model.CompatNone,
+			configs:    []model.StoredConfig{{Name: "legacy", Version: 1, Data: json.RawMessage(`{}`)}},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").
+					Return(model.Schema{Type: "geo_rule", Version: 1, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: model.CompatBackward}, nil)
+				m.EXPECT().Append(gomock.Any(), "geo_rule", gomock.Any(), model.CompatNone).
+					Return(model.Schema{Type: "geo_rule", Version: 2, Compatibility: model.CompatNone}, nil)
+			},
+			res: model.Schema{Type: "geo_rule", Version: 2, Compatibility: model.CompatNone},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			var changed []string
+			svc := service{repo: repo, configs: ConfigSourceFunc(func(context.Context, string) ([]model.StoredConfig, error) {
+				return tc.configs, nil
+			}), changes: ChangeListenerFunc(func(schemaType string) { changed = append(changed, schemaType) })}
+
+			got, err := svc.Register(context.Background(), tc.schemaType, json.RawMessage(tc.schema), tc.compat)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Empty(t, changed)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{tc.schemaType}, changed)
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
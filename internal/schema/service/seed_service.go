@@ -0,0 +1,86 @@
+package service
+
+import (
+	"bytes"
+	"configuration-management-service/internal/schema/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+)
+
+// Seed registers every default schema as version 1 of its type when the type
+// has no version yet; registered types are left untouched. It runs before
+// stored configs can be checked, so changed defaults wait for Upgrade.
+func (s service) Seed(ctx context.Context, defaults map[string]string) error {
+	for _, t := range sortedTypes(defaults) {
+		body, err := compile(t, []byte(defaults[t]))
+		if err != nil {
+			return fmt.Errorf("seed %s: %w", t, err)
+		}
+		seeded, err := s.repo.Seed(ctx, t, body)
+		if err != nil {
+			return fmt.Errorf("seed %s: %w", t, err)
+		}
+		if seeded {
+			log.Printf("schema: seeded built-in %s as version 1", t)
+		}
+	}
+	return nil
+}
+
+// Upgrade registers a changed default schema as the next version of its
+// type, through Register, so it passes the compatibility check against the
+// stored configs and keeps the mode of the latest version. A type whose
+// latest version is not the one seeded from its default was changed by an
+// operator and is left as it is, as is a default the stored configs break.
+func (s service) Upgrade(ctx context.Context, defaults map[string]string) error {
+	for _, t := range sortedTypes(defaults) {
+		body, err := compile(t, []byte(defaults[t]))
+		if err != nil {
+			return fmt.Errorf("upgrade %s: %w", t, err)
+		}
+		latest, err := s.repo.Latest(ctx, t)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("upgrade %s: %w", t, err)
+		}
+		if bytes.Equal(latest.Schema, body) {
+			continue
+		}
+		seeded, err := s.repo.SeededVersion(ctx, t)
+		if err != nil {
+			return fmt.Errorf("upgrade %s: %w", t, err)
+		}
+		if seeded != latest.Version {
+			log.Printf("schema: kept %s version %d registered after the built-in one", t, latest.Version)
+			continue
+		}
+
+		res, err := s.Register(ctx, t, body, "")
+		if errors.Is(err, ErrIncompatible) {
+			log.Printf("schema: kept %s version %d: %v", t, latest.Version, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("upgrade %s: %w", t, err)
+		}
+		if err := s.repo.MarkSeeded(ctx, t, res.Version); err != nil {
+			return fmt.Errorf("upgrade %s: %w", t, err)
+		}
+		log.Printf("schema: upgraded built-in %s to version %d", t, res.Version)
+	}
+	return nil
+}
+
+func sortedTypes(defaults map[string]string) []string {
+	types := make([]string, 0, len(defaults))
+	for t := range defaults {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/repository"
+	repoMock "configuration-management-service/internal/schema/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Seed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repoMock.NewMockIRepo(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().Seed(gomock.Any(), "a_type", json.RawMessage(`{"type":"object"}`)).Return(true, nil),
+		repo.EXPECT().Seed(gomock.Any(), "b_type", json.RawMessage(`{"type":"string"}`)).Return(false, nil),
+	)
+	svc := service{repo: repo}
+
+	err := svc.Seed(context.Background(), map[string]string{
+		"b_type": `{ "type": "string" }`,
+		"a_type": `{ "type": "object" }`,
+	})
+	assert.NoError(t, err)
+}
+
+func Test_service_Seed_InvalidDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := service{repo: repoMock.NewMockIRepo(ctrl)}
+	err := svc.Seed(context.Background(), map[string]string{"bad": `{`})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func Test_service_Upgrade(t *testing.T) {
+	const changed = `{"type":"object","required":["region"]}`
+	seeded := model.Schema{Type: "geo_rule", Version: 1, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: model.CompatFull}
+
+	cases := []struct {
+		name     string
+		configs  []model.StoredConfig
+		mockFunc func(m *repoMock.MockIRepo)
+	}{
+		{
+			name: "when type has no version should skip it",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(model.Schema{}, repository.ErrNotFound)
+			},
+		},
+		{
+			name: "when default unchanged should skip it",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(model.Schema{Type: "geo_rule", Version: 1, Schema: json.RawMessage(changed)}, nil)
+			},
+		},
+		{
+			name: "when latest version is the seeded one should register the default with its mode",
+			configs: []model.StoredConfig{
+				{Name: "eu", Version: 1, Data: json.RawMessage(`{"region":"eu"}`)},
+			},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(seeded, nil).Times(2)
+				m.EXPECT().SeededVersion(gomock.Any(), "geo_rule").Return(1, nil)
+				m.EXPECT().Append(gomock.Any(), "geo_rule", json.RawMessage(changed), model.CompatFull).
+					Return(model.Schema{Type: "geo_rule", Version: 2, Compatibility: model.CompatFull}, nil)
+				m.EXPECT().MarkSeeded(gomock.Any(), "geo_rule", 2).Return(nil)
+			},
+		},
+		{
+			name: "when an operator registered a later version should keep it",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").
+					Return(model.Schema{Type: "geo_rule", Version: 2, Schema: json.RawMessage(`{"type":"object","properties":{}}`)}, nil)
+				m.EXPECT().SeededVersion(gomock.Any(), "geo_rule").Return(1, nil)
+			},
+		},
+		{
+			name: "when stored configs break the default should keep the seeded version",
+			configs: []model.StoredConfig{
+				{Name: "legacy", Version: 1, Data: json.RawMessage(`{}`)},
+			},
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "geo_rule").Return(seeded, nil).Times(2)
+				m.EXPECT().SeededVersion(gomock.Any(), "geo_rule").Return(1, nil)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo, configs: ConfigSourceFunc(func(context.Context, string) ([]model.StoredConfig, error) {
+				return tc.configs, nil
+			})}
+
+			err := svc.Upgrade(context.Background(), map[string]string{"geo_rule": changed})
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidInput = errors.New("invalid input")
+	ErrIncompatible = errors.New("incompatible schema")
+)
+
+// IncompatibleError carries the report of a rejected schema change.
+type IncompatibleError struct {
+	Report model.CompatibilityReport
+}
+
+func (e *IncompatibleError) Error() string {
+	return fmt.Sprintf("%s: %d breaking configs, %d schema issues", ErrIncompatible, len(e.Report.Breaking), len(e.Report.SchemaIssues))
+}
+
+func (e *IncompatibleError) Is(target error) bool { return target == ErrIncompatible }
+
+// ConfigSource lists the stored configs a schema change must keep valid.
+type ConfigSource interface {
+	LatestOfType(ctx context.Context, schemaType string) ([]model.StoredConfig, error)
+}
+
+// ConfigSourceFunc adapts a function to ConfigSource.
+type ConfigSourceFunc func(ctx context.Context, schemaType string) ([]model.StoredConfig, error)
+
+func (f ConfigSourceFunc) LatestOfType(ctx context.Context, schemaType string) ([]model.StoredConfig, error) {
+	return f(ctx, schemaType)
+}
+
+// ChangeListener is told the type of every schema version the registry
+// stores, so callers that cache the latest schema can drop it.
+type ChangeListener interface {
+	SchemaChanged(schemaType string)
+}
+
+// ChangeListenerFunc adapts a function to ChangeListener.
+type ChangeListenerFunc func(schemaType string)
+
+func (f ChangeListenerFunc) SchemaChanged(schemaType string) {
+	f(schemaType)
+}
+
+// typePattern matches the naming of the built-in config types.
+var typePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+type IService interface {
+	Register(ctx context.Context, schemaType string, schema json.RawMessage, compatibility string) (model.Schema, error)
+	Check(ctx context.Context, schemaType string, schema json.RawMessage, compatibility string) (model.CompatibilityReport, error)
+	Get(ctx context.Context, schemaType string, version *int) (model.Schema, error)
+	ListVersions(ctx context.Context, schemaType string) ([]model.Schema, error)
+	List(ctx context.Context) ([]model.Schema, error)
+	Seed(ctx context.Context, defaults map[string]string) error
+	Upgrade(ctx context.Context, defaults map[string]string) error
+	Sync(ctx context.Context, schemaType string, schema json.RawMessage) (model.Schema, bool, error)
+}
+
+type service struct {
+	repo repository.IRepo
+	// configs may be nil, in which case backward checks see no stored configs.
+	configs ConfigSource
+	// changes may be nil when nothing caches schemas.
+	changes ChangeListener
+}
+
+func NewService(repo repository.IRepo, configs ConfigSource, changes ChangeListener) IService {
+	return service{repo: repo, configs: configs, changes: changes}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"bytes"
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/repository"
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Sync registers schema as the next version of schemaType unless it equals
+// the latest version already. It reports whether a version was written;
+// a changed schema passes the same checks as Register.
+func (s service) Sync(ctx context.Context, schemaType string, schema json.RawMessage) (model.Schema, bool, error) {
+	schemaType, body, err := prepare(schemaType, schema, "")
+	if err != nil {
+		return model.Schema{}, false, err
+	}
+
+	latest, err := s.repo.Latest(ctx, schemaType)
+	switch {
+	case err == nil && bytes.Equal(latest.Schema, body):
+		return latest, false, nil
+	case err != nil && !errors.Is(err, repository.ErrNotFound):
+		return model.Schema{}, false, err
+	}
+
+	res, err := s.Register(ctx, schemaType, body, "")
+	if err != nil {
+		return model.Schema{}, false, err
+	}
+	return res, true, nil
+}
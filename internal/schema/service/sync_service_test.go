@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/schema/model"
+	"configuration-management-service/internal/schema/repository"
+	repoMock "configuration-management-service/internal/schema/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Sync(t *testing.T) {
+	v1 := model.Schema{Type: "circuit_breaker", Version: 1, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: model.CompatNone}
+
+	cases := []struct {
+		name     string
+		schema   string
+		mockFunc func(m *repoMock.MockIRepo)
+		res      model.Schema
+		changed  bool
+		err      error
+	}{
+		{
+			name:     "when schema does not compile should return ErrInvalidInput",
+			schema:   `{"type":"no-such-type"}`,
+			mockFunc: func(m *repoMock.MockIRepo) {},
+			err:      ErrInvalidInput,
+		},
+		{
+			name:   "when type is new should register version 1",
+			schema: `{ "type": "object" }`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "circuit_breaker").Return(model.Schema{}, repository.ErrNotFound).Times(2)
+				m.EXPECT().Append(gomock.Any(), "circuit_breaker", json.RawMessage(`{"type":"object"}`), model.CompatBackward).
+					Return(model.Schema{Type: "circuit_breaker", Version: 1, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: model.CompatBackward}, nil)
+			},
+			res:     model.Schema{Type: "circuit_breaker", Version: 1, Schema: json.RawMessage(`{"type":"object"}`), Compatibility: model.CompatBackward},
+			changed: true,
+		},
+		{
+			name:   "when schema equals the latest version should write nothing",
+			schema: "{\n  \"type\": \"object\"\n}",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "circuit_breaker").Return(v1, nil)
+			},
+			res: v1,
+		},
+		{
+			name:   "when schema changed should register the next version with the latest mode",
+			schema: `{"type":"object","required":["threshold"]}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "circuit_breaker").Return(v1, nil).Times(2)
+				m.EXPECT().Append(gomock.Any(), "circuit_breaker", json.RawMessage(`{"type":"object","required":["threshold"]}`), model.CompatNone).
+					Return(model.Schema{Type: "circuit_breaker", Version: 2, Compatibility: model.CompatNone}, nil)
+			},
+			res:     model.Schema{Type: "circuit_breaker", Version: 2, Compatibility: model.CompatNone},
+			changed: true,
+		},
+		{
+			name:   "when latest lookup fails should return the error",
+			schema: `{"type":"object"}`,
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "circuit_breaker").Return(model.Schema{}, errors.New("db down"))
+			},
+			err: errors.New("db down"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo}
+
+			res, changed, err := svc.Sync(context.Background(), "circuit_breaker", json.RawMessage(tc.schema))
+			if tc.err != nil {
+				if errors.Is(tc.err, ErrInvalidInput) {
+					assert.ErrorIs(t, err, tc.err)
+				} else {
+					assert.EqualError(t, err, tc.err.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.res, res)
+			assert.Equal(t, tc.changed, changed)
+		})
+	}
+}
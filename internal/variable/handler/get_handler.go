@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handler) Get(c echo.Context) error {
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		return httpx.WriteError(c, http.StatusBadRequest, "name is required", nil)
+	}
+
+	v, err := h.srv.Get(c.Request().Context(), name)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, v)
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/variable/model"
+	"configuration-management-service/internal/variable/service"
+	srvMock "configuration-management-service/internal/variable/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	cases := []struct {
+		name     string
+		varName  string
+		mockFunc func(m *srvMock.MockIService)
+		code     int
+		json     string
+	}{
+		{
+			name:     "when missing name should status code 400",
+			varName:  " ",
+			mockFunc: func(m *srvMock.MockIService) {},
+			code:     http.StatusBadRequest,
+			json:     `{"error":{"code":"Bad Request","message":"name is required","details":null}}`,
+		},
+		{
+			name:    "when not found should status code 404",
+			varName: "domain",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "domain").Return(model.Variable{}, service.ErrNotFound)
+			},
+			code: http.StatusNotFound,
+			json: `{"error":{"code":"Not Found","message":"not found","details":null}}`,
+		},
+		{
+			name:    "when success",
+			varName: "domain",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Get(gomock.Any(), "domain").Return(model.Variable{Name: "domain", Version: 3, Value: "example.com"}, nil)
+			},
+			code: http.StatusOK,
+			json: `{"name":"domain","version":3,"value":"example.com","created_at":""}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodGet, "/variables/_placeholder", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("name")
+			c.SetParamValues(tc.varName)
+
+			_ = h.Get(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.json, string(b))
+		})
+	}
+}
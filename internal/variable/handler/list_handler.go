@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"configuration-management-service/pkg/httpx"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// List returns the latest version of every variable.
+func (h *handler) List(c echo.Context) error {
+	res, err := h.srv.LatestAll(c.Request().Context())
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]any{"variables": res})
+}
+
+func (h *handler) ListVersions(c echo.Context) error {
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		return httpx.WriteError(c, http.StatusBadRequest, "name is required", nil)
+	}
+
+	res, err := h.srv.ListVersions(c.Request().Context(), name)
+	if err != nil {
+		return h.writeServiceError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]any{"versions": res})
+}
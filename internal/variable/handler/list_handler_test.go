@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/variable/model"
+	"configuration-management-service/internal/variable/service"
+	srvMock "configuration-management-service/internal/variable/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		code     int
+		json     string
+	}{
+		{
+			name: "when service error should status code 500",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().LatestAll(gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			code: http.StatusInternalServerError,
+			json: `{"error":{"code":"Internal Server Error","message":"internal error","details":null}}`,
+		},
+		{
+			name: "when success should return latest of every variable",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().LatestAll(gomock.Any()).Return([]model.Variable{{Name: "domain", Version: 3, Value: "example.com"}}, nil)
+			},
+			code: http.StatusOK,
+			json: `{"variables":[{"name":"domain","version":3,"value":"example.com","created_at":""}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/variables", nil), rec)
+
+			_ = h.List(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.json, string(b))
+		})
+	}
+}
+
+func TestListVersions(t *testing.T) {
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		code     int
+		json     string
+	}{
+		{
+			name: "when not found should status code 404",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().ListVersions(gomock.Any(), "domain").Return(nil, service.ErrNotFound)
+			},
+			code: http.StatusNotFound,
+			json: `{"error":{"code":"Not Found","message":"not found","details":null}}`,
+		},
+		{
+			name: "when success",
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().ListVersions(gomock.Any(), "domain").Return([]model.Variable{
+					{Name: "domain", Version: 1, Value: "a.com"},
+					{Name: "domain", Version: 2, Value: "b.com"},
+				}, nil)
+			},
+			code: http.StatusOK,
+			json: `{"versions":[{"name":"domain","version":1,"value":"a.com","created_at":""},{"name":"domain","version":2,"value":"b.com","created_at":""}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/variables/_placeholder/versions", nil), rec)
+			c.SetParamNames("name")
+			c.SetParamValues("domain")
+
+			_ = h.ListVersions(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.json, string(b))
+		})
+	}
+}
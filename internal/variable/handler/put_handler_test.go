@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"configuration-management-service/internal/variable/model"
+	"configuration-management-service/internal/variable/service"
+	srvMock "configuration-management-service/internal/variable/service/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPut(t *testing.T) {
+	type input struct {
+		ct   string
+		name string
+		body string
+	}
+	type expected struct {
+		code int
+		json string
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m *srvMock.MockIService)
+		in       input
+		ex       expected
+	}{
+		{
+			name:     "when unsupported media type should status code 415",
+			in:       input{ct: "text/plain", name: "domain", body: `{}`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusUnsupportedMediaType,
+				json: `{"error":{"code":"Unsupported Media Type","message":"content-type must be application/json","details":null}}`,
+			},
+		},
+		{
+			name:     "when value missing should status code 400",
+			in:       input{ct: echo.MIMEApplicationJSON, name: "domain", body: `{}`},
+			mockFunc: func(m *srvMock.MockIService) {},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"value is required","details":null}}`,
+			},
+		},
+		{
+			name: "when invalid name should status code 400",
+			in:   input{ct: echo.MIMEApplicationJSON, name: "my-domain", body: `{"value":"x"}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Put(gomock.Any(), "my-domain", "x").
+					Return(model.Variable{}, fmt.Errorf("%w: bad name", service.ErrInvalidInput))
+			},
+			ex: expected{
+				code: http.StatusBadRequest,
+				json: `{"error":{"code":"Bad Request","message":"invalid input","details":"invalid input: bad name"}}`,
+			},
+		},
+		{
+			name: "when success should return stored version",
+			in:   input{ct: echo.MIMEApplicationJSON, name: "domain", body: `{"value":"example.com"}`},
+			mockFunc: func(m *srvMock.MockIService) {
+				m.EXPECT().Put(gomock.Any(), "domain", "example.com").
+					Return(model.Variable{Name: "domain", Version: 2, Value: "example.com"}, nil)
+			},
+			ex: expected{
+				code: http.StatusOK,
+				json: `{"name":"domain","version":2,"value":"example.com","created_at":""}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv := srvMock.NewMockIService(ctrl)
+			tc.mockFunc(srv)
+			h := NewHandler(srv)
+
+			req := httptest.NewRequest(http.MethodPut, "/variables/_placeholder", bytes.NewBufferString(tc.in.body))
+			req.Header.Set(echo.HeaderContentType, tc.in.ct)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("name")
+			c.SetParamValues(tc.in.name)
+
+			_ = h.Put(c)
+
+			res := rec.Result()
+			defer res.Body.Close()
+			b, _ := io.ReadAll(res.Body)
+
+			assert.Equal(t, tc.ex.code, res.StatusCode, string(b))
+			assert.JSONEq(t, tc.ex.json, string(b))
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package model
+
+// Variable is one version of a shared value that configs reference as
+// {{ .vars.<name> }}.
+type Variable struct {
+	Name      string `json:"name"`
+	Version   int    `json:"version"`
+	Value     string `json:"value"`
+	CreatedAt string `json:"created_at"`
+}
+
+type VariablePutRequest struct {
+	Value *string `json:"value"`
+}
@@ -0,0 +1,51 @@
+package variable
+
+import (
+	"configuration-management-service/internal/variable/handler"
+	"configuration-management-service/internal/variable/repository"
+	"configuration-management-service/internal/variable/service"
+	"database/sql"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IModule interface {
+	RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc)
+	Service() service.IService
+}
+
+type module struct {
+	srv  service.IService
+	repo repository.IRepo
+	h    handler.IHandler
+}
+
+func New(repo repository.IRepo) IModule {
+	srv := service.NewService(repo)
+	return &module{
+		srv:  srv,
+		repo: repo,
+		h:    handler.NewHandler(srv),
+	}
+}
+
+func InitModule(db *sql.DB) IModule {
+	return New(repository.NewRepo(db))
+}
+
+// Service exposes the variable store to modules that render {{ .vars.* }}.
+func (m *module) Service() service.IService {
+	return m.srv
+}
+
+func (m *module) RegisterRoute(g *echo.Group, writeLimit echo.MiddlewareFunc) {
+	if g == nil {
+		return
+	}
+
+	vars := g.Group("/variables")
+	vars.GET("", m.h.List)
+	vars.PUT("/:name", m.h.Put, writeLimit)
+	vars.GET("/:name", m.h.Get)
+	vars.GET("/:name/versions", m.h.ListVersions)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"configuration-management-service/internal/variable/model"
+	"context"
+)
+
+func (r *repo) Latest(ctx context.Context, name string) (model.Variable, error) {
+	const q = `
+		SELECT name, version, value, created_at
+		FROM variables
+		WHERE name = ?
+		ORDER BY version DESC
+		LIMIT 1
+	`
+	return scanVariable(r.db.QueryRowContext(ctx, q, name))
+}
+
+// LatestAll returns the latest version of every variable.
+func (r *repo) LatestAll(ctx context.Context) ([]model.Variable, error) {
+	const q = `
+		SELECT v.name, v.version, v.value, v.created_at
+		FROM variables v
+		JOIN (SELECT name, MAX(version) AS version FROM variables GROUP BY name) l
+		  ON l.name = v.name AND l.version = v.version
+		ORDER BY v.name ASC
+	`
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return scanVariables(rows)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"configuration-management-service/internal/variable/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Latest(t *testing.T) {
+	const q = `SELECT name, version, value, created_at FROM variables WHERE name = ? ORDER BY version DESC LIMIT 1`
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		exErr    error
+	}{
+		{
+			name: "when no rows should return ErrNotFound",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("domain").WillReturnError(sql.ErrNoRows)
+			},
+			exErr: ErrNotFound,
+		},
+		{
+			name: "when success",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("domain").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "version", "value", "created_at"}).
+						AddRow("domain", 3, "example.com", "2025-10-01T00:00:00Z"))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.Latest(context.Background(), "domain")
+
+			assert.Equal(t, tc.exErr, err)
+			if tc.exErr == nil {
+				assert.Equal(t, model.Variable{Name: "domain", Version: 3, Value: "example.com", CreatedAt: "2025-10-01T00:00:00Z"}, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_LatestAll(t *testing.T) {
+	r, mock, db := newMockRepoEq(t)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT v.name, v.version, v.value, v.created_at FROM variables v JOIN (SELECT name, MAX(version) AS version FROM variables GROUP BY name) l ON l.name = v.name AND l.version = v.version ORDER BY v.name ASC`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "version", "value", "created_at"}).
+			AddRow("domain", 3, "example.com", "2025-10-01T00:00:00Z").
+			AddRow("region", 1, "eu", "2025-10-01T00:00:00Z"))
+
+	got, err := r.LatestAll(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
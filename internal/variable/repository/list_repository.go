@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"configuration-management-service/internal/variable/model"
+	"context"
+)
+
+func (r *repo) List(ctx context.Context, name string) ([]model.Variable, error) {
+	const q = `
+		SELECT name, version, value, created_at
+		FROM variables
+		WHERE name = ?
+		ORDER BY version ASC
+	`
+	rows, err := r.db.QueryContext(ctx, q, name)
+	if err != nil {
+		return nil, err
+	}
+	return scanVariables(rows)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_List(t *testing.T) {
+	const q = `SELECT name, version, value, created_at FROM variables WHERE name = ? ORDER BY version ASC`
+
+	type exRes struct {
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		ex       exRes
+	}{
+		{
+			name: "when query error should return error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("domain").WillReturnError(errors.New("query err"))
+			},
+			ex: exRes{err: errors.New("query err")},
+		},
+		{
+			name: "when success with rows should return rows",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(q).WithArgs("domain").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "version", "value", "created_at"}).
+						AddRow("domain", 1, "a.com", "2025-10-01T00:00:00Z").
+						AddRow("domain", 2, "b.com", "2025-10-01T00:01:00Z"))
+			},
+			ex: exRes{count: 2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.List(context.Background(), "domain")
+
+			if tc.ex.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, got, tc.ex.count)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
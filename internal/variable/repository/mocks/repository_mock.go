@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/variable/repository/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "configuration-management-service/internal/variable/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRepo is a mock of IRepo interface.
+type MockIRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRepoMockRecorder
+}
+
+// MockIRepoMockRecorder is the mock recorder for MockIRepo.
+type MockIRepoMockRecorder struct {
+	mock *MockIRepo
+}
+
+// NewMockIRepo creates a new mock instance.
+func NewMockIRepo(ctrl *gomock.Controller) *MockIRepo {
+	mock := &MockIRepo{ctrl: ctrl}
+	mock.recorder = &MockIRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRepo) EXPECT() *MockIRepoMockRecorder {
+	return m.recorder
+}
+
+// Latest mocks base method.
+func (m *MockIRepo) Latest(ctx context.Context, name string) (model.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Latest", ctx, name)
+	ret0, _ := ret[0].(model.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Latest indicates an expected call of Latest.
+func (mr *MockIRepoMockRecorder) Latest(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockIRepo)(nil).Latest), ctx, name)
+}
+
+// LatestAll mocks base method.
+func (m *MockIRepo) LatestAll(ctx context.Context) ([]model.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestAll", ctx)
+	ret0, _ := ret[0].([]model.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestAll indicates an expected call of LatestAll.
+func (mr *MockIRepoMockRecorder) LatestAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestAll", reflect.TypeOf((*MockIRepo)(nil).LatestAll), ctx)
+}
+
+// List mocks base method.
+func (m *MockIRepo) List(ctx context.Context, name string) ([]model.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, name)
+	ret0, _ := ret[0].([]model.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIRepoMockRecorder) List(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIRepo)(nil).List), ctx, name)
+}
+
+// Put mocks base method.
+func (m *MockIRepo) Put(ctx context.Context, name, value string) (model.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, name, value)
+	ret0, _ := ret[0].(model.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockIRepoMockRecorder) Put(ctx, name, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockIRepo)(nil).Put), ctx, name, value)
+}
+
+// MockrowScanner is a mock of rowScanner interface.
+type MockrowScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockrowScannerMockRecorder
+}
+
+// MockrowScannerMockRecorder is the mock recorder for MockrowScanner.
+type MockrowScannerMockRecorder struct {
+	mock *MockrowScanner
+}
+
+// NewMockrowScanner creates a new mock instance.
+func NewMockrowScanner(ctrl *gomock.Controller) *MockrowScanner {
+	mock := &MockrowScanner{ctrl: ctrl}
+	mock.recorder = &MockrowScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrowScanner) EXPECT() *MockrowScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockrowScanner) Scan(dest ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockrowScannerMockRecorder) Scan(dest ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockrowScanner)(nil).Scan), dest...)
+}
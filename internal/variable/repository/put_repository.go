@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"configuration-management-service/internal/variable/model"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Put stores value as the next version of the variable (version 1 when new).
+func (r *repo) Put(ctx context.Context, name, value string) (model.Variable, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return model.Variable{}, fmt.Errorf("put.begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const qIns = `
+		INSERT INTO variables(name, version, value)
+		SELECT ?, COALESCE(MAX(version), 0) + 1, ?
+		FROM variables
+		WHERE name = ?
+	`
+	if _, err := tx.ExecContext(ctx, qIns, name, value, name); err != nil {
+		return model.Variable{}, fmt.Errorf("put.insert: %w", err)
+	}
+
+	const qSel = `
+		SELECT name, version, value, created_at
+		FROM variables
+		WHERE name = ?
+		ORDER BY version DESC
+		LIMIT 1
+	`
+	v, err := scanVariable(tx.QueryRowContext(ctx, qSel, name))
+	if err != nil {
+		return model.Variable{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.Variable{}, fmt.Errorf("put.commit: %w", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"configuration-management-service/internal/variable/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Put(t *testing.T) {
+	const qIns = `INSERT INTO variables(name, version, value) SELECT ?, COALESCE(MAX(version), 0) + 1, ? FROM variables WHERE name = ?`
+	const qSel = `SELECT name, version, value, created_at FROM variables WHERE name = ? ORDER BY version DESC LIMIT 1`
+
+	type exRes struct {
+		res model.Variable
+		err error
+	}
+
+	cases := []struct {
+		name     string
+		mockFunc func(m sqlmock.Sqlmock)
+		ex       exRes
+	}{
+		{
+			name: "when insert error should rollback and return error",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(qIns).WithArgs("domain", "example.com", "domain").WillReturnError(errors.New("insert err"))
+				m.ExpectRollback()
+			},
+			ex: exRes{err: errors.New("insert err")},
+		},
+		{
+			name: "when success should return stored version",
+			mockFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(qIns).WithArgs("domain", "example.com", "domain").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery(qSel).WithArgs("domain").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "version", "value", "created_at"}).
+						AddRow("domain", 2, "example.com", "2025-10-01T00:00:00Z"))
+				m.ExpectCommit()
+			},
+			ex: exRes{res: model.Variable{Name: "domain", Version: 2, Value: "example.com", CreatedAt: "2025-10-01T00:00:00Z"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, mock, db := newMockRepoEq(t)
+			defer db.Close()
+
+			tc.mockFunc(mock)
+			got, err := r.Put(context.Background(), "domain", "example.com")
+
+			if tc.ex.err != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.ex.res, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
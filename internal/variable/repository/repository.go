@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"configuration-management-service/internal/variable/model"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type IRepo interface {
+	Put(ctx context.Context, name, value string) (model.Variable, error)
+	Latest(ctx context.Context, name string) (model.Variable, error)
+	LatestAll(ctx context.Context) ([]model.Variable, error)
+	List(ctx context.Context, name string) ([]model.Variable, error)
+}
+
+type repo struct {
+	db *sql.DB
+}
+
+func NewRepo(db *sql.DB) IRepo {
+	return &repo{db: db}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanVariable(row rowScanner) (model.Variable, error) {
+	var v model.Variable
+	if err := row.Scan(&v.Name, &v.Version, &v.Value, &v.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Variable{}, ErrNotFound
+		}
+		return model.Variable{}, err
+	}
+	return v, nil
+}
+
+func scanVariables(rows *sql.Rows) ([]model.Variable, error) {
+	defer rows.Close()
+	var out []model.Variable
+	for rows.Next() {
+		v, err := scanVariable(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"configuration-management-service/internal/variable/model"
+	"configuration-management-service/internal/variable/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+func (s service) Get(ctx context.Context, name string) (model.Variable, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.Variable{}, ErrInvalidInput
+	}
+	v, err := s.repo.Latest(ctx, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return model.Variable{}, ErrNotFound
+		}
+		return model.Variable{}, err
+	}
+	return v, nil
+}
+
+func (s service) LatestAll(ctx context.Context) ([]model.Variable, error) {
+	return s.repo.LatestAll(ctx)
+}
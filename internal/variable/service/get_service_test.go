@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"configuration-management-service/internal/variable/model"
+	"configuration-management-service/internal/variable/repository"
+	repoMock "configuration-management-service/internal/variable/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Get(t *testing.T) {
+	cases := []struct {
+		name     string
+		varName  string
+		mockFunc func(m *repoMock.MockIRepo)
+		res      model.Variable
+		err      error
+	}{
+		{
+			name:     "when empty name should return ErrInvalidInput",
+			varName:  " ",
+			mockFunc: func(m *repoMock.MockIRepo) {},
+			err:      ErrInvalidInput,
+		},
+		{
+			name:    "when repo not found should return ErrNotFound",
+			varName: "domain",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "domain").Return(model.Variable{}, repository.ErrNotFound)
+			},
+			err: ErrNotFound,
+		},
+		{
+			name:    "when success",
+			varName: "domain",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Latest(gomock.Any(), "domain").Return(model.Variable{Name: "domain", Version: 2}, nil)
+			},
+			res: model.Variable{Name: "domain", Version: 2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo}
+
+			got, err := svc.Get(context.Background(), tc.varName)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"configuration-management-service/internal/variable/model"
+	"context"
+	"strings"
+)
+
+func (s service) ListVersions(ctx context.Context, name string) ([]model.Variable, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidInput
+	}
+	res, err := s.repo.List(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, ErrNotFound
+	}
+	return res, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"configuration-management-service/internal/variable/model"
+	repoMock "configuration-management-service/internal/variable/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_ListVersions(t *testing.T) {
+	cases := []struct {
+		name     string
+		varName  string
+		mockFunc func(m *repoMock.MockIRepo)
+		count    int
+		err      error
+	}{
+		{
+			name:     "when empty name should return ErrInvalidInput",
+			varName:  "",
+			mockFunc: func(m *repoMock.MockIRepo) {},
+			err:      ErrInvalidInput,
+		},
+		{
+			name:    "when no versions should return ErrNotFound",
+			varName: "domain",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().List(gomock.Any(), "domain").Return(nil, nil)
+			},
+			err: ErrNotFound,
+		},
+		{
+			name:    "when success",
+			varName: "domain",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().List(gomock.Any(), "domain").Return([]model.Variable{{Version: 1}, {Version: 2}}, nil)
+			},
+			count: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo}
+
+			got, err := svc.ListVersions(context.Background(), tc.varName)
+			assert.Equal(t, tc.err, err)
+			assert.Len(t, got, tc.count)
+		})
+	}
+}
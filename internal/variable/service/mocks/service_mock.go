@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/variable/service/service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "configuration-management-service/internal/variable/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIService is a mock of IService interface.
+type MockIService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceMockRecorder
+}
+
+// MockIServiceMockRecorder is the mock recorder for MockIService.
+type MockIServiceMockRecorder struct {
+	mock *MockIService
+}
+
+// NewMockIService creates a new mock instance.
+func NewMockIService(ctrl *gomock.Controller) *MockIService {
+	mock := &MockIService{ctrl: ctrl}
+	mock.recorder = &MockIServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIService) EXPECT() *MockIServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIService) Get(ctx context.Context, name string) (model.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, name)
+	ret0, _ := ret[0].(model.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIServiceMockRecorder) Get(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIService)(nil).Get), ctx, name)
+}
+
+// LatestAll mocks base method.
+func (m *MockIService) LatestAll(ctx context.Context) ([]model.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestAll", ctx)
+	ret0, _ := ret[0].([]model.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestAll indicates an expected call of LatestAll.
+func (mr *MockIServiceMockRecorder) LatestAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestAll", reflect.TypeOf((*MockIService)(nil).LatestAll), ctx)
+}
+
+// ListVersions mocks base method.
+func (m *MockIService) ListVersions(ctx context.Context, name string) ([]model.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVersions", ctx, name)
+	ret0, _ := ret[0].([]model.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVersions indicates an expected call of ListVersions.
+func (mr *MockIServiceMockRecorder) ListVersions(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersions", reflect.TypeOf((*MockIService)(nil).ListVersions), ctx, name)
+}
+
+// Put mocks base method.
+func (m *MockIService) Put(ctx context.Context, name, value string) (model.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, name, value)
+	ret0, _ := ret[0].(model.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockIServiceMockRecorder) Put(ctx, name, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockIService)(nil).Put), ctx, name, value)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"configuration-management-service/internal/variable/model"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (s service) Put(ctx context.Context, name, value string) (model.Variable, error) {
+	name = strings.TrimSpace(name)
+	if !namePattern.MatchString(name) {
+		return model.Variable{}, fmt.Errorf("%w: name must match %s", ErrInvalidInput, namePattern.String())
+	}
+	return s.repo.Put(ctx, name, value)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"configuration-management-service/internal/variable/model"
+	repoMock "configuration-management-service/internal/variable/repository/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_Put(t *testing.T) {
+	cases := []struct {
+		name     string
+		varName  string
+		mockFunc func(m *repoMock.MockIRepo)
+		res      model.Variable
+		err      error
+	}{
+		{
+			name:     "when name is not a template identifier should return ErrInvalidInput",
+			varName:  "my-domain",
+			mockFunc: func(m *repoMock.MockIRepo) {},
+			err:      ErrInvalidInput,
+		},
+		{
+			name:    "when success should store next version",
+			varName: " domain ",
+			mockFunc: func(m *repoMock.MockIRepo) {
+				m.EXPECT().Put(gomock.Any(), "domain", "example.com").
+					Return(model.Variable{Name: "domain", Version: 1, Value: "example.com"}, nil)
+			},
+			res: model.Variable{Name: "domain", Version: 1, Value: "example.com"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repoMock.NewMockIRepo(ctrl)
+			tc.mockFunc(repo)
+			svc := service{repo: repo}
+
+			got, err := svc.Put(context.Background(), tc.varName, "example.com")
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, got)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"configuration-management-service/internal/variable/model"
+	"configuration-management-service/internal/variable/repository"
+	"context"
+	"errors"
+	"regexp"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+// namePattern keeps names usable as template fields: {{ .vars.<name> }}.
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type IService interface {
+	Put(ctx context.Context, name, value string) (model.Variable, error)
+	Get(ctx context.Context, name string) (model.Variable, error)
+	ListVersions(ctx context.Context, name string) ([]model.Variable, error)
+	LatestAll(ctx context.Context) ([]model.Variable, error)
+}
+
+type service struct {
+	repo repository.IRepo
+}
+
+func NewService(repo repository.IRepo) IService {
+	return service{repo: repo}
+}
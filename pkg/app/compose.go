@@ -2,11 +2,30 @@ package app
 
 import (
 	"configuration-management-service/db"
+	"configuration-management-service/internal/evaluation"
+	"configuration-management-service/internal/evaluation/events"
+	evaluationModel "configuration-management-service/internal/evaluation/model"
+	evaluationService "configuration-management-service/internal/evaluation/service"
+	"configuration-management-service/internal/migration"
+	migrationModel "configuration-management-service/internal/migration/model"
+	migrationService "configuration-management-service/internal/migration/service"
 	"configuration-management-service/internal/remote_config"
+	remoteConfigModel "configuration-management-service/internal/remote_config/model"
+	"configuration-management-service/internal/remote_config/render"
+	remoteConfigService "configuration-management-service/internal/remote_config/service"
+	"configuration-management-service/internal/remote_config/validator"
+	"configuration-management-service/internal/schema"
+	schemaModel "configuration-management-service/internal/schema/model"
+	schemaService "configuration-management-service/internal/schema/service"
+	"configuration-management-service/internal/variable"
+	variableService "configuration-management-service/internal/variable/service"
 	"configuration-management-service/pkg/auth"
 	"configuration-management-service/pkg/config"
 	"configuration-management-service/pkg/httpx"
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -31,10 +50,196 @@ func BuildServer() (*echo.Echo, func(ctx context.Context) error, error) {
 	writeLimit := httpx.WriteBodyLimiter(1 << 20)
 
 	e.GET("/healthz", httpx.HealthHandler(cfg.Service, cfg.Version, sqlDB))
-	api := e.Group("/api", auth.StaticKeyMiddleware(cfg.StaticKey))
+	api := e.Group("/api", auth.StaticKeyMiddleware(cfg.StaticKey, cfg.RevealKey))
 
-	remoteConfigModule := remote_config.InitModule(sqlDB)
+	// The registry checks schema changes against stored configs, but the
+	// config module validates against the registry, so bind it late.
+	var configs remoteConfigService.IService
+	var remoteConfigModule remote_config.IModule
+	schemaModule, err := schema.InitModule(context.Background(), sqlDB,
+		storedConfigs(func() remoteConfigService.IService { return configs }),
+		schemaChanges(func() remote_config.IModule { return remoteConfigModule }))
+	if err != nil {
+		return nil, nil, err
+	}
+	schemaModule.RegisterRoute(api, writeLimit)
+
+	variableModule := variable.InitModule(sqlDB)
+	variableModule.RegisterRoute(api, writeLimit)
+
+	remoteConfigModule, err = remote_config.InitModule(sqlDB, cfg, variableSource(variableModule.Service()), SchemaSource(schemaModule.Service()))
+	if err != nil {
+		return nil, nil, err
+	}
+	configs = remoteConfigModule.Service()
 	remoteConfigModule.RegisterRoute(api, writeLimit)
 
-	return e, e.Shutdown, nil
+	// Changed built-in schemas and custom types load once configs are
+	// bound, so they are checked against the stored configs like any other
+	// new version.
+	if err := schemaModule.UpgradeBuiltins(context.Background()); err != nil {
+		return nil, nil, err
+	}
+	if cfg.SchemaDir != "" {
+		if err := schemaModule.LoadDir(context.Background(), cfg.SchemaDir, cfg.SchemaDirStrict); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	migrationModule := migration.InitModule(sqlDB, configMigrator(configs))
+	migrationModule.RegisterRoute(api, writeLimit)
+
+	recorder, err := eventRecorder(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	evaluationModule := evaluation.InitModule(evaluatedConfigs{srv: configs}, recorder)
+	evaluationModule.RegisterRoute(api, writeLimit)
+
+	shutdown := func(ctx context.Context) error {
+		// Stop serving first so no evaluation records after the last flush.
+		return errors.Join(e.Shutdown(ctx), recorder.Close(ctx))
+	}
+	return e, shutdown, nil
+}
+
+// eventRecorder records evaluations to the NDJSON log in cfg.EventsDir, or
+// only counts them when no directory is set. Without a hash key the log is
+// turned off too: hashed with a known key, user keys could be recovered by
+// hashing candidates.
+func eventRecorder(cfg config.App) (*events.Recorder, error) {
+	var sink events.EventSink = events.NopSink{}
+	switch {
+	case cfg.EventsDir == "":
+	case cfg.EventsHashKey == "":
+		log.Printf("events: EVENTS_HASH_KEY is not set, keeping counters only; set it to write the event log to %s", cfg.EventsDir)
+	default:
+		s, err := events.NewNDJSONSink(cfg.EventsDir, cfg.EventsMaxBytes, cfg.EventsMaxFiles)
+		if err != nil {
+			return nil, err
+		}
+		sink = s
+	}
+	return events.NewRecorder(sink, events.Options{HashKey: []byte(cfg.EventsHashKey)}), nil
+}
+
+// variableSource feeds the latest variables into config rendering.
+func variableSource(srv variableService.IService) render.VariableSource {
+	return render.SourceFunc(func(ctx context.Context) (map[string]render.Value, error) {
+		vars, err := srv.LatestAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out := make(map[string]render.Value, len(vars))
+		for _, v := range vars {
+			out[v.Name] = render.Value{Version: v.Version, Value: v.Value}
+		}
+		return out, nil
+	})
+}
+
+// storedConfigs feeds the effective latest configs of a type into schema
+// compatibility checks. srv returns nil until the config module is built.
+func storedConfigs(srv func() remoteConfigService.IService) schemaService.ConfigSource {
+	return schemaService.ConfigSourceFunc(func(ctx context.Context, schemaType string) ([]schemaModel.StoredConfig, error) {
+		s := srv()
+		if s == nil {
+			return nil, nil
+		}
+		cfgs, err := s.EffectiveByType(ctx, schemaType)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]schemaModel.StoredConfig, 0, len(cfgs))
+		for _, c := range cfgs {
+			out = append(out, schemaModel.StoredConfig{Name: c.Name, Version: c.Version, Data: c.Data})
+		}
+		return out, nil
+	})
+}
+
+// schemaChanges drops the cached schema of a type from config validation
+// when a new version is registered. m returns nil until the config module
+// is built, when nothing is cached yet.
+func schemaChanges(m func() remote_config.IModule) schemaService.ChangeListener {
+	return schemaService.ChangeListenerFunc(func(schemaType string) {
+		if mod := m(); mod != nil {
+			mod.SchemaChanged(schemaType)
+		}
+	})
+}
+
+// configMigrator runs data migrations over the stored configs.
+func configMigrator(srv remoteConfigService.IService) migrationService.ConfigMigrator {
+	return migrationService.ConfigMigratorFunc(func(ctx context.Context, schemaType, migrationID string, fn migrationModel.TransformFunc, dryRun bool) ([]migrationModel.ConfigResult, error) {
+		res, err := srv.Migrate(ctx, schemaType, migrationID, remoteConfigModel.MigrateFunc(fn), dryRun)
+		var out []migrationModel.ConfigResult
+		if res != nil {
+			out = make([]migrationModel.ConfigResult, 0, len(res))
+			for _, r := range res {
+				out = append(out, migrationModel.ConfigResult(r))
+			}
+		}
+		switch {
+		case err == nil:
+			return out, nil
+		case errors.Is(err, remoteConfigService.ErrInvalidInput):
+			return out, fmt.Errorf("%w: %s", migrationService.ErrInvalidInput, err.Error())
+		case errors.Is(err, remoteConfigService.ErrAlreadyExists):
+			return nil, fmt.Errorf("%w: %s", migrationService.ErrAlreadyApplied, migrationID)
+		case errors.Is(err, remoteConfigService.ErrConflict):
+			return nil, fmt.Errorf("%w, run it again", migrationService.ErrConflict)
+		}
+		return nil, err
+	})
+}
+
+// evaluatedConfigs feeds the latest resolved configs into evaluation.
+type evaluatedConfigs struct {
+	srv remoteConfigService.IService
+}
+
+func (e evaluatedConfigs) Latest(ctx context.Context, name string) (evaluationModel.StoredConfig, error) {
+	cfg, err := e.srv.Get(ctx, name, nil, remoteConfigModel.ReadOptions{})
+	if err != nil {
+		if errors.Is(err, remoteConfigService.ErrNotFound) {
+			return evaluationModel.StoredConfig{}, fmt.Errorf("%w: %s", evaluationService.ErrNotFound, name)
+		}
+		return evaluationModel.StoredConfig{}, err
+	}
+	return storedConfig(cfg), nil
+}
+
+func (e evaluatedConfigs) Snapshot(ctx context.Context, types []string) ([]evaluationModel.StoredConfig, error) {
+	cfgs, err := e.srv.Snapshot(ctx, types)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]evaluationModel.StoredConfig, 0, len(cfgs))
+	for _, c := range cfgs {
+		out = append(out, storedConfig(c))
+	}
+	return out, nil
+}
+
+func storedConfig(c remoteConfigModel.RemoteConfig) evaluationModel.StoredConfig {
+	return evaluationModel.StoredConfig{Name: c.Name, Type: c.Type, Version: c.Version, Data: c.Data, Variables: c.Variables}
+}
+
+// SchemaSource validates configs against the schema registry.
+func SchemaSource(srv schemaService.IService) validator.SchemaSource {
+	return validator.SourceFunc(func(ctx context.Context, schemaType string, version int) (validator.Schema, error) {
+		var v *int
+		if version > 0 {
+			v = &version
+		}
+		res, err := srv.Get(ctx, schemaType, v)
+		if err != nil {
+			if errors.Is(err, schemaService.ErrNotFound) || errors.Is(err, schemaService.ErrInvalidInput) {
+				return validator.Schema{}, fmt.Errorf("%w: %s", validator.ErrUnknownType, schemaType)
+			}
+			return validator.Schema{}, err
+		}
+		return validator.Schema{Version: res.Version, Body: res.Schema}, nil
+	})
 }
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -8,14 +9,50 @@ import (
 
 const HeaderAPIKey = "X-Api-Key"
 
-func StaticKeyMiddleware(expectedKey string) echo.MiddlewareFunc {
+// Permission is an extra capability granted on top of plain API access.
+type Permission string
+
+// PermReveal allows reading decrypted secret fields.
+const PermReveal Permission = "reveal"
+
+type permsKey struct{}
+
+// StaticKeyMiddleware accepts the expected key, or the reveal key when set,
+// which additionally grants PermReveal on the request context.
+func StaticKeyMiddleware(expectedKey string, revealKey string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			key := c.Request().Header.Get(HeaderAPIKey)
-			if key == "" || key != expectedKey {
+			switch {
+			case key == "":
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid or missing API key")
+			case revealKey != "" && key == revealKey:
+				req := c.Request()
+				c.SetRequest(req.WithContext(WithPermissions(req.Context(), PermReveal)))
+			case key != expectedKey:
 				return echo.NewHTTPError(http.StatusUnauthorized, "invalid or missing API key")
 			}
 			return next(c)
 		}
 	}
 }
+
+// WithPermissions returns a context carrying the given permissions.
+func WithPermissions(ctx context.Context, perms ...Permission) context.Context {
+	set := map[Permission]bool{}
+	if prev, ok := ctx.Value(permsKey{}).(map[Permission]bool); ok {
+		for p := range prev {
+			set[p] = true
+		}
+	}
+	for _, p := range perms {
+		set[p] = true
+	}
+	return context.WithValue(ctx, permsKey{}, set)
+}
+
+// HasPermission reports whether the request context was granted perm.
+func HasPermission(ctx context.Context, perm Permission) bool {
+	set, _ := ctx.Value(permsKey{}).(map[Permission]bool)
+	return set[perm]
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strconv"
 )
 
 type App struct {
@@ -9,6 +10,22 @@ type App struct {
 	Service   string
 	Version   string
 	StaticKey string
+	RevealKey string
+
+	KeyringFile   string // path to a JSON keyring file
+	KeyringKeys   string // "id:base64key,..." used when no file is given
+	KeyringActive string // overrides the active key id
+
+	SecretsDir       string // directory served by the file secret provider
+	SecretsEnvPrefix string // env var prefix served by the env secret provider
+
+	SchemaDir       string // directory of custom <type>.json schemas loaded at startup
+	SchemaDirStrict bool   // fail startup on a bad custom schema instead of skipping it
+
+	EventsDir      string // directory of the evaluation event log; empty keeps no log
+	EventsMaxBytes int64  // size at which the event log rotates
+	EventsMaxFiles int    // rotated event log files kept
+	EventsHashKey  string // HMAC key for user keys in evaluation events
 }
 
 func Load() App {
@@ -16,6 +33,26 @@ func Load() App {
 	if dsn == "" {
 		dsn = "file:./data/configs.db?_pragma=busy_timeout=5000&_pragma=journal_mode=WAL"
 	}
+	secretsPrefix, ok := os.LookupEnv("SECRETS_ENV_PREFIX")
+	if !ok {
+		secretsPrefix = "SECRET_"
+	}
+	schemaDirStrict := true
+	if v, err := strconv.ParseBool(os.Getenv("SCHEMA_DIR_STRICT")); err == nil {
+		schemaDirStrict = v
+	}
+	eventsDir, ok := os.LookupEnv("EVENTS_DIR")
+	if !ok {
+		eventsDir = "./data/events"
+	}
+	eventsMaxMB := 64
+	if v, err := strconv.Atoi(os.Getenv("EVENTS_MAX_FILE_MB")); err == nil && v > 0 {
+		eventsMaxMB = v
+	}
+	eventsMaxFiles := 10
+	if v, err := strconv.Atoi(os.Getenv("EVENTS_MAX_FILES")); err == nil && v >= 0 {
+		eventsMaxFiles = v
+	}
 	staticKey := os.Getenv("S2S_STATIC_KEY")
 	if staticKey == "" {
 		staticKey = "super-secret-123"
@@ -25,5 +62,21 @@ func Load() App {
 		Service:   os.Getenv("SERVICE_NAME"),
 		Version:   os.Getenv("SERVICE_VERSION"),
 		StaticKey: staticKey,
+		RevealKey: os.Getenv("S2S_REVEAL_KEY"),
+
+		KeyringFile:   os.Getenv("ENCRYPTION_KEYRING_FILE"),
+		KeyringKeys:   os.Getenv("ENCRYPTION_KEYS"),
+		KeyringActive: os.Getenv("ENCRYPTION_ACTIVE_KEY"),
+
+		SecretsDir:       os.Getenv("SECRETS_DIR"),
+		SecretsEnvPrefix: secretsPrefix,
+
+		SchemaDir:       os.Getenv("SCHEMA_DIR"),
+		SchemaDirStrict: schemaDirStrict,
+
+		EventsDir:      eventsDir,
+		EventsMaxBytes: int64(eventsMaxMB) << 20,
+		EventsMaxFiles: eventsMaxFiles,
+		EventsHashKey:  os.Getenv("EVENTS_HASH_KEY"),
 	}
 }
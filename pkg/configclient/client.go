@@ -0,0 +1,130 @@
+// Package configclient reads configs over the HTTP API.
+//
+// types_gen.go holds a Go type for every built-in config type, generated
+// from the same schemas the service validates against, and typed getters
+// such as GetFeatureToggle. Regenerate it with `go run ./cmd/typegen`;
+// types of custom config types can be generated into a package of their
+// own with its -api flag.
+package configclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrNotFound is matched by an APIError with status 404.
+	ErrNotFound = errors.New("config not found")
+	// ErrTypeMismatch is returned when a typed getter reads a config of
+	// another type.
+	ErrTypeMismatch = errors.New("config type mismatch")
+	// ErrInvalid wraps the errors of a config that fails Validate.
+	ErrInvalid = errors.New("config data is invalid")
+)
+
+// Config is a config version as the API returns it.
+type Config struct {
+	Name          string          `json:"name"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	Data          json.RawMessage `json:"data"`
+	CreatedAt     string          `json:"created_at"`
+	Extends       string          `json:"extends,omitempty"`
+	SchemaVersion int             `json:"schema_version,omitempty"`
+	Defaulted     []string        `json:"defaulted,omitempty"`
+}
+
+// Validator is implemented by every generated config type.
+type Validator interface {
+	Validate() error
+}
+
+// APIError is a non-2xx response of the API.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Details    json.RawMessage
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("configclient: %d %s", e.StatusCode, e.Message)
+}
+
+// Is matches ErrNotFound for 404 responses.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+type Client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+// NewClient returns a client of the service at baseURL (e.g.
+// http://localhost:8080) that authenticates with apiKey. A nil httpClient
+// uses http.DefaultClient.
+func NewClient(baseURL, apiKey string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), apiKey: apiKey, http: httpClient}
+}
+
+// Get reads the resolved latest version of the config name.
+func (c *Client) Get(ctx context.Context, name string) (Config, error) {
+	var cfg Config
+	err := c.do(ctx, "/api/configs/"+url.PathEscape(name), &cfg)
+	return cfg, err
+}
+
+// GetAs reads the config name, checks that it has type schemaType and
+// decodes and validates its data into out.
+func (c *Client) GetAs(ctx context.Context, schemaType, name string, out Validator) error {
+	cfg, err := c.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if cfg.Type != schemaType {
+		return fmt.Errorf("%w: %s is a %s, not a %s", ErrTypeMismatch, name, cfg.Type, schemaType)
+	}
+	if err := json.Unmarshal(cfg.Data, out); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalid, name, err)
+	}
+	if err := out.Validate(); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalid, name, err)
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Api-Key", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		var envelope struct {
+			Error struct {
+				Message string          `json:"message"`
+				Details json.RawMessage `json:"details"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(res.Body).Decode(&envelope)
+		return &APIError{StatusCode: res.StatusCode, Message: envelope.Error.Message, Details: envelope.Error.Details}
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
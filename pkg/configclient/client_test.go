@@ -0,0 +1,84 @@
+package configclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetFeatureToggle(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		want   FeatureToggle
+		errIs  error
+		errMsg string
+	}{
+		{
+			name:   "when config has the type should decode and validate it",
+			status: http.StatusOK,
+			body:   `{"name":"checkout","type":"feature_toggle","version":3,"data":{"enabled":true,"rollout_percentage":25}}`,
+			want:   FeatureToggle{Enabled: true, RolloutPercentage: ptr(25)},
+		},
+		{
+			name:   "when config has another type should return ErrTypeMismatch",
+			status: http.StatusOK,
+			body:   `{"name":"checkout","type":"rate_limit_policy","version":1,"data":{}}`,
+			errIs:  ErrTypeMismatch,
+			errMsg: "config type mismatch: checkout is a rate_limit_policy, not a feature_toggle",
+		},
+		{
+			name:   "when data fails Validate should return ErrInvalid with every violation",
+			status: http.StatusOK,
+			body:   `{"name":"checkout","type":"feature_toggle","version":1,"data":{"enabled":true,"rollout_percentage":120,"tags":["a","a"]}}`,
+			errIs:  ErrInvalid,
+			errMsg: "config data is invalid: checkout: /rollout_percentage: must be <= 100\n/tags: items must be unique",
+		},
+		{
+			name:   "when data does not decode should return ErrInvalid",
+			status: http.StatusOK,
+			body:   `{"name":"checkout","type":"feature_toggle","version":1,"data":{"enabled":"yes"}}`,
+			errIs:  ErrInvalid,
+		},
+		{
+			name:   "when config is missing should return APIError matching ErrNotFound",
+			status: http.StatusNotFound,
+			body:   `{"error":{"code":"Not Found","message":"config not found","details":null}}`,
+			errIs:  ErrNotFound,
+			errMsg: "configclient: 404 config not found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/configs/checkout", r.URL.Path)
+				assert.Equal(t, "k1", r.Header.Get("X-Api-Key"))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			got, err := NewClient(srv.URL+"/", "k1", nil).GetFeatureToggle(context.Background(), "checkout")
+			if tc.errIs != nil {
+				assert.True(t, errors.Is(err, tc.errIs), "%v", err)
+				if tc.errMsg != "" {
+					assert.EqualError(t, err, tc.errMsg)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
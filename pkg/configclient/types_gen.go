@@ -0,0 +1,750 @@
+// Code generated by go run ./cmd/typegen from the built-in schemas; DO NOT EDIT.
+
+package configclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// Config types with generated data types.
+const (
+	TypeExperimentConfig   = "experiment_config"
+	TypeFeatureToggle      = "feature_toggle"
+	TypeNotificationPolicy = "notification_policy"
+	TypeRateLimitPolicy    = "rate_limit_policy"
+	TypeScheduleRule       = "schedule_rule"
+	TypeSegment            = "segment"
+	TypeServiceClient      = "service_client"
+	TypeThresholdPolicy    = "threshold_policy"
+)
+
+// ExperimentConfigAudienceOS is one of the values the schema allows.
+type ExperimentConfigAudienceOS string
+
+// Values of ExperimentConfigAudienceOS.
+const (
+	ExperimentConfigAudienceOSIos     ExperimentConfigAudienceOS = "ios"
+	ExperimentConfigAudienceOSAndroid ExperimentConfigAudienceOS = "android"
+	ExperimentConfigAudienceOSWeb     ExperimentConfigAudienceOS = "web"
+)
+
+// Valid reports whether v is one of the values of ExperimentConfigAudienceOS.
+func (v ExperimentConfigAudienceOS) Valid() bool {
+	switch v {
+	case ExperimentConfigAudienceOSIos, ExperimentConfigAudienceOSAndroid, ExperimentConfigAudienceOSWeb:
+		return true
+	}
+	return false
+}
+
+// ExperimentConfigAudience is a nested object of the config data.
+type ExperimentConfigAudience struct {
+	Countries     []string                     `json:"countries,omitempty"`
+	MinAppVersion *string                      `json:"min_app_version,omitempty"`
+	OS            []ExperimentConfigAudienceOS `json:"os,omitempty"`
+	Segments      []string                     `json:"segments,omitempty"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v ExperimentConfigAudience) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v ExperimentConfigAudience) validate(path string) []error {
+	var errs []error
+	if duplicate(v.Countries) {
+		errs = append(errs, fmt.Errorf("%s: items must be unique", path+"/countries"))
+	}
+	if duplicate(v.OS) {
+		errs = append(errs, fmt.Errorf("%s: items must be unique", path+"/os"))
+	}
+	for i0, e0 := range v.OS {
+		if !e0.Valid() {
+			errs = append(errs, fmt.Errorf("%s: %q is not one of the allowed values", path+"/os/"+strconv.Itoa(i0), e0))
+		}
+	}
+	if duplicate(v.Segments) {
+		errs = append(errs, fmt.Errorf("%s: items must be unique", path+"/segments"))
+	}
+	for i0, e0 := range v.Segments {
+		if utf8.RuneCountInString(e0) < 1 {
+			errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/segments/"+strconv.Itoa(i0)))
+		}
+	}
+	return errs
+}
+
+// ExperimentConfigLayer is a nested object of the config data.
+type ExperimentConfigLayer struct {
+	End   float64 `json:"end"`
+	Name  string  `json:"name"`
+	Start float64 `json:"start"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v ExperimentConfigLayer) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v ExperimentConfigLayer) validate(path string) []error {
+	var errs []error
+	if v.End < 0 {
+		errs = append(errs, fmt.Errorf("%s: must be >= 0", path+"/end"))
+	}
+	if v.End > 100 {
+		errs = append(errs, fmt.Errorf("%s: must be <= 100", path+"/end"))
+	}
+	if utf8.RuneCountInString(v.Name) < 1 {
+		errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/name"))
+	}
+	if v.Start < 0 {
+		errs = append(errs, fmt.Errorf("%s: must be >= 0", path+"/start"))
+	}
+	if v.Start > 100 {
+		errs = append(errs, fmt.Errorf("%s: must be <= 100", path+"/start"))
+	}
+	return errs
+}
+
+// ExperimentConfigVariantsItem is a nested object of the config data.
+type ExperimentConfigVariantsItem struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v ExperimentConfigVariantsItem) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v ExperimentConfigVariantsItem) validate(path string) []error {
+	var errs []error
+	if utf8.RuneCountInString(v.Name) < 1 {
+		errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/name"))
+	}
+	if v.Weight < 0 {
+		errs = append(errs, fmt.Errorf("%s: must be >= 0", path+"/weight"))
+	}
+	return errs
+}
+
+// ExperimentConfig is the data of experiment_config configs.
+type ExperimentConfig struct {
+	Active        bool                           `json:"active"`
+	Audience      *ExperimentConfigAudience      `json:"audience,omitempty"`
+	Description   *string                        `json:"description,omitempty"`
+	ExperimentKey string                         `json:"experiment_key"`
+	Holdout       *bool                          `json:"holdout,omitempty"`
+	Layer         *ExperimentConfigLayer         `json:"layer,omitempty"`
+	Salt          *string                        `json:"salt,omitempty"`
+	Tags          []string                       `json:"tags,omitempty"`
+	Variants      []ExperimentConfigVariantsItem `json:"variants"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v ExperimentConfig) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v ExperimentConfig) validate(path string) []error {
+	var errs []error
+	if v.Audience != nil {
+		errs = append(errs, v.Audience.validate(path+"/audience")...)
+	}
+	if utf8.RuneCountInString(v.ExperimentKey) < 1 {
+		errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/experiment_key"))
+	}
+	if v.Layer != nil {
+		errs = append(errs, v.Layer.validate(path+"/layer")...)
+	}
+	if v.Salt != nil {
+		if utf8.RuneCountInString(*v.Salt) < 1 {
+			errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/salt"))
+		}
+	}
+	if duplicate(v.Tags) {
+		errs = append(errs, fmt.Errorf("%s: items must be unique", path+"/tags"))
+	}
+	if len(v.Variants) < 2 {
+		errs = append(errs, fmt.Errorf("%s: must have >= 2 items", path+"/variants"))
+	}
+	for i0, e0 := range v.Variants {
+		errs = append(errs, e0.validate(path+"/variants/"+strconv.Itoa(i0))...)
+	}
+	return errs
+}
+
+// FeatureToggleRulesItemClausesItemOp is one of the values the schema allows.
+type FeatureToggleRulesItemClausesItemOp string
+
+// Values of FeatureToggleRulesItemClausesItemOp.
+const (
+	FeatureToggleRulesItemClausesItemOpIn         FeatureToggleRulesItemClausesItemOp = "in"
+	FeatureToggleRulesItemClausesItemOpNotIn      FeatureToggleRulesItemClausesItemOp = "not_in"
+	FeatureToggleRulesItemClausesItemOpStartsWith FeatureToggleRulesItemClausesItemOp = "starts_with"
+	FeatureToggleRulesItemClausesItemOpMatches    FeatureToggleRulesItemClausesItemOp = "matches"
+	FeatureToggleRulesItemClausesItemOpSemverEq   FeatureToggleRulesItemClausesItemOp = "semver_eq"
+	FeatureToggleRulesItemClausesItemOpSemverLt   FeatureToggleRulesItemClausesItemOp = "semver_lt"
+	FeatureToggleRulesItemClausesItemOpSemverLte  FeatureToggleRulesItemClausesItemOp = "semver_lte"
+	FeatureToggleRulesItemClausesItemOpSemverGt   FeatureToggleRulesItemClausesItemOp = "semver_gt"
+	FeatureToggleRulesItemClausesItemOpSemverGte  FeatureToggleRulesItemClausesItemOp = "semver_gte"
+	FeatureToggleRulesItemClausesItemOpLt         FeatureToggleRulesItemClausesItemOp = "lt"
+	FeatureToggleRulesItemClausesItemOpLte        FeatureToggleRulesItemClausesItemOp = "lte"
+	FeatureToggleRulesItemClausesItemOpGt         FeatureToggleRulesItemClausesItemOp = "gt"
+	FeatureToggleRulesItemClausesItemOpGte        FeatureToggleRulesItemClausesItemOp = "gte"
+	FeatureToggleRulesItemClausesItemOpBefore     FeatureToggleRulesItemClausesItemOp = "before"
+	FeatureToggleRulesItemClausesItemOpAfter      FeatureToggleRulesItemClausesItemOp = "after"
+)
+
+// Valid reports whether v is one of the values of FeatureToggleRulesItemClausesItemOp.
+func (v FeatureToggleRulesItemClausesItemOp) Valid() bool {
+	switch v {
+	case FeatureToggleRulesItemClausesItemOpIn, FeatureToggleRulesItemClausesItemOpNotIn, FeatureToggleRulesItemClausesItemOpStartsWith, FeatureToggleRulesItemClausesItemOpMatches, FeatureToggleRulesItemClausesItemOpSemverEq, FeatureToggleRulesItemClausesItemOpSemverLt, FeatureToggleRulesItemClausesItemOpSemverLte, FeatureToggleRulesItemClausesItemOpSemverGt, FeatureToggleRulesItemClausesItemOpSemverGte, FeatureToggleRulesItemClausesItemOpLt, FeatureToggleRulesItemClausesItemOpLte, FeatureToggleRulesItemClausesItemOpGt, FeatureToggleRulesItemClausesItemOpGte, FeatureToggleRulesItemClausesItemOpBefore, FeatureToggleRulesItemClausesItemOpAfter:
+		return true
+	}
+	return false
+}
+
+// FeatureToggleRulesItemClausesItem is a nested object of the config data.
+type FeatureToggleRulesItemClausesItem struct {
+	Attribute string                              `json:"attribute"`
+	Op        FeatureToggleRulesItemClausesItemOp `json:"op"`
+	Values    []any                               `json:"values"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v FeatureToggleRulesItemClausesItem) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v FeatureToggleRulesItemClausesItem) validate(path string) []error {
+	var errs []error
+	if utf8.RuneCountInString(v.Attribute) < 1 {
+		errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/attribute"))
+	}
+	if !v.Op.Valid() {
+		errs = append(errs, fmt.Errorf("%s: %q is not one of the allowed values", path+"/op", v.Op))
+	}
+	if len(v.Values) < 1 {
+		errs = append(errs, fmt.Errorf("%s: must have >= 1 items", path+"/values"))
+	}
+	return errs
+}
+
+// FeatureToggleRulesItem is a nested object of the config data.
+type FeatureToggleRulesItem struct {
+	Clauses           []FeatureToggleRulesItemClausesItem `json:"clauses,omitempty"`
+	Description       *string                             `json:"description,omitempty"`
+	RolloutPercentage *int                                `json:"rollout_percentage,omitempty"`
+	Segments          []string                            `json:"segments,omitempty"`
+	Serve             *bool                               `json:"serve,omitempty"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v FeatureToggleRulesItem) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v FeatureToggleRulesItem) validate(path string) []error {
+	var errs []error
+	if v.Clauses != nil {
+		if len(v.Clauses) < 1 {
+			errs = append(errs, fmt.Errorf("%s: must have >= 1 items", path+"/clauses"))
+		}
+		for i0, e0 := range v.Clauses {
+			errs = append(errs, e0.validate(path+"/clauses/"+strconv.Itoa(i0))...)
+		}
+	}
+	if v.RolloutPercentage != nil {
+		if *v.RolloutPercentage < 0 {
+			errs = append(errs, fmt.Errorf("%s: must be >= 0", path+"/rollout_percentage"))
+		}
+		if *v.RolloutPercentage > 100 {
+			errs = append(errs, fmt.Errorf("%s: must be <= 100", path+"/rollout_percentage"))
+		}
+	}
+	if duplicate(v.Segments) {
+		errs = append(errs, fmt.Errorf("%s: items must be unique", path+"/segments"))
+	}
+	for i0, e0 := range v.Segments {
+		if utf8.RuneCountInString(e0) < 1 {
+			errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/segments/"+strconv.Itoa(i0)))
+		}
+	}
+	return errs
+}
+
+// FeatureToggle is the data of feature_toggle configs.
+type FeatureToggle struct {
+	Description       *string                  `json:"description,omitempty"`
+	Enabled           bool                     `json:"enabled"`
+	RolloutPercentage *int                     `json:"rollout_percentage,omitempty"`
+	Rules             []FeatureToggleRulesItem `json:"rules,omitempty"`
+	Tags              []string                 `json:"tags,omitempty"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v FeatureToggle) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v FeatureToggle) validate(path string) []error {
+	var errs []error
+	if v.RolloutPercentage != nil {
+		if *v.RolloutPercentage < 0 {
+			errs = append(errs, fmt.Errorf("%s: must be >= 0", path+"/rollout_percentage"))
+		}
+		if *v.RolloutPercentage > 100 {
+			errs = append(errs, fmt.Errorf("%s: must be <= 100", path+"/rollout_percentage"))
+		}
+	}
+	for i0, e0 := range v.Rules {
+		errs = append(errs, e0.validate(path+"/rules/"+strconv.Itoa(i0))...)
+	}
+	if duplicate(v.Tags) {
+		errs = append(errs, fmt.Errorf("%s: items must be unique", path+"/tags"))
+	}
+	return errs
+}
+
+// NotificationPolicyChannel is one of the values the schema allows.
+type NotificationPolicyChannel string
+
+// Values of NotificationPolicyChannel.
+const (
+	NotificationPolicyChannelEmail NotificationPolicyChannel = "email"
+	NotificationPolicyChannelSMS   NotificationPolicyChannel = "sms"
+	NotificationPolicyChannelPush  NotificationPolicyChannel = "push"
+)
+
+// Valid reports whether v is one of the values of NotificationPolicyChannel.
+func (v NotificationPolicyChannel) Valid() bool {
+	switch v {
+	case NotificationPolicyChannelEmail, NotificationPolicyChannelSMS, NotificationPolicyChannelPush:
+		return true
+	}
+	return false
+}
+
+// NotificationPolicy is the data of notification_policy configs.
+type NotificationPolicy struct {
+	Channel      NotificationPolicyChannel `json:"channel"`
+	DailyLimit   *int                      `json:"daily_limit,omitempty"`
+	Description  *string                   `json:"description,omitempty"`
+	Enabled      bool                      `json:"enabled"`
+	Placeholders []string                  `json:"placeholders,omitempty"`
+	TemplateID   *string                   `json:"template_id,omitempty"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v NotificationPolicy) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v NotificationPolicy) validate(path string) []error {
+	var errs []error
+	if !v.Channel.Valid() {
+		errs = append(errs, fmt.Errorf("%s: %q is not one of the allowed values", path+"/channel", v.Channel))
+	}
+	if v.DailyLimit != nil {
+		if *v.DailyLimit < 0 {
+			errs = append(errs, fmt.Errorf("%s: must be >= 0", path+"/daily_limit"))
+		}
+	}
+	if duplicate(v.Placeholders) {
+		errs = append(errs, fmt.Errorf("%s: items must be unique", path+"/placeholders"))
+	}
+	for i0, e0 := range v.Placeholders {
+		if utf8.RuneCountInString(e0) < 1 {
+			errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/placeholders/"+strconv.Itoa(i0)))
+		}
+	}
+	return errs
+}
+
+// RateLimitPolicyIdentifierType is one of the values the schema allows.
+type RateLimitPolicyIdentifierType string
+
+// Values of RateLimitPolicyIdentifierType.
+const (
+	RateLimitPolicyIdentifierTypeIP     RateLimitPolicyIdentifierType = "ip"
+	RateLimitPolicyIdentifierTypeUser   RateLimitPolicyIdentifierType = "user"
+	RateLimitPolicyIdentifierTypeAPIKey RateLimitPolicyIdentifierType = "api_key"
+)
+
+// Valid reports whether v is one of the values of RateLimitPolicyIdentifierType.
+func (v RateLimitPolicyIdentifierType) Valid() bool {
+	switch v {
+	case RateLimitPolicyIdentifierTypeIP, RateLimitPolicyIdentifierTypeUser, RateLimitPolicyIdentifierTypeAPIKey:
+		return true
+	}
+	return false
+}
+
+// RateLimitPolicy is the data of rate_limit_policy configs.
+type RateLimitPolicy struct {
+	Burst          *int                          `json:"burst,omitempty"`
+	Description    *string                       `json:"description,omitempty"`
+	IdentifierType RateLimitPolicyIdentifierType `json:"identifier_type"`
+	MaxRequests    int                           `json:"max_requests"`
+	Scope          []string                      `json:"scope,omitempty"`
+	WindowSeconds  int                           `json:"window_seconds"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v RateLimitPolicy) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v RateLimitPolicy) validate(path string) []error {
+	var errs []error
+	if v.Burst != nil {
+		if *v.Burst < 0 {
+			errs = append(errs, fmt.Errorf("%s: must be >= 0", path+"/burst"))
+		}
+	}
+	if !v.IdentifierType.Valid() {
+		errs = append(errs, fmt.Errorf("%s: %q is not one of the allowed values", path+"/identifier_type", v.IdentifierType))
+	}
+	if v.MaxRequests < 1 {
+		errs = append(errs, fmt.Errorf("%s: must be >= 1", path+"/max_requests"))
+	}
+	if duplicate(v.Scope) {
+		errs = append(errs, fmt.Errorf("%s: items must be unique", path+"/scope"))
+	}
+	for i0, e0 := range v.Scope {
+		if utf8.RuneCountInString(e0) < 1 {
+			errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/scope/"+strconv.Itoa(i0)))
+		}
+	}
+	if v.WindowSeconds < 1 {
+		errs = append(errs, fmt.Errorf("%s: must be >= 1", path+"/window_seconds"))
+	}
+	return errs
+}
+
+// ScheduleRuleWindowsItem is a nested object of the config data.
+type ScheduleRuleWindowsItem struct {
+	End   time.Time `json:"end"`
+	Start time.Time `json:"start"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v ScheduleRuleWindowsItem) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v ScheduleRuleWindowsItem) validate(path string) []error {
+	return nil
+}
+
+// ScheduleRule is the data of schedule_rule configs.
+type ScheduleRule struct {
+	Active      bool                      `json:"active"`
+	Cron        *string                   `json:"cron,omitempty"`
+	Description *string                   `json:"description,omitempty"`
+	Timezone    string                    `json:"timezone"`
+	Windows     []ScheduleRuleWindowsItem `json:"windows,omitempty"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v ScheduleRule) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v ScheduleRule) validate(path string) []error {
+	var errs []error
+	if v.Cron != nil {
+		if utf8.RuneCountInString(*v.Cron) < 1 {
+			errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/cron"))
+		}
+	}
+	if utf8.RuneCountInString(v.Timezone) < 1 {
+		errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/timezone"))
+	}
+	for i0, e0 := range v.Windows {
+		errs = append(errs, e0.validate(path+"/windows/"+strconv.Itoa(i0))...)
+	}
+	return errs
+}
+
+// SegmentClausesItemOp is one of the values the schema allows.
+type SegmentClausesItemOp string
+
+// Values of SegmentClausesItemOp.
+const (
+	SegmentClausesItemOpIn         SegmentClausesItemOp = "in"
+	SegmentClausesItemOpNotIn      SegmentClausesItemOp = "not_in"
+	SegmentClausesItemOpStartsWith SegmentClausesItemOp = "starts_with"
+	SegmentClausesItemOpMatches    SegmentClausesItemOp = "matches"
+	SegmentClausesItemOpSemverEq   SegmentClausesItemOp = "semver_eq"
+	SegmentClausesItemOpSemverLt   SegmentClausesItemOp = "semver_lt"
+	SegmentClausesItemOpSemverLte  SegmentClausesItemOp = "semver_lte"
+	SegmentClausesItemOpSemverGt   SegmentClausesItemOp = "semver_gt"
+	SegmentClausesItemOpSemverGte  SegmentClausesItemOp = "semver_gte"
+	SegmentClausesItemOpLt         SegmentClausesItemOp = "lt"
+	SegmentClausesItemOpLte        SegmentClausesItemOp = "lte"
+	SegmentClausesItemOpGt         SegmentClausesItemOp = "gt"
+	SegmentClausesItemOpGte        SegmentClausesItemOp = "gte"
+	SegmentClausesItemOpBefore     SegmentClausesItemOp = "before"
+	SegmentClausesItemOpAfter      SegmentClausesItemOp = "after"
+)
+
+// Valid reports whether v is one of the values of SegmentClausesItemOp.
+func (v SegmentClausesItemOp) Valid() bool {
+	switch v {
+	case SegmentClausesItemOpIn, SegmentClausesItemOpNotIn, SegmentClausesItemOpStartsWith, SegmentClausesItemOpMatches, SegmentClausesItemOpSemverEq, SegmentClausesItemOpSemverLt, SegmentClausesItemOpSemverLte, SegmentClausesItemOpSemverGt, SegmentClausesItemOpSemverGte, SegmentClausesItemOpLt, SegmentClausesItemOpLte, SegmentClausesItemOpGt, SegmentClausesItemOpGte, SegmentClausesItemOpBefore, SegmentClausesItemOpAfter:
+		return true
+	}
+	return false
+}
+
+// SegmentClausesItem is a nested object of the config data.
+type SegmentClausesItem struct {
+	Attribute string               `json:"attribute"`
+	Op        SegmentClausesItemOp `json:"op"`
+	Values    []any                `json:"values"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v SegmentClausesItem) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v SegmentClausesItem) validate(path string) []error {
+	var errs []error
+	if utf8.RuneCountInString(v.Attribute) < 1 {
+		errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/attribute"))
+	}
+	if !v.Op.Valid() {
+		errs = append(errs, fmt.Errorf("%s: %q is not one of the allowed values", path+"/op", v.Op))
+	}
+	if len(v.Values) < 1 {
+		errs = append(errs, fmt.Errorf("%s: must have >= 1 items", path+"/values"))
+	}
+	return errs
+}
+
+// Segment is the data of segment configs.
+type Segment struct {
+	Clauses     []SegmentClausesItem `json:"clauses,omitempty"`
+	Description *string              `json:"description,omitempty"`
+	Exclude     []string             `json:"exclude,omitempty"`
+	Include     []string             `json:"include,omitempty"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v Segment) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v Segment) validate(path string) []error {
+	var errs []error
+	for i0, e0 := range v.Clauses {
+		errs = append(errs, e0.validate(path+"/clauses/"+strconv.Itoa(i0))...)
+	}
+	if duplicate(v.Exclude) {
+		errs = append(errs, fmt.Errorf("%s: items must be unique", path+"/exclude"))
+	}
+	for i0, e0 := range v.Exclude {
+		if utf8.RuneCountInString(e0) < 1 {
+			errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/exclude/"+strconv.Itoa(i0)))
+		}
+	}
+	if duplicate(v.Include) {
+		errs = append(errs, fmt.Errorf("%s: items must be unique", path+"/include"))
+	}
+	for i0, e0 := range v.Include {
+		if utf8.RuneCountInString(e0) < 1 {
+			errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/include/"+strconv.Itoa(i0)))
+		}
+	}
+	return errs
+}
+
+// ServiceClientRetry is a nested object of the config data.
+type ServiceClientRetry struct {
+	BackoffMs  *int  `json:"backoff_ms,omitempty"`
+	Jitter     *bool `json:"jitter,omitempty"`
+	MaxRetries int   `json:"max_retries"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v ServiceClientRetry) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v ServiceClientRetry) validate(path string) []error {
+	var errs []error
+	if v.BackoffMs != nil {
+		if *v.BackoffMs < 0 {
+			errs = append(errs, fmt.Errorf("%s: must be >= 0", path+"/backoff_ms"))
+		}
+	}
+	if v.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("%s: must be >= 0", path+"/max_retries"))
+	}
+	if v.MaxRetries > 10 {
+		errs = append(errs, fmt.Errorf("%s: must be <= 10", path+"/max_retries"))
+	}
+	return errs
+}
+
+// ServiceClient is the data of service_client configs.
+type ServiceClient struct {
+	BaseURL     string  `json:"base_url"`
+	Description *string `json:"description,omitempty"`
+	// Secret: encrypted at rest and redacted unless read with reveal.
+	Headers   map[string]string   `json:"headers,omitempty"`
+	Name      string              `json:"name"`
+	Retry     *ServiceClientRetry `json:"retry,omitempty"`
+	TimeoutMs int                 `json:"timeout_ms"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v ServiceClient) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v ServiceClient) validate(path string) []error {
+	var errs []error
+	if utf8.RuneCountInString(v.Name) < 1 {
+		errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/name"))
+	}
+	if v.Retry != nil {
+		errs = append(errs, v.Retry.validate(path+"/retry")...)
+	}
+	if v.TimeoutMs < 100 {
+		errs = append(errs, fmt.Errorf("%s: must be >= 100", path+"/timeout_ms"))
+	}
+	return errs
+}
+
+// ThresholdPolicyUnit is one of the values the schema allows.
+type ThresholdPolicyUnit string
+
+// Values of ThresholdPolicyUnit.
+const (
+	ThresholdPolicyUnitCount   ThresholdPolicyUnit = "count"
+	ThresholdPolicyUnitMs      ThresholdPolicyUnit = "ms"
+	ThresholdPolicyUnitPercent ThresholdPolicyUnit = "percent"
+	ThresholdPolicyUnitAmount  ThresholdPolicyUnit = "amount"
+)
+
+// Valid reports whether v is one of the values of ThresholdPolicyUnit.
+func (v ThresholdPolicyUnit) Valid() bool {
+	switch v {
+	case ThresholdPolicyUnitCount, ThresholdPolicyUnitMs, ThresholdPolicyUnitPercent, ThresholdPolicyUnitAmount:
+		return true
+	}
+	return false
+}
+
+// ThresholdPolicy is the data of threshold_policy configs.
+type ThresholdPolicy struct {
+	Description *string `json:"description,omitempty"`
+	Enabled     bool    `json:"enabled"`
+	// Defaults to true.
+	Inclusive *bool               `json:"inclusive,omitempty"`
+	Max       *float64            `json:"max,omitempty"`
+	Metric    string              `json:"metric"`
+	Min       *float64            `json:"min,omitempty"`
+	Unit      ThresholdPolicyUnit `json:"unit"`
+}
+
+// Validate reports every value of v the schema rejects.
+func (v ThresholdPolicy) Validate() error {
+	return errors.Join(v.validate("")...)
+}
+
+func (v ThresholdPolicy) validate(path string) []error {
+	var errs []error
+	if utf8.RuneCountInString(v.Metric) < 1 {
+		errs = append(errs, fmt.Errorf("%s: length must be >= 1", path+"/metric"))
+	}
+	if !v.Unit.Valid() {
+		errs = append(errs, fmt.Errorf("%s: %q is not one of the allowed values", path+"/unit", v.Unit))
+	}
+	return errs
+}
+
+// duplicate reports whether items holds a value twice.
+func duplicate[T comparable](items []T) bool {
+	seen := make(map[T]bool, len(items))
+	for _, item := range items {
+		if seen[item] {
+			return true
+		}
+		seen[item] = true
+	}
+	return false
+}
+
+// GetExperimentConfig reads the resolved latest version of the experiment_config config name
+// and validates it.
+func (c *Client) GetExperimentConfig(ctx context.Context, name string) (ExperimentConfig, error) {
+	var v ExperimentConfig
+	err := c.GetAs(ctx, TypeExperimentConfig, name, &v)
+	return v, err
+}
+
+// GetFeatureToggle reads the resolved latest version of the feature_toggle config name
+// and validates it.
+func (c *Client) GetFeatureToggle(ctx context.Context, name string) (FeatureToggle, error) {
+	var v FeatureToggle
+	err := c.GetAs(ctx, TypeFeatureToggle, name, &v)
+	return v, err
+}
+
+// GetNotificationPolicy reads the resolved latest version of the notification_policy config name
+// and validates it.
+func (c *Client) GetNotificationPolicy(ctx context.Context, name string) (NotificationPolicy, error) {
+	var v NotificationPolicy
+	err := c.GetAs(ctx, TypeNotificationPolicy, name, &v)
+	return v, err
+}
+
+// GetRateLimitPolicy reads the resolved latest version of the rate_limit_policy config name
+// and validates it.
+func (c *Client) GetRateLimitPolicy(ctx context.Context, name string) (RateLimitPolicy, error) {
+	var v RateLimitPolicy
+	err := c.GetAs(ctx, TypeRateLimitPolicy, name, &v)
+	return v, err
+}
+
+// GetScheduleRule reads the resolved latest version of the schedule_rule config name
+// and validates it.
+func (c *Client) GetScheduleRule(ctx context.Context, name string) (ScheduleRule, error) {
+	var v ScheduleRule
+	err := c.GetAs(ctx, TypeScheduleRule, name, &v)
+	return v, err
+}
+
+// GetSegment reads the resolved latest version of the segment config name
+// and validates it.
+func (c *Client) GetSegment(ctx context.Context, name string) (Segment, error) {
+	var v Segment
+	err := c.GetAs(ctx, TypeSegment, name, &v)
+	return v, err
+}
+
+// GetServiceClient reads the resolved latest version of the service_client config name
+// and validates it.
+func (c *Client) GetServiceClient(ctx context.Context, name string) (ServiceClient, error) {
+	var v ServiceClient
+	err := c.GetAs(ctx, TypeServiceClient, name, &v)
+	return v, err
+}
+
+// GetThresholdPolicy reads the resolved latest version of the threshold_policy config name
+// and validates it.
+func (c *Client) GetThresholdPolicy(ctx context.Context, name string) (ThresholdPolicy, error) {
+	var v ThresholdPolicy
+	err := c.GetAs(ctx, TypeThresholdPolicy, name, &v)
+	return v, err
+}
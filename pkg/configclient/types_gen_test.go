@@ -0,0 +1,78 @@
+package configclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"configuration-management-service/internal/schema/builtin"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGeneratedTypes_BuiltinExamples decodes the example of every built-in
+// schema into its generated type and validates it.
+func TestGeneratedTypes_BuiltinExamples(t *testing.T) {
+	types := map[string]Validator{
+		TypeExperimentConfig:   &ExperimentConfig{},
+		TypeFeatureToggle:      &FeatureToggle{},
+		TypeNotificationPolicy: &NotificationPolicy{},
+		TypeRateLimitPolicy:    &RateLimitPolicy{},
+		TypeScheduleRule:       &ScheduleRule{},
+		TypeSegment:            &Segment{},
+		TypeServiceClient:      &ServiceClient{},
+		TypeThresholdPolicy:    &ThresholdPolicy{},
+	}
+	schemas := builtin.Schemas()
+	assert.Len(t, types, len(schemas))
+
+	for schemaType, schema := range schemas {
+		t.Run(schemaType, func(t *testing.T) {
+			var doc struct {
+				Examples []json.RawMessage `json:"examples"`
+			}
+			assert.NoError(t, json.Unmarshal([]byte(schema), &doc))
+			assert.NotEmpty(t, doc.Examples)
+
+			v := types[schemaType]
+			for _, ex := range doc.Examples {
+				assert.NoError(t, json.Unmarshal(ex, v))
+				assert.NoError(t, v.Validate())
+			}
+		})
+	}
+}
+
+func TestGeneratedTypes_Validate(t *testing.T) {
+	cases := []struct {
+		name string
+		v    Validator
+		err  string
+	}{
+		{
+			name: "when enum value is unknown should report it",
+			v:    RateLimitPolicy{IdentifierType: "cookie", WindowSeconds: 60, MaxRequests: 10},
+			err:  `/identifier_type: "cookie" is not one of the allowed values`,
+		},
+		{
+			name: "when nested values break the schema should report their pointers",
+			v: ExperimentConfig{ExperimentKey: "k", Variants: []ExperimentConfigVariantsItem{{Name: "a", Weight: 1}, {Name: "", Weight: -1}},
+				Audience: &ExperimentConfigAudience{OS: []ExperimentConfigAudienceOS{ExperimentConfigAudienceOSIos, "tv"}}},
+			err: "/audience/os/1: \"tv\" is not one of the allowed values\n/variants/1/name: length must be >= 1\n/variants/1/weight: must be >= 0",
+		},
+		{
+			name: "when optional values are absent should pass",
+			v:    ThresholdPolicy{Metric: "p95", Unit: ThresholdPolicyUnitMs, Enabled: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.v.Validate()
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
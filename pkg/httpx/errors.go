@@ -0,0 +1,25 @@
+package httpx
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WriteError writes the service-wide JSON error envelope.
+func WriteError(c echo.Context, code int, msg string, details any) error {
+	return c.JSON(code, map[string]any{
+		"error": map[string]any{
+			"code":    http.StatusText(code),
+			"message": msg,
+			"details": details,
+		},
+	})
+}
+
+// IsJSON reports whether the request body is declared as JSON.
+func IsJSON(c echo.Context) bool {
+	ct := c.Request().Header.Get(echo.HeaderContentType)
+	return strings.HasPrefix(ct, echo.MIMEApplicationJSON)
+}
@@ -0,0 +1,129 @@
+package httpx
+
+import (
+	"configuration-management-service/pkg/jsonx"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Format is a body format of the config API. Data is always stored as
+// JSON; YAML and TOML bodies are converted on the way in and out.
+type Format string
+
+const (
+	FormatJSON Format = "JSON"
+	FormatYAML Format = "YAML"
+	FormatTOML Format = "TOML"
+)
+
+const (
+	MIMEApplicationYAML = "application/yaml"
+	MIMEApplicationTOML = "application/toml"
+)
+
+// mediaTypes maps the accepted media types to their format.
+var mediaTypes = map[string]Format{
+	echo.MIMEApplicationJSON: FormatJSON,
+	MIMEApplicationYAML:      FormatYAML,
+	"application/x-yaml":     FormatYAML,
+	"text/yaml":              FormatYAML,
+	MIMEApplicationTOML:      FormatTOML,
+}
+
+// ContentFormat returns the format of the request body; false for any
+// other Content-Type.
+func ContentFormat(c echo.Context) (Format, bool) {
+	mt, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return "", false
+	}
+	f, ok := mediaTypes[mt]
+	return f, ok
+}
+
+// Bind decodes the request body, declared as f, into v as JSON would.
+func Bind(c echo.Context, f Format, v any) error {
+	if f == FormatJSON {
+		return c.Bind(v)
+	}
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+	var raw json.RawMessage
+	switch f {
+	case FormatYAML:
+		raw, err = jsonx.FromYAML(body)
+	case FormatTOML:
+		raw, err = jsonx.FromTOML(body)
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// AcceptFormat picks the response format from the Accept header: the
+// supported type with the highest q, JSON when none is listed.
+func AcceptFormat(c echo.Context) Format {
+	best, bestQ := FormatJSON, 0.0
+	for _, part := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		f, ok := mediaTypes[mt]
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ {
+			best, bestQ = f, q
+		}
+	}
+	return best
+}
+
+// Respond writes v in the format the client accepts. A value TOML cannot
+// hold, such as one with a null, is answered with 406.
+func Respond(c echo.Context, code int, v any) error {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	f := AcceptFormat(c)
+	if f == FormatJSON {
+		return c.JSON(code, v)
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var out []byte
+	var contentType string
+	switch f {
+	case FormatYAML:
+		out, err = jsonx.ToYAML(raw)
+		contentType = MIMEApplicationYAML
+	case FormatTOML:
+		out, err = jsonx.ToTOML(raw)
+		contentType = MIMEApplicationTOML
+	}
+	if errors.Is(err, jsonx.ErrNotTOML) {
+		return WriteError(c, http.StatusNotAcceptable, "response cannot be written as TOML", err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return c.Blob(code, contentType+"; charset=UTF-8", out)
+}
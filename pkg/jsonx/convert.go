@@ -0,0 +1,261 @@
+package jsonx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// FromYAML converts a YAML document to JSON. Anchors and merge keys are
+// expanded, timestamps keep their text and non-string keys become their
+// text; .inf and .nan are rejected since JSON has no such numbers.
+func FromYAML(b []byte) (json.RawMessage, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, errors.New("empty document")
+	}
+	timestampsAsText(&doc)
+	var v any
+	if err := doc.Decode(&v); err != nil {
+		return nil, err
+	}
+	v, err := normalize(v, "")
+	if err != nil {
+		return nil, err
+	}
+	return Encode(v)
+}
+
+func timestampsAsText(n *yaml.Node) {
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!timestamp" {
+		n.Tag = "!!str"
+	}
+	for _, c := range n.Content {
+		timestampsAsText(c)
+	}
+}
+
+// FromTOML converts a TOML document to JSON. Dates and times become
+// strings in their TOML form.
+func FromTOML(b []byte) (json.RawMessage, error) {
+	var v map[string]any
+	if _, err := toml.NewDecoder(bytes.NewReader(b)).Decode(&v); err != nil {
+		return nil, err
+	}
+	out, err := normalize(v, "")
+	if err != nil {
+		return nil, err
+	}
+	return Encode(out)
+}
+
+// normalize turns a decoded YAML or TOML value into one encoding/json
+// writes as the same document.
+func normalize(v any, ptr string) (any, error) {
+	switch x := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(x))
+		for k, e := range x {
+			n, err := normalize(e, child(ptr, k))
+			if err != nil {
+				return nil, err
+			}
+			out[k] = n
+		}
+		return out, nil
+	case map[any]any:
+		out := make(map[string]any, len(x))
+		for k, e := range x {
+			key := fmt.Sprint(k)
+			n, err := normalize(e, child(ptr, key))
+			if err != nil {
+				return nil, err
+			}
+			out[key] = n
+		}
+		return out, nil
+	case []map[string]any:
+		out := make([]any, len(x))
+		for i, e := range x {
+			n, err := normalize(e, child(ptr, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = n
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(x))
+		for i, e := range x {
+			n, err := normalize(e, child(ptr, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = n
+		}
+		return out, nil
+	case float64:
+		if math.IsInf(x, 0) || math.IsNaN(x) {
+			return nil, fmt.Errorf("%s: %v is not a JSON number", pointerOrRoot(ptr), x)
+		}
+		return x, nil
+	case time.Time:
+		// The TOML decoder marks local dates and times with these zones.
+		switch x.Location().String() {
+		case "date-local":
+			return x.Format("2006-01-02"), nil
+		case "time-local":
+			return x.Format("15:04:05.999999999"), nil
+		case "datetime-local":
+			return x.Format("2006-01-02T15:04:05.999999999"), nil
+		}
+		return x.Format(time.RFC3339Nano), nil
+	default:
+		return v, nil
+	}
+}
+
+// ToYAML converts a JSON document to YAML, keeping the order of its keys.
+func ToYAML(raw json.RawMessage) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	n, err := yamlNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func yamlNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		n := &yaml.Node{Kind: yaml.MappingNode}
+		if t == '[' {
+			n.Kind = yaml.SequenceNode
+		}
+		for dec.More() {
+			if n.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			child, err := yamlNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, child)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}, nil
+	case json.Number:
+		tag := "!!int"
+		if _, err := t.Int64(); err != nil {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(t)}, nil
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+// ErrNotTOML is returned by ToTOML for documents TOML cannot hold: a root
+// that is not an object, or a null anywhere.
+var ErrNotTOML = errors.New("not representable as TOML")
+
+// ToTOML converts a JSON object to TOML. Keys are sorted, as TOML tables
+// do not keep an order.
+func ToTOML(raw json.RawMessage) ([]byte, error) {
+	v, err := Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := v.(map[string]any); !ok {
+		return nil, fmt.Errorf("%w: the document is not an object", ErrNotTOML)
+	}
+	v, err = tomlValue(v, "")
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// tomlValue replaces json.Number with int64 or float64 and rejects nulls.
+func tomlValue(v any, ptr string) (any, error) {
+	switch x := v.(type) {
+	case map[string]any:
+		for k, e := range x {
+			n, err := tomlValue(e, child(ptr, k))
+			if err != nil {
+				return nil, err
+			}
+			x[k] = n
+		}
+		return x, nil
+	case []any:
+		for i, e := range x {
+			n, err := tomlValue(e, child(ptr, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			x[i] = n
+		}
+		return x, nil
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return i, nil
+		}
+		return x.Float64()
+	case nil:
+		return nil, fmt.Errorf("%w: null at %s", ErrNotTOML, pointerOrRoot(ptr))
+	default:
+		return v, nil
+	}
+}
+
+func child(ptr, key string) string {
+	return ptr + pointer([]string{key})
+}
+
+func pointerOrRoot(ptr string) string {
+	if ptr == "" {
+		return "(root)"
+	}
+	return ptr
+}
@@ -0,0 +1,130 @@
+package jsonx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromYAML(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+		err  string
+	}{
+		{
+			name: "when document uses anchors and merge keys should expand them",
+			in:   "base: &b\n  retries: 3\nsvc:\n  <<: *b\n  timeout_ms: 200\n",
+			want: `{"base":{"retries":3},"svc":{"retries":3,"timeout_ms":200}}`,
+		},
+		{
+			name: "when value is a timestamp should keep its text",
+			in:   "since: 2024-05-01\nat: 2024-05-01T10:00:00+07:00\n",
+			want: `{"since":"2024-05-01","at":"2024-05-01T10:00:00+07:00"}`,
+		},
+		{
+			name: "when keys are not strings should use their text",
+			in:   "1: a\ntrue: b\n",
+			want: `{"1":"a","true":"b"}`,
+		},
+		{
+			name: "when value is infinite should fail with its pointer",
+			in:   "limits:\n  max: .inf\n",
+			err:  "/limits/max: +Inf is not a JSON number",
+		},
+		{
+			name: "when document is empty should fail",
+			in:   "",
+			err:  "empty document",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromYAML([]byte(tc.in))
+			if tc.err != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tc.err, err.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestFromTOML(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "when document has an array of tables should write an array of objects",
+			in:   "[[rules]]\nregion = \"ID\"\n\n[[rules]]\nregion = \"SG\"\n",
+			want: `{"rules":[{"region":"ID"},{"region":"SG"}]}`,
+		},
+		{
+			name: "when values are dates and times should write them in TOML form",
+			in:   "day = 2024-05-01\nopen = 09:30:00\nlocal = 2024-05-01T09:30:00\nat = 2024-05-01T09:30:00Z\n",
+			want: `{"day":"2024-05-01","open":"09:30:00","local":"2024-05-01T09:30:00","at":"2024-05-01T09:30:00Z"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromTOML([]byte(tc.in))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestToYAML(t *testing.T) {
+	got, err := ToYAML([]byte(`{"z":1,"a":{"n":1.5,"s":"true","l":[null,false]}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "z: 1\na:\n  n: 1.5\n  s: \"true\"\n  l:\n    - null\n    - false\n", string(got))
+}
+
+func TestToTOML(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+		err  string
+	}{
+		{
+			name: "when document is an object should write sorted keys and tables",
+			in:   `{"name":"svc","retry":{"max":3,"factor":1.5},"tags":["a"]}`,
+			want: "name = \"svc\"\ntags = [\"a\"]\n\n[retry]\n  factor = 1.5\n  max = 3\n",
+		},
+		{
+			name: "when document holds a null should fail with its pointer",
+			in:   `{"limits":[1,null]}`,
+			err:  "not representable as TOML: null at /limits/1",
+		},
+		{
+			name: "when document is not an object should fail",
+			in:   `[1]`,
+			err:  "not representable as TOML: the document is not an object",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToTOML([]byte(tc.in))
+			if tc.err != "" {
+				if assert.Error(t, err) {
+					assert.True(t, errors.Is(err, ErrNotTOML))
+					assert.Equal(t, tc.err, err.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Change is one difference between two documents, JSON Patch style.
+type Change struct {
+	Op   string          `json:"op"`
+	Path string          `json:"path"`
+	Old  json.RawMessage `json:"old,omitempty"`
+	New  json.RawMessage `json:"new,omitempty"`
+}
+
+// Diff lists the changes from before to after. Objects are compared member
+// by member; any other changed value, arrays included, is one replace.
+func Diff(before, after json.RawMessage) ([]Change, error) {
+	a, err := Decode(before)
+	if err != nil {
+		return nil, err
+	}
+	b, err := Decode(after)
+	if err != nil {
+		return nil, err
+	}
+	out := []Change{}
+	if err := diff(nil, a, b, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func diff(path []string, a, b any, out *[]Change) error {
+	am, ok1 := a.(map[string]any)
+	bm, ok2 := b.(map[string]any)
+	if !ok1 || !ok2 {
+		if reflect.DeepEqual(a, b) {
+			return nil
+		}
+		return change(out, "replace", path, &a, &b)
+	}
+
+	keys := make([]string, 0, len(am)+len(bm))
+	for k := range am {
+		keys = append(keys, k)
+	}
+	for k := range bm {
+		if _, ok := am[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		p := append(append([]string{}, path...), k)
+		av, inA := am[k]
+		bv, inB := bm[k]
+		var err error
+		switch {
+		case !inB:
+			err = change(out, "remove", p, &av, nil)
+		case !inA:
+			err = change(out, "add", p, nil, &bv)
+		default:
+			err = diff(p, av, bv, out)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func change(out *[]Change, op string, path []string, from, to *any) error {
+	c := Change{Op: op, Path: pointer(path)}
+	var err error
+	if from != nil {
+		if c.Old, err = Encode(*from); err != nil {
+			return err
+		}
+	}
+	if to != nil {
+		if c.New, err = Encode(*to); err != nil {
+			return err
+		}
+	}
+	*out = append(*out, c)
+	return nil
+}
+
+// pointer joins path segments into a JSON pointer.
+func pointer(segs []string) string {
+	var b strings.Builder
+	for _, s := range segs {
+		b.WriteString("/" + strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// Lookup returns the value at pointer in doc, descending into objects and
+// arrays.
+func Lookup(doc any, pointer string) (any, bool) {
+	if pointer == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	cur := doc
+	for _, seg := range strings.Split(pointer[1:], "/") {
+		seg = strings.ReplaceAll(strings.ReplaceAll(seg, "~1", "/"), "~0", "~")
+		switch node := cur.(type) {
+		case map[string]any:
+			v, ok := node[seg]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []any:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			cur = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
@@ -0,0 +1,48 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	got, err := Diff(
+		json.RawMessage(`{"timeout_ms":200,"tags":["a"],"policy":{"retries":3,"mode":"fast"}}`),
+		json.RawMessage(`{"timeout":200,"tags":["a","b"],"policy":{"retries":3,"mode":"safe"}}`),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Op: "replace", Path: "/policy/mode", Old: json.RawMessage(`"fast"`), New: json.RawMessage(`"safe"`)},
+		{Op: "replace", Path: "/tags", Old: json.RawMessage(`["a"]`), New: json.RawMessage(`["a","b"]`)},
+		{Op: "add", Path: "/timeout", New: json.RawMessage(`200`)},
+		{Op: "remove", Path: "/timeout_ms", Old: json.RawMessage(`200`)},
+	}, got)
+
+	none, err := Diff(json.RawMessage(`{"a":1}`), json.RawMessage(`{"a":1}`))
+	assert.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestLookup(t *testing.T) {
+	doc, err := Decode([]byte(`{"a":{"b/c":[1,{"d":true}]},"e~f":null}`))
+	assert.NoError(t, err)
+
+	cases := []struct {
+		pointer string
+		want    any
+		ok      bool
+	}{
+		{pointer: "", want: doc, ok: true},
+		{pointer: "/a/b~1c/1/d", want: true, ok: true},
+		{pointer: "/e~0f", want: nil, ok: true},
+		{pointer: "/a/b~1c/2", ok: false},
+		{pointer: "/a/x", ok: false},
+		{pointer: "a", ok: false},
+	}
+	for _, tc := range cases {
+		got, ok := Lookup(doc, tc.pointer)
+		assert.Equal(t, tc.ok, ok, tc.pointer)
+		assert.Equal(t, tc.want, got, tc.pointer)
+	}
+}
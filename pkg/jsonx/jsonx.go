@@ -0,0 +1,63 @@
+// Package jsonx holds the generic JSON document helpers shared by the
+// packages that rewrite config data (numbers are kept as json.Number so
+// values round-trip without float rounding).
+package jsonx
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Decode parses b into maps, slices and scalars, keeping numbers as json.Number.
+func Decode(b []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Encode serialises v without HTML escaping and without a trailing newline.
+func Encode(v any) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// Merge deep-merges overlay onto base: objects are merged key by key, any
+// other overlay value (arrays, scalars, null) replaces the base value.
+// base is modified in place and returned.
+func Merge(base, overlay any) any {
+	bm, ok1 := base.(map[string]any)
+	om, ok2 := overlay.(map[string]any)
+	if !ok1 || !ok2 {
+		return overlay
+	}
+	for k, ov := range om {
+		if bv, exists := bm[k]; exists {
+			bm[k] = Merge(bv, ov)
+			continue
+		}
+		bm[k] = ov
+	}
+	return bm
+}
+
+// MergeRaw is Merge for serialised documents.
+func MergeRaw(base, overlay json.RawMessage) (json.RawMessage, error) {
+	b, err := Decode(base)
+	if err != nil {
+		return nil, err
+	}
+	o, err := Decode(overlay)
+	if err != nil {
+		return nil, err
+	}
+	return Encode(Merge(b, o))
+}
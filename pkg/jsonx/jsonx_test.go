@@ -0,0 +1,49 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRaw(t *testing.T) {
+	cases := []struct {
+		name    string
+		base    string
+		overlay string
+		want    string
+	}{
+		{
+			name:    "when overlay adds and overrides keys should deep merge objects",
+			base:    `{"name":"svc","retry":{"max_retries":3,"jitter":true},"headers":{"a":"1"}}`,
+			overlay: `{"retry":{"max_retries":5},"headers":{"b":"2"},"timeout_ms":200}`,
+			want:    `{"name":"svc","retry":{"max_retries":5,"jitter":true},"headers":{"a":"1","b":"2"},"timeout_ms":200}`,
+		},
+		{
+			name:    "when overlay sets array should replace base array",
+			base:    `{"tags":["a","b"]}`,
+			overlay: `{"tags":["c"]}`,
+			want:    `{"tags":["c"]}`,
+		},
+		{
+			name:    "when overlay sets null should keep null as value",
+			base:    `{"min":1}`,
+			overlay: `{"min":null}`,
+			want:    `{"min":null}`,
+		},
+		{
+			name:    "when numbers are large should keep precision",
+			base:    `{"n":12345678901234567890}`,
+			overlay: `{}`,
+			want:    `{"n":12345678901234567890}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := MergeRaw(json.RawMessage(tc.base), json.RawMessage(tc.overlay))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
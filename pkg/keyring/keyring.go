@@ -0,0 +1,217 @@
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNoActiveKey = errors.New("keyring: no active key")
+	ErrUnknownKey  = errors.New("keyring: unknown key id")
+	ErrMalformed   = errors.New("keyring: malformed ciphertext")
+)
+
+// tokenPrefix marks a value sealed by the keyring: enc:v1:<key id>:<base64(nonce|ciphertext)>
+const tokenPrefix = "enc:v1:"
+
+// Keyring holds AES-256 keys by id. New values are always sealed with the
+// active key; older keys stay around so existing ciphertexts can be opened
+// until they are re-encrypted.
+type Keyring struct {
+	active string
+	keys   map[string][]byte
+}
+
+// fileFormat is the on-disk layout of a keyring file.
+//
+//	{ "active": "2025-10", "keys": { "2025-01": "<base64>", "2025-10": "<base64>" } }
+type fileFormat struct {
+	Active string            `json:"active"`
+	Keys   map[string]string `json:"keys"`
+}
+
+func New(active string, keys map[string][]byte) (*Keyring, error) {
+	kr := &Keyring{active: active, keys: map[string][]byte{}}
+	for id, k := range keys {
+		if strings.ContainsRune(id, ':') || id == "" {
+			return nil, fmt.Errorf("keyring: invalid key id %q", id)
+		}
+		if len(k) != 32 {
+			return nil, fmt.Errorf("keyring: key %q must be 32 bytes, got %d", id, len(k))
+		}
+		kr.keys[id] = k
+	}
+	if active != "" {
+		if _, ok := kr.keys[active]; !ok {
+			return nil, fmt.Errorf("keyring: active key %q not present", active)
+		}
+	}
+	return kr, nil
+}
+
+// Load builds a keyring from a JSON keyring file, or when path is empty from
+// an env-style spec "id1:base64key,id2:base64key". When active is empty the
+// last key of the spec (or the file's "active" field) is used.
+func Load(path, spec, active string) (*Keyring, error) {
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("keyring: %w", err)
+		}
+		var f fileFormat
+		if err := json.Unmarshal(b, &f); err != nil {
+			return nil, fmt.Errorf("keyring: %s: %w", path, err)
+		}
+		keys, err := decodeKeys(f.Keys)
+		if err != nil {
+			return nil, err
+		}
+		if active == "" {
+			active = f.Active
+		}
+		return New(active, keys)
+	}
+
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return New("", nil)
+	}
+	raw := map[string]string{}
+	last := ""
+	for _, part := range strings.Split(spec, ",") {
+		id, k, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, fmt.Errorf("keyring: entry %q must be <id>:<base64 key>", part)
+		}
+		raw[id] = k
+		last = id
+	}
+	keys, err := decodeKeys(raw)
+	if err != nil {
+		return nil, err
+	}
+	if active == "" {
+		active = last
+	}
+	return New(active, keys)
+}
+
+func decodeKeys(raw map[string]string) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(raw))
+	for id, s := range raw {
+		k, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("keyring: key %q: %w", id, err)
+		}
+		out[id] = k
+	}
+	return out, nil
+}
+
+// ActiveKeyID returns the id new values are sealed with ("" when the keyring is empty).
+func (k *Keyring) ActiveKeyID() string {
+	if k == nil {
+		return ""
+	}
+	return k.active
+}
+
+// KeyIDs lists the loaded key ids in sorted order.
+func (k *Keyring) KeyIDs() []string {
+	if k == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Seal encrypts plaintext with the active key. aad binds the ciphertext to its
+// context (e.g. the config name) so it cannot be replayed elsewhere.
+func (k *Keyring) Seal(plaintext, aad []byte) (string, error) {
+	if k == nil || k.active == "" {
+		return "", ErrNoActiveKey
+	}
+	gcm, err := newGCM(k.keys[k.active])
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	ct := gcm.Seal(nonce, nonce, plaintext, aad)
+	return tokenPrefix + k.active + ":" + base64.StdEncoding.EncodeToString(ct), nil
+}
+
+// Open decrypts a token produced by Seal.
+func (k *Keyring) Open(token string, aad []byte) ([]byte, error) {
+	id, payload, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if k == nil {
+		return nil, ErrUnknownKey
+	}
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < gcm.NonceSize() {
+		return nil, ErrMalformed
+	}
+	pt, err := gcm.Open(nil, payload[:gcm.NonceSize()], payload[gcm.NonceSize():], aad)
+	if err != nil {
+		return nil, fmt.Errorf("keyring: open with key %s: %w", id, err)
+	}
+	return pt, nil
+}
+
+// IsSealed reports whether s looks like a token produced by Seal.
+func IsSealed(s string) bool {
+	return strings.HasPrefix(s, tokenPrefix)
+}
+
+// KeyID returns the id of the key a token was sealed with.
+func KeyID(token string) (string, error) {
+	id, _, err := parseToken(token)
+	return id, err
+}
+
+func parseToken(token string) (string, []byte, error) {
+	if !IsSealed(token) {
+		return "", nil, ErrMalformed
+	}
+	id, enc, ok := strings.Cut(strings.TrimPrefix(token, tokenPrefix), ":")
+	if !ok || id == "" {
+		return "", nil, ErrMalformed
+	}
+	payload, err := base64.StdEncoding.DecodeString(enc)
+	if err != nil {
+		return "", nil, ErrMalformed
+	}
+	return id, payload, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,121 @@
+package keyring
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testKey(b byte) []byte { return bytes.Repeat([]byte{b}, 32) }
+
+func TestKeyring_SealOpen(t *testing.T) {
+	kr, err := New("k1", map[string][]byte{"k1": testKey(1)})
+	assert.NoError(t, err)
+
+	tok, err := kr.Seal([]byte(`"token-123"`), []byte("svc"))
+	assert.NoError(t, err)
+	assert.True(t, IsSealed(tok))
+	kid, err := KeyID(tok)
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", kid)
+
+	pt, err := kr.Open(tok, []byte("svc"))
+	assert.NoError(t, err)
+	assert.Equal(t, `"token-123"`, string(pt))
+
+	_, err = kr.Open(tok, []byte("other"))
+	assert.Error(t, err, "aad mismatch must fail")
+}
+
+func TestKeyring_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		run  func() error
+	}{
+		{
+			name: "when keyring empty seal should return ErrNoActiveKey",
+			run: func() error {
+				kr, _ := New("", nil)
+				_, err := kr.Seal([]byte("x"), nil)
+				return err
+			},
+		},
+		{
+			name: "when key has wrong size should return error",
+			run: func() error {
+				_, err := New("k1", map[string][]byte{"k1": []byte("short")})
+				return err
+			},
+		},
+		{
+			name: "when active key missing should return error",
+			run: func() error {
+				_, err := New("k2", map[string][]byte{"k1": testKey(1)})
+				return err
+			},
+		},
+		{
+			name: "when token sealed with unknown key should return error",
+			run: func() error {
+				a, _ := New("a", map[string][]byte{"a": testKey(1)})
+				b, _ := New("b", map[string][]byte{"b": testKey(2)})
+				tok, _ := a.Seal([]byte("x"), nil)
+				_, err := b.Open(tok, nil)
+				return err
+			},
+		},
+		{
+			name: "when token malformed should return ErrMalformed",
+			run: func() error {
+				kr, _ := New("a", map[string][]byte{"a": testKey(1)})
+				_, err := kr.Open("enc:v1:a:!!!", nil)
+				return err
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Error(t, tc.run())
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	k1 := base64.StdEncoding.EncodeToString(testKey(1))
+	k2 := base64.StdEncoding.EncodeToString(testKey(2))
+
+	t.Run("when env spec given should use last key as active", func(t *testing.T) {
+		kr, err := Load("", "old:"+k1+", new:"+k2, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "new", kr.ActiveKeyID())
+		assert.Equal(t, []string{"new", "old"}, kr.KeyIDs())
+	})
+
+	t.Run("when file given should use file active key unless overridden", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keyring.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`{"active":"a","keys":{"a":"`+k1+`","b":"`+k2+`"}}`), 0o600))
+
+		kr, err := Load(path, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "a", kr.ActiveKeyID())
+
+		kr, err = Load(path, "", "b")
+		assert.NoError(t, err)
+		assert.Equal(t, "b", kr.ActiveKeyID())
+	})
+
+	t.Run("when nothing configured should return empty keyring", func(t *testing.T) {
+		kr, err := Load("", "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "", kr.ActiveKeyID())
+	})
+
+	t.Run("when spec malformed should return error", func(t *testing.T) {
+		_, err := Load("", "no-separator", "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,117 @@
+// Package schedule computes the fire times of schedule_rule cron
+// expressions in the rule's time zone, with the daylight saving rules of
+// cron daemons:
+//
+//   - A job at a fixed hour runs once a day on the wall clock. When its time
+//     is skipped by a spring-forward transition it runs when the clocks
+//     jump, and when its time repeats on a fall-back transition it runs on
+//     the first pass only.
+//   - A job whose hour field covers every hour ("*/15 * * * *") follows real
+//     time: it runs through both passes of a repeated hour and has nothing
+//     to run in a skipped one.
+package schedule
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// parser accepts the five standard fields and descriptors such as @daily.
+var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// everyHour is the hour field of a spec that matches every hour.
+const everyHour = 1<<24 - 1
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	s cron.Schedule
+}
+
+// Parse parses a 5-field cron expression or a descriptor. A CRON_TZ=
+// prefix overrides the zone fire times are computed in.
+func Parse(expr string) (Schedule, error) {
+	s, err := parser.Parse(expr)
+	if err != nil {
+		return Schedule{}, err
+	}
+	return Schedule{s: s}, nil
+}
+
+// Next returns the first fire time after t, in loc, or the zero time when
+// the expression never fires again.
+func (s Schedule) Next(t time.Time, loc *time.Location) time.Time {
+	spec, ok := s.s.(*cron.SpecSchedule)
+	if !ok {
+		// @every is a fixed delay, which daylight saving does not move.
+		return s.s.Next(t).In(loc)
+	}
+	if spec.Location != time.Local {
+		loc = spec.Location
+	}
+	t = t.In(loc)
+	if spec.Hour&everyHour == everyHour {
+		return spec.Next(t)
+	}
+
+	// Walk the wall clock, where every local time happens exactly once,
+	// and map each match back to an instant.
+	wallSpec := *spec
+	wallSpec.Location = time.UTC
+	w := wall(t)
+	for {
+		if w = wallSpec.Next(w); w.IsZero() {
+			return time.Time{}
+		}
+		if at := instant(w, loc); at.After(t) {
+			return at
+		}
+	}
+}
+
+// List returns the next count fire times after t, in loc. It stops early
+// when the expression never fires again.
+func (s Schedule) List(t time.Time, loc *time.Location, count int) []time.Time {
+	out := make([]time.Time, 0, count)
+	for len(out) < count {
+		if t = s.Next(t, loc); t.IsZero() {
+			break
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// wall returns the wall clock time of t as the same fields in UTC.
+func wall(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// instant returns the first instant the wall clock of loc shows w. A time
+// skipped by a transition maps to the transition itself, the first instant
+// after the gap.
+func instant(w time.Time, loc *time.Location) time.Time {
+	at := time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), loc)
+	switch shown := wall(at); {
+	case shown.After(w):
+		// Normalized past the gap: the zone at shows began with the jump.
+		start, _ := at.ZoneBounds()
+		return start
+	case shown.Before(w):
+		// Normalized before the gap, which starts where this zone ends.
+		_, end := at.ZoneBounds()
+		return end
+	}
+
+	// On a fall-back transition w shows twice; take the first pass.
+	start, _ := at.ZoneBounds()
+	if start.IsZero() {
+		return at
+	}
+	_, before := start.Add(-time.Nanosecond).Zone()
+	_, after := at.Zone()
+	if earlier := at.Add(-time.Duration(before-after) * time.Second); before > after && wall(earlier).Equal(w) {
+		return earlier
+	}
+	return at
+}
@@ -0,0 +1,144 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_List(t *testing.T) {
+	cases := []struct {
+		name  string
+		expr  string
+		zone  string
+		from  string
+		count int
+		want  []string
+	}{
+		{
+			name:  "when fixed time is skipped by spring forward should run when the clocks jump",
+			expr:  "30 2 * * *",
+			zone:  "America/New_York",
+			from:  "2025-03-08T12:00:00-05:00",
+			count: 3,
+			want:  []string{"2025-03-09T03:00:00-04:00", "2025-03-10T02:30:00-04:00", "2025-03-11T02:30:00-04:00"},
+		},
+		{
+			name:  "when several fixed times are skipped should run once when the clocks jump",
+			expr:  "0,30 2 * * *",
+			zone:  "America/New_York",
+			from:  "2025-03-09T00:00:00-05:00",
+			count: 3,
+			want:  []string{"2025-03-09T03:00:00-04:00", "2025-03-10T02:00:00-04:00", "2025-03-10T02:30:00-04:00"},
+		},
+		{
+			name:  "when fixed time repeats on fall back should run on the first pass only",
+			expr:  "30 1 * * *",
+			zone:  "America/New_York",
+			from:  "2025-11-01T12:00:00-04:00",
+			count: 2,
+			want:  []string{"2025-11-02T01:30:00-04:00", "2025-11-03T01:30:00-05:00"},
+		},
+		{
+			name:  "when starting in the second pass of a repeated hour should not run again",
+			expr:  "30 1 * * *",
+			zone:  "America/New_York",
+			from:  "2025-11-02T01:10:00-05:00",
+			count: 1,
+			want:  []string{"2025-11-03T01:30:00-05:00"},
+		},
+		{
+			name:  "when every hour is listed should run through both passes of a repeated hour",
+			expr:  "*/30 * * * *",
+			zone:  "America/New_York",
+			from:  "2025-11-02T00:10:00-04:00",
+			count: 5,
+			want:  []string{"2025-11-02T00:30:00-04:00", "2025-11-02T01:00:00-04:00", "2025-11-02T01:30:00-04:00", "2025-11-02T01:00:00-05:00", "2025-11-02T01:30:00-05:00"},
+		},
+		{
+			name:  "when every hour is listed should have nothing to run in a skipped hour",
+			expr:  "*/30 * * * *",
+			zone:  "America/New_York",
+			from:  "2025-03-09T01:10:00-05:00",
+			count: 3,
+			want:  []string{"2025-03-09T01:30:00-05:00", "2025-03-09T03:00:00-04:00", "2025-03-09T03:30:00-04:00"},
+		},
+		{
+			name:  "when the zone shifts by half an hour should map the gap and the repeat the same way",
+			expr:  "15 2 * * *",
+			zone:  "Australia/Lord_Howe",
+			from:  "2025-10-04T12:00:00+10:30",
+			count: 2,
+			want:  []string{"2025-10-05T02:30:00+11:00", "2025-10-06T02:15:00+11:00"},
+		},
+		{
+			name:  "when the half hour repeats should run on the first pass",
+			expr:  "45 1 * * *",
+			zone:  "Australia/Lord_Howe",
+			from:  "2025-04-05T12:00:00+11:00",
+			count: 2,
+			want:  []string{"2025-04-06T01:45:00+11:00", "2025-04-07T01:45:00+10:30"},
+		},
+		{
+			name:  "when descriptor is used should run in the zone",
+			expr:  "@daily",
+			zone:  "Asia/Jakarta",
+			from:  "2025-01-01T20:00:00Z",
+			count: 2,
+			want:  []string{"2025-01-03T00:00:00+07:00", "2025-01-04T00:00:00+07:00"},
+		},
+		{
+			name:  "when CRON_TZ is given should override the zone",
+			expr:  "CRON_TZ=Asia/Tokyo 0 9 * * *",
+			zone:  "America/New_York",
+			from:  "2024-12-31T23:00:00Z",
+			count: 1,
+			want:  []string{"2025-01-01T09:00:00+09:00"},
+		},
+		{
+			name:  "when @every is used should add real time across a transition",
+			expr:  "@every 90m",
+			zone:  "America/New_York",
+			from:  "2025-03-09T01:00:00-05:00",
+			count: 1,
+			want:  []string{"2025-03-09T03:30:00-04:00"},
+		},
+		{
+			name:  "when expression never fires should return nothing",
+			expr:  "0 0 30 2 *",
+			zone:  "UTC",
+			from:  "2025-01-01T00:00:00Z",
+			count: 3,
+			want:  []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Parse(tc.expr)
+			if !assert.NoError(t, err) {
+				return
+			}
+			loc, err := time.LoadLocation(tc.zone)
+			if !assert.NoError(t, err) {
+				return
+			}
+			from, err := time.Parse(time.RFC3339, tc.from)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			got := []string{}
+			for _, at := range s.List(from, loc, tc.count) {
+				got = append(got, at.Format(time.RFC3339))
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse("61 * * * *")
+	assert.EqualError(t, err, "end of range (61) above maximum (59): 61")
+}
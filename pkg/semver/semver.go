@@ -0,0 +1,30 @@
+// Package semver compares app versions such as "5.2.0", "v5.2" or
+// "6.0.0-beta.1" by Semantic Versioning 2.0 precedence. The "v" prefix is
+// optional, a missing minor or patch counts as 0 and build metadata is
+// ignored.
+package semver
+
+import (
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// Valid reports whether v is a version this package can compare.
+func Valid(v string) bool {
+	return semver.IsValid(canonical(v))
+}
+
+// Compare returns -1, 0 or +1 as a is lower than, equal to or higher than
+// b. A version that is not Valid is lower than every valid one.
+func Compare(a, b string) int {
+	return semver.Compare(canonical(a), canonical(b))
+}
+
+func canonical(v string) string {
+	v = strings.TrimSpace(v)
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	return v
+}
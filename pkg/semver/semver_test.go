@@ -0,0 +1,37 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{name: "when versions are equal should return 0", a: "5.2.0", b: "5.2.0", want: 0},
+		{name: "when prefix and missing patch differ should still be equal", a: "v5.2", b: "5.2.0", want: 0},
+		{name: "when minor compares numerically should not compare as text", a: "5.10.0", b: "5.9.3", want: 1},
+		{name: "when one is a pre-release should rank it lower", a: "6.0.0-beta.1", b: "6.0.0", want: -1},
+		{name: "when pre-release identifiers are numeric should compare them as numbers", a: "6.0.0-beta.11", b: "6.0.0-beta.2", want: 1},
+		{name: "when build metadata differs should ignore it", a: "1.0.0+build.7", b: "1.0.0", want: 0},
+		{name: "when a is invalid should rank it lowest", a: "latest", b: "0.0.1", want: -1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Compare(tc.a, tc.b))
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("5.2.0"))
+	assert.True(t, Valid("v5"))
+	assert.False(t, Valid("5.2.0.1"))
+	assert.False(t, Valid("05.1.0"))
+	assert.False(t, Valid(""))
+}
@@ -0,0 +1,344 @@
+// Package targeting matches evaluation contexts against clauses over their
+// attributes, as used by feature_toggle rules.
+//
+// A clause names an attribute, an operator and one or more values; it
+// matches when the attribute matches any of the values (not_in: none of
+// them). A context without the attribute matches no clause, whatever the
+// operator. The attribute "key" is the context's user key.
+//
+// Clauses are compiled once (regular expressions, versions, numbers and
+// dates parsed up front) so matching does no parsing of its own beyond the
+// context's attribute.
+package targeting
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"configuration-management-service/pkg/semver"
+)
+
+// Operators.
+const (
+	OpIn         = "in"
+	OpNotIn      = "not_in"
+	OpStartsWith = "starts_with"
+	OpMatches    = "matches"
+	OpSemverEq   = "semver_eq"
+	OpSemverLt   = "semver_lt"
+	OpSemverLte  = "semver_lte"
+	OpSemverGt   = "semver_gt"
+	OpSemverGte  = "semver_gte"
+	OpLt         = "lt"
+	OpLte        = "lte"
+	OpGt         = "gt"
+	OpGte        = "gte"
+	OpBefore     = "before"
+	OpAfter      = "after"
+)
+
+// Ops lists every operator, in the order the schema documents them.
+var Ops = []string{
+	OpIn, OpNotIn, OpStartsWith, OpMatches,
+	OpSemverEq, OpSemverLt, OpSemverLte, OpSemverGt, OpSemverGte,
+	OpLt, OpLte, OpGt, OpGte,
+	OpBefore, OpAfter,
+}
+
+// AttrKey names the context's user key in a clause.
+const AttrKey = "key"
+
+// Clause is one condition on a context attribute.
+type Clause struct {
+	Attribute string `json:"attribute"`
+	Op        string `json:"op"`
+	Values    []any  `json:"values"`
+}
+
+// Context is what clauses are matched against.
+type Context struct {
+	Key        string
+	Attributes map[string]any
+}
+
+func (c Context) attribute(name string) (any, bool) {
+	if name == AttrKey {
+		return c.Key, c.Key != ""
+	}
+	v, ok := c.Attributes[name]
+	return v, ok && v != nil
+}
+
+// Error locates a clause, or one of its values, that does not compile.
+type Error struct {
+	Clause int
+	// Value is the index of the offending value, or -1.
+	Value   int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Value < 0 {
+		return fmt.Sprintf("clauses.%d: %s", e.Clause, e.Message)
+	}
+	return fmt.Sprintf("clauses.%d.values.%d: %s", e.Clause, e.Value, e.Message)
+}
+
+// Matcher is a compiled list of clauses; it matches when all of them do.
+type Matcher []matcher
+
+type matcher struct {
+	attribute string
+	negate    bool
+	match     func(attr any) bool
+}
+
+// Compile checks every clause and prepares it for matching.
+func Compile(clauses []Clause) (Matcher, error) {
+	out := make(Matcher, 0, len(clauses))
+	for i, c := range clauses {
+		m, err := compile(c)
+		if err != nil {
+			err.Clause = i
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// Match reports whether ctx satisfies every clause.
+func (m Matcher) Match(ctx Context) bool {
+	for _, c := range m {
+		attr, ok := ctx.attribute(c.attribute)
+		if !ok || c.match(attr) == c.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func compile(c Clause) (matcher, *Error) {
+	if strings.TrimSpace(c.Attribute) == "" {
+		return matcher{}, &Error{Value: -1, Message: "attribute is required"}
+	}
+	if len(c.Values) == 0 {
+		return matcher{}, &Error{Value: -1, Message: "values must not be empty"}
+	}
+	m := matcher{attribute: c.Attribute}
+
+	switch c.Op {
+	case OpIn, OpNotIn:
+		values := c.Values
+		m.negate = c.Op == OpNotIn
+		m.match = func(attr any) bool {
+			for _, v := range values {
+				if equal(attr, v) {
+					return true
+				}
+			}
+			return false
+		}
+
+	case OpStartsWith:
+		prefixes, err := stringValues(c.Values)
+		if err != nil {
+			return matcher{}, err
+		}
+		m.match = func(attr any) bool {
+			s, ok := attr.(string)
+			if !ok {
+				return false
+			}
+			for _, p := range prefixes {
+				if strings.HasPrefix(s, p) {
+					return true
+				}
+			}
+			return false
+		}
+
+	case OpMatches:
+		patterns, err := stringValues(c.Values)
+		if err != nil {
+			return matcher{}, err
+		}
+		res := make([]*regexp.Regexp, len(patterns))
+		for i, p := range patterns {
+			re, cerr := regexp.Compile(p)
+			if cerr != nil {
+				return matcher{}, &Error{Value: i, Message: "invalid regular expression: " + cerr.Error()}
+			}
+			res[i] = re
+		}
+		m.match = func(attr any) bool {
+			s, ok := attr.(string)
+			if !ok {
+				return false
+			}
+			for _, re := range res {
+				if re.MatchString(s) {
+					return true
+				}
+			}
+			return false
+		}
+
+	case OpSemverEq, OpSemverLt, OpSemverLte, OpSemverGt, OpSemverGte:
+		versions, err := stringValues(c.Values)
+		if err != nil {
+			return matcher{}, err
+		}
+		for i, v := range versions {
+			if !semver.Valid(v) {
+				return matcher{}, &Error{Value: i, Message: fmt.Sprintf("%q is not a semantic version", v)}
+			}
+		}
+		holds := comparison(c.Op)
+		m.match = func(attr any) bool {
+			s, ok := attr.(string)
+			if !ok || !semver.Valid(s) {
+				return false
+			}
+			for _, v := range versions {
+				if holds(semver.Compare(s, v)) {
+					return true
+				}
+			}
+			return false
+		}
+
+	case OpLt, OpLte, OpGt, OpGte:
+		bounds := make([]float64, len(c.Values))
+		for i, v := range c.Values {
+			f, ok := v.(float64)
+			if !ok {
+				return matcher{}, &Error{Value: i, Message: "must be a number"}
+			}
+			bounds[i] = f
+		}
+		holds := comparison(c.Op)
+		m.match = func(attr any) bool {
+			f, ok := number(attr)
+			if !ok {
+				return false
+			}
+			for _, b := range bounds {
+				if holds(compareFloat(f, b)) {
+					return true
+				}
+			}
+			return false
+		}
+
+	case OpBefore, OpAfter:
+		times := make([]time.Time, len(c.Values))
+		for i, v := range c.Values {
+			t, ok := instant(v)
+			if !ok {
+				return matcher{}, &Error{Value: i, Message: "must be an RFC 3339 date-time, a date (2006-01-02) or Unix seconds"}
+			}
+			times[i] = t
+		}
+		before := c.Op == OpBefore
+		m.match = func(attr any) bool {
+			t, ok := instant(attr)
+			if !ok {
+				return false
+			}
+			for _, b := range times {
+				if (before && t.Before(b)) || (!before && t.After(b)) {
+					return true
+				}
+			}
+			return false
+		}
+
+	default:
+		return matcher{}, &Error{Value: -1, Message: fmt.Sprintf("unknown operator %q", c.Op)}
+	}
+	return m, nil
+}
+
+// stringValues returns values as strings, or the index of one that is not.
+func stringValues(values []any) ([]string, *Error) {
+	out := make([]string, len(values))
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return nil, &Error{Value: i, Message: "must be a string"}
+		}
+		out[i] = s
+	}
+	return out, nil
+}
+
+// equal compares an attribute with a clause value: strings and booleans
+// exactly, numbers by value whether given as number or numeric string.
+func equal(attr, v any) bool {
+	switch x := v.(type) {
+	case float64:
+		f, ok := number(attr)
+		return ok && f == x
+	default:
+		return attr == v
+	}
+}
+
+// number reads a numeric attribute; numeric strings count, since clients
+// often send every attribute as text.
+func number(v any) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(x), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// instant reads an RFC 3339 date-time, a date (midnight UTC) or Unix
+// seconds.
+func instant(v any) (time.Time, bool) {
+	switch x := v.(type) {
+	case float64:
+		return time.Unix(int64(x), 0).UTC(), true
+	case string:
+		if t, err := time.Parse(time.RFC3339, x); err == nil {
+			return t, true
+		}
+		if t, err := time.Parse("2006-01-02", x); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparison turns an ordering operator into a test on a Compare result.
+func comparison(op string) func(int) bool {
+	switch op {
+	case OpSemverLt, OpLt:
+		return func(c int) bool { return c < 0 }
+	case OpSemverLte, OpLte:
+		return func(c int) bool { return c <= 0 }
+	case OpSemverGt, OpGt:
+		return func(c int) bool { return c > 0 }
+	case OpSemverGte, OpGte:
+		return func(c int) bool { return c >= 0 }
+	}
+	return func(c int) bool { return c == 0 }
+}
@@ -0,0 +1,106 @@
+package targeting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	ctx := Context{Key: "user-42", Attributes: map[string]any{
+		"country":     "ID",
+		"email":       "ana@example.com",
+		"app_version": "5.10.1",
+		"orders":      float64(12),
+		"age":         "31",
+		"signed_up":   "2024-03-15T08:00:00Z",
+		"beta":        true,
+		"nothing":     nil,
+	}}
+
+	cases := []struct {
+		name    string
+		clauses []Clause
+		want    bool
+	}{
+		{name: "when there are no clauses should match", want: true},
+		{name: "when in lists the value should match", clauses: []Clause{{"country", OpIn, []any{"SG", "ID"}}}, want: true},
+		{name: "when in does not list the value should not match", clauses: []Clause{{"country", OpIn, []any{"SG"}}}},
+		{name: "when in compares booleans should match exactly", clauses: []Clause{{"beta", OpIn, []any{true}}}, want: true},
+		{name: "when in compares a number with a numeric string should match by value", clauses: []Clause{{"age", OpIn, []any{float64(31)}}}, want: true},
+		{name: "when in names the key attribute should match the user key", clauses: []Clause{{"key", OpIn, []any{"user-42"}}}, want: true},
+		{name: "when not_in does not list the value should match", clauses: []Clause{{"country", OpNotIn, []any{"SG"}}}, want: true},
+		{name: "when not_in lists the value should not match", clauses: []Clause{{"country", OpNotIn, []any{"ID"}}}},
+		{name: "when not_in attribute is missing should not match", clauses: []Clause{{"plan", OpNotIn, []any{"free"}}}},
+		{name: "when attribute is null should not match", clauses: []Clause{{"nothing", OpNotIn, []any{"x"}}}},
+		{name: "when starts_with any prefix should match", clauses: []Clause{{"email", OpStartsWith, []any{"bob", "ana@"}}}, want: true},
+		{name: "when starts_with attribute is not a string should not match", clauses: []Clause{{"orders", OpStartsWith, []any{"1"}}}},
+		{name: "when matches the pattern should match", clauses: []Clause{{"email", OpMatches, []any{`@example\.com$`}}}, want: true},
+		{name: "when does not match the pattern should not match", clauses: []Clause{{"email", OpMatches, []any{`^bob@`}}}},
+		{name: "when semver_gte compares numerically should match", clauses: []Clause{{"app_version", OpSemverGte, []any{"5.9.0"}}}, want: true},
+		{name: "when semver_lt is not met should not match", clauses: []Clause{{"app_version", OpSemverLt, []any{"5.10.1"}}}},
+		{name: "when semver_lte value has a v prefix should match", clauses: []Clause{{"app_version", OpSemverLte, []any{"v5.10.1"}}}, want: true},
+		{name: "when semver attribute is not a version should not match", clauses: []Clause{{"country", OpSemverGt, []any{"0.0.1"}}}},
+		{name: "when gt holds for a number should match", clauses: []Clause{{"orders", OpGt, []any{float64(10)}}}, want: true},
+		{name: "when lte holds for a numeric string should match", clauses: []Clause{{"age", OpLte, []any{float64(31)}}}, want: true},
+		{name: "when lt does not hold should not match", clauses: []Clause{{"orders", OpLt, []any{float64(12)}}}},
+		{name: "when numeric attribute is not a number should not match", clauses: []Clause{{"email", OpGte, []any{float64(0)}}}},
+		{name: "when before a date should match", clauses: []Clause{{"signed_up", OpBefore, []any{"2024-04-01"}}}, want: true},
+		{name: "when after an instant should not match an earlier one", clauses: []Clause{{"signed_up", OpAfter, []any{"2024-03-15T09:00:00+00:00"}}}},
+		{name: "when after Unix seconds should match", clauses: []Clause{{"signed_up", OpAfter, []any{float64(1700000000)}}}, want: true},
+		{name: "when every clause matches should match", clauses: []Clause{{"country", OpIn, []any{"ID"}}, {"orders", OpGte, []any{float64(5)}}}, want: true},
+		{name: "when one clause fails should not match", clauses: []Clause{{"country", OpIn, []any{"ID"}}, {"orders", OpGte, []any{float64(50)}}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := Compile(tc.clauses)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, m.Match(ctx))
+		})
+	}
+}
+
+func TestMatcher_Match_missingKey(t *testing.T) {
+	m, err := Compile([]Clause{{"key", OpNotIn, []any{"user-1"}}})
+	assert.NoError(t, err)
+	assert.False(t, m.Match(Context{}))
+}
+
+func TestCompile(t *testing.T) {
+	cases := []struct {
+		name    string
+		clauses []Clause
+		err     string
+	}{
+		{name: "when operator is unknown should fail on the clause", clauses: []Clause{{"country", "equals", []any{"ID"}}}, err: `clauses.0: unknown operator "equals"`},
+		{name: "when attribute is blank should fail", clauses: []Clause{{" ", OpIn, []any{"ID"}}}, err: "clauses.0: attribute is required"},
+		{name: "when values are empty should fail", clauses: []Clause{{"country", OpIn, nil}}, err: "clauses.0: values must not be empty"},
+		{name: "when regex is invalid should fail on the value", clauses: []Clause{{"a", OpIn, []any{"x"}}, {"email", OpMatches, []any{"ok", "("}}}, err: "clauses.1.values.1: invalid regular expression: error parsing regexp: missing closing ): `(`"},
+		{name: "when semver value is invalid should fail on the value", clauses: []Clause{{"app_version", OpSemverGte, []any{"latest"}}}, err: `clauses.0.values.0: "latest" is not a semantic version`},
+		{name: "when numeric value is a string should fail on the value", clauses: []Clause{{"orders", OpGt, []any{"10"}}}, err: "clauses.0.values.0: must be a number"},
+		{name: "when date value does not parse should fail on the value", clauses: []Clause{{"signed_up", OpBefore, []any{"next week"}}}, err: "clauses.0.values.0: must be an RFC 3339 date-time, a date (2006-01-02) or Unix seconds"},
+		{name: "when string operator gets a number should fail on the value", clauses: []Clause{{"email", OpStartsWith, []any{float64(1)}}}, err: "clauses.0.values.0: must be a string"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Compile(tc.clauses)
+			if assert.Error(t, err) {
+				assert.Equal(t, tc.err, err.Error())
+			}
+		})
+	}
+}
+
+func BenchmarkMatcher_Match(b *testing.B) {
+	m, _ := Compile([]Clause{
+		{"country", OpIn, []any{"ID", "SG", "MY"}},
+		{"app_version", OpSemverGte, []any{"5.2.0"}},
+		{"email", OpMatches, []any{`@example\.com$`}},
+	})
+	ctx := Context{Key: "user-1", Attributes: map[string]any{"country": "SG", "app_version": "5.10.0", "email": "a@example.com"}}
+	for i := 0; i < b.N; i++ {
+		m.Match(ctx)
+	}
+}